@@ -0,0 +1,104 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import "strings"
+
+//-----------------------------------------------------------------------------
+
+// The historical and deprecated names of the predefined algorithms, from the CRC RevEng
+// catalogue and other libraries, with the name of the algorithm in the catalogue.
+// Names are matched in their normalized form.
+var aliases = [...][2]string{
+	{"ARC", "CRC-16/ARC"},
+	{"CRC-16", "CRC-16/ARC"},
+	{"CRC-16/ANSI", "CRC-16/ARC"},
+	{"CRC-16/IBM", "CRC-16/ARC"},
+	{"CRC-16/LHA", "CRC-16/ARC"},
+	{"CRC-IBM", "CRC-16/ARC"},
+	{"R-CRC-16", "CRC-16/DECT-R"},
+	{"X-CRC-16", "CRC-16/DECT-X"},
+	{"CRC-16/DARC", "CRC-16/GENIBUS"},
+	{"CRC-16/EPC", "CRC-16/GENIBUS"},
+	{"CRC-16/EPC-C1G2", "CRC-16/GENIBUS"},
+	{"CRC-16/I-CODE", "CRC-16/GENIBUS"},
+	{"CRC-16/AUTOSAR", "CRC-16/IBM-3740"},
+	{"CRC-16/CCITT-FALSE", "CRC-16/IBM-3740"},
+	{"CRC-16/ISO-HDLC", "CRC-16/IBM-SDLC"},
+	{"CRC-16/ISO-IEC-14443-3-B", "CRC-16/IBM-SDLC"},
+	{"CRC-16/X-25", "CRC-16/IBM-SDLC"},
+	{"CRC-B", "CRC-16/IBM-SDLC"},
+	{"CRC-16/CRC-A", "CRC-16/ISO-IEC-14443-3-A"},
+	{"CRC-A", "CRC-16/ISO-IEC-14443-3-A"},
+	{"CRC-16/BLUETOOTH", "CRC-16/KERMIT"},
+	{"CRC-16/CCITT", "CRC-16/KERMIT"},
+	{"CRC-16/CCITT-TRUE", "CRC-16/KERMIT"},
+	{"CRC-16/V-41-LSB", "CRC-16/KERMIT"},
+	{"CRC-CCITT", "CRC-16/KERMIT"},
+	{"CRC-16/MAXIM", "CRC-16/MAXIM-DOW"},
+	{"CRC-16/IEC-61158-2", "CRC-16/PROFIBUS"},
+	{"CRC-16/AUG-CCITT", "CRC-16/SPI-FUJITSU"},
+	{"CRC-16/BUYPASS", "CRC-16/UMTS"},
+	{"CRC-16/VERIFONE", "CRC-16/UMTS"},
+	{"CRC-16/ACORN", "CRC-16/XMODEM"},
+	{"CRC-16/LTE", "CRC-16/XMODEM"},
+	{"CRC-16/V-41-MSB", "CRC-16/XMODEM"},
+	{"ZMODEM", "CRC-16/XMODEM"},
+}
+
+//-----------------------------------------------------------------------------
+
+// Returns the name in upper case without the separators spelled inconsistently
+// across libraries, so that e.g. "crc16-ansi" matches "CRC-16/ANSI".
+func normalize(aName string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', '_', '/', ' ', '.':
+			return -1
+		}
+		return r
+	}, strings.ToUpper(aName))
+}
+
+//--------------------------------------
+
+// AlgoByName returns the predefined or registered algorithm with the specified name.
+// Names are matched case-insensitively, regardless of separators and with or without
+// the "CRC-16/" prefix, e.g. "modbus" matches "CRC-16/MODBUS". Historical or deprecated
+// names, e.g. "CRC-16/AUTOSAR" for CRC-16/IBM-3740, are resolved when no algorithm bears them.
+// The algorithms searched are those of Algorithms: the families built in and the custom
+// ones loaded by LoadCatalog.
+func AlgoByName(aName string) (*TAlgo, bool) {
+	vName := normalize(aName)
+	for _, n := range [...]string{vName, "CRC16" + vName} {
+		for a := range predefined() {
+			if normalize(a.Name) == n {
+				return a, true
+			}
+		}
+	}
+	for _, n := range [...]string{vName, "CRC16" + vName} {
+		for _, a := range aliases {
+			if normalize(a[0]) == n {
+				return AlgoByName(a[1])
+			}
+		}
+	}
+	return nil, false
+}
+
+//--------------------------------------
+
+// Aliases returns the historical and deprecated names resolving to the predefined algorithm
+// with the specified name, e.g. CRC-16/X-25 for CRC-16/IBM-SDLC.
+func Aliases(aName string) []string {
+	var vRet []string
+	for _, a := range aliases {
+		if normalize(a[1]) == normalize(aName) {
+			vRet = append(vRet, a[0])
+		}
+	}
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,270 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import "math"
+
+//-----------------------------------------------------------------------------
+
+// This file contains facilities analysing the properties of CRC polynomials.
+
+//-----------------------------------------------------------------------------
+
+// Period returns the multiplicative order of x modulo the generator polynomial of the algorithm,
+// i.e. the smallest e such that x^e = 1 (mod P(x)).
+//
+// Codewords (message and checksum) longer than Period bits are no longer guaranteed
+// to detect all two-bit errors, so it bounds the useful frame length of the polynomial.
+// It returns 0 for polynomials without the x^0 term, which have no period.
+func Period(aAlgo TAlgo) int {
+	if aAlgo.Poly&1 == 0 {
+		return 0
+	}
+	vR := uint32(1)
+	for e := 1; ; e++ {
+		vR <<= 1
+		if vR&0x10000 != 0 {
+			vR ^= 0x10000 | uint32(aAlgo.Poly)
+		}
+		if vR == 1 {
+			return e
+		}
+	}
+}
+
+//-----------------------------------------------------------------------------
+
+// Returns the rank of the linear map from messages of aLen bytes to their checksums,
+// i.e. the base 2 logarithm of the number of distinct checksums such messages produce.
+func checksumRank(aAlgo TAlgo, aLen int) int {
+	vTable := MakeTable(aAlgo)
+	vBase := Complete(0, vTable)
+	vZero := []byte{0}
+
+	var vRegs [8]uint16
+	for b := range vRegs {
+		vRegs[b] = Update(0, []byte{1 << b}, vTable)
+	}
+
+	// Basis of the syndrome space, indexed by the leading bit.
+	var vBasis [16]uint16
+	vRank := 0
+	for i := 0; i < aLen && vRank < 16; i++ {
+		for b := range vRegs {
+			s := Complete(vRegs[b], vTable) ^ vBase
+			for k := 15; k >= 0 && s != 0; k-- {
+				if s&(1<<k) == 0 {
+					continue
+				}
+				if vBasis[k] == 0 {
+					vBasis[k] = s
+					vRank++
+					break
+				}
+				s ^= vBasis[k]
+			}
+			vRegs[b] = Update(vRegs[b], vZero, vTable)
+		}
+	}
+	return vRank
+}
+
+//--------------------------------------
+
+// CollisionProbability returns the probability that two uniformly random messages
+// of aLen bytes have the same checksum under the specified algorithm.
+//
+// For messages of two or more bytes it is 2^-16 for every well-formed algorithm;
+// shorter messages never collide unless they are equal.
+func CollisionProbability(aAlgo TAlgo, aLen int) float64 {
+	return math.Ldexp(1, -checksumRank(aAlgo, aLen))
+}
+
+//--------------------------------------
+
+// BirthdayProbability returns the probability that at least two of aCount uniformly random
+// messages of aLen bytes share a checksum under the specified algorithm.
+func BirthdayProbability(aAlgo TAlgo, aLen int, aCount int64) float64 {
+	vSpace := math.Ldexp(1, checksumRank(aAlgo, aLen))
+	if float64(aCount) > vSpace {
+		return 1
+	}
+	vUnique := 1.0
+	for i := int64(1); i < aCount; i++ {
+		vUnique *= 1 - float64(i)/vSpace
+	}
+	return 1 - vUnique
+}
+
+//--------------------------------------
+
+// BirthdayBound returns the smallest number of uniformly random messages of aLen bytes
+// for which the probability of a shared checksum reaches aProb.
+// It is the practical limit of the number of items CRC-16 can fingerprint at that risk.
+func BirthdayBound(aAlgo TAlgo, aLen int, aProb float64) int64 {
+	vSpace := math.Ldexp(1, checksumRank(aAlgo, aLen))
+	vUnique := 1.0
+	vCount := int64(1)
+	for 1-vUnique < aProb && float64(vCount) <= vSpace {
+		vUnique *= 1 - float64(vCount)/vSpace
+		vCount++
+	}
+	return vCount
+}
+
+//-----------------------------------------------------------------------------
+
+// TErrorProfile is the error detection capability of an algorithm for messages of a given length,
+// in the spirit of Koopman's tables of CRC polynomials, for choosing a polynomial for a frame format.
+type TErrorProfile struct {
+	Bits            int      // Codeword length in bits, message and checksum.
+	HammingDistance int      // Smallest number of bit errors that can go undetected, or 0 if none can.
+	Undetected      [5]int64 // Undetected[k] is the number of undetected k-bit error patterns, 1 <= k <= 4.
+}
+
+//-----------------------------------------------------------------------------
+
+// Returns the syndromes x^i mod P(x) of the single-bit errors of a codeword of aBits bits,
+// bit i standing for the coefficient of x^i, i.e. transmitted aBits-1-i bits before the end.
+func errorSyndromes(aPoly uint16, aBits int) []uint16 {
+	vRet := make([]uint16, aBits)
+	vR := uint32(1)
+	for i := range vRet {
+		vRet[i] = uint16(vR)
+		vR <<= 1
+		if vR&0x10000 != 0 {
+			vR ^= 0x10000 | uint32(aPoly)
+		}
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// HammingDistance returns the smallest number of bit errors in a codeword of a message
+// of aLen bytes and its checksum that the algorithm can fail to detect, or 0 if it detects
+// all errors, which is only the case for empty messages. Init, XorOut and reflection
+// have no influence on it.
+//
+// It looks for the smallest set of single-bit error syndromes summing to zero by meeting
+// sets of m-1 and m errors in the middle; the Hamming bound keeps m small for long messages.
+func HammingDistance(aAlgo TAlgo, aLen int) int {
+	vSyn := errorSyndromes(aAlgo.Poly, 8*aLen+16)
+	vPrev := new([1 << 16]bool)
+	vPrev[0] = true
+	for m := 1; m <= len(vSyn); m++ {
+		// Sets of m errors whose sum equals that of a set of m-1 errors, or of another set
+		// of m errors, make 2m-1 or 2m errors going undetected, the sets being disjoint
+		// as no fewer errors do.
+		vCur := new([1 << 16]bool)
+		vOdd, vEven := false, false
+		var vWalk func(aFrom, aLeft int, aSum uint16)
+		vWalk = func(aFrom, aLeft int, aSum uint16) {
+			if aLeft == 0 {
+				if vPrev[aSum] {
+					vOdd = true
+				} else if vCur[aSum] {
+					vEven = true
+				}
+				vCur[aSum] = true
+				return
+			}
+			for i := aFrom; i <= len(vSyn)-aLeft && !vOdd; i++ {
+				vWalk(i+1, aLeft-1, aSum^vSyn[i])
+			}
+		}
+		vWalk(0, m, 0)
+		if vOdd {
+			return 2*m - 1
+		}
+		if vEven {
+			return 2 * m
+		}
+		vPrev = vCur
+	}
+	return 0
+}
+
+//--------------------------------------
+
+// UndetectedErrors returns the number of the error patterns of k bits in a codeword of a message
+// of aLen bytes and its checksum that the algorithm does not detect, the weight of the polynomial
+// in Koopman's terms. It panics unless 1 <= k <= 4. It runs in O(n^2) time for n codeword bits.
+func UndetectedErrors(aAlgo TAlgo, aLen int, k int) int64 {
+	if k < 1 || k > 4 {
+		panic("crc16: error weight out of range")
+	}
+	vSyn := errorSyndromes(aAlgo.Poly, 8*aLen+16)
+	n := int64(len(vSyn))
+	vCount := make([]int64, 1<<16)
+	var vRet int64
+	switch k {
+	case 1:
+		for _, s := range vSyn {
+			if s == 0 {
+				vRet++
+			}
+		}
+	case 2:
+		for _, s := range vSyn {
+			vRet += vCount[s]
+			vCount[s]++
+		}
+	case 3:
+		// vCount holds the syndromes of the errors after j.
+		for _, s := range vSyn {
+			vCount[s]++
+		}
+		for j, s := range vSyn {
+			vCount[s]--
+			for _, r := range vSyn[:j] {
+				vRet += vCount[r^s]
+			}
+		}
+	case 4:
+		// Every undetected pattern splits into two pairs of equal sums in three ways; pairs of
+		// pairs sharing an error are counted out, they make one undetected 2-bit pattern each
+		// for every other error.
+		for j, s := range vSyn {
+			for _, r := range vSyn[:j] {
+				vRet += vCount[r^s]
+				vCount[r^s]++
+			}
+		}
+		vRet = (vRet - UndetectedErrors(aAlgo, aLen, 2)*(n-2)) / 3
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// BurstDetection returns the fraction of the error bursts of aBurst bits, i.e. error patterns
+// whose first and last bits are aBurst bits apart counting both, that the algorithm detects,
+// for algorithms whose polynomial has the x^0 term. Bursts count in the order the bits are
+// shifted into the register, least significant first for algorithms with reflected input.
+func BurstDetection(aAlgo TAlgo, aBurst int) float64 {
+	switch {
+	case aAlgo.Poly&1 == 0 || aBurst <= 0:
+		return 0
+	case aBurst <= 16:
+		return 1
+	case aBurst == 17:
+		// The only undetected burst is the polynomial itself, out of 2^15.
+		return 1 - math.Ldexp(1, -15)
+	default:
+		return 1 - math.Ldexp(1, -16)
+	}
+}
+
+//--------------------------------------
+
+// ErrorProfile returns the error detection capability of the algorithm for messages of aLen bytes.
+func ErrorProfile(aAlgo TAlgo, aLen int) TErrorProfile {
+	vRet := TErrorProfile{Bits: 8*aLen + 16, HammingDistance: HammingDistance(aAlgo, aLen)}
+	for k := 1; k < len(vRet.Undetected); k++ {
+		vRet.Undetected[k] = UndetectedErrors(aAlgo, aLen, k)
+	}
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,111 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nocatalogue || (crc16_ccitt && crc16_ibm && crc16_misc)
+
+package crc16
+
+import (
+	"math/bits"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestPeriod(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		So(Period(CRC16_XMODEM), ShouldEqual, 32767)
+		So(Period(CRC16_ARC), ShouldEqual, 32767)
+		So(Period(TAlgo{Poly: 0x1020}), ShouldEqual, 0)
+
+		// The period divides the order of the multiplicative group.
+		vPeriod := Period(CRC16_DNP)
+		So(vPeriod, ShouldBeGreaterThan, 0)
+		So(vPeriod, ShouldBeLessThanOrEqualTo, 65535)
+	})
+}
+
+//--------------------------------------
+
+func TestCollisionProbability(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		So(CollisionProbability(CRC16_XMODEM, 0), ShouldEqual, 1)
+		So(CollisionProbability(CRC16_XMODEM, 1), ShouldEqual, 1.0/256)
+		So(CollisionProbability(CRC16_MODBUS, 9), ShouldEqual, 1.0/65536)
+
+		So(BirthdayProbability(CRC16_MODBUS, 9, 1), ShouldEqual, 0)
+		So(BirthdayProbability(CRC16_MODBUS, 9, 2), ShouldAlmostEqual, 1.0/65536)
+		So(BirthdayProbability(CRC16_MODBUS, 9, 65537), ShouldEqual, 1)
+		So(BirthdayProbability(CRC16_MODBUS, 1, 257), ShouldEqual, 1)
+
+		So(BirthdayBound(CRC16_MODBUS, 9, 0.5), ShouldEqual, 302)
+		So(BirthdayBound(CRC16_MODBUS, 1, 0.5), ShouldEqual, 20)
+		So(BirthdayProbability(CRC16_MODBUS, 9, 302), ShouldBeGreaterThanOrEqualTo, 0.5)
+		So(BirthdayProbability(CRC16_MODBUS, 9, 301), ShouldBeLessThan, 0.5)
+	})
+}
+
+//--------------------------------------
+
+func TestErrorProfile(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		for _, a := range []TAlgo{CRC16_XMODEM, CRC16_CDMA2000, CRC16_DECT_R} {
+			// All error patterns of a codeword of one byte and its checksum.
+			const cBits = 24
+			vSyn := errorSyndromes(a.Poly, cBits)
+			var vWeights [cBits + 1]int64
+			var vSum uint16
+			var vErr uint32
+			for i := uint32(1); i < 1<<cBits; i++ {
+				b := bits.TrailingZeros32(i)
+				vErr ^= 1 << b
+				vSum ^= vSyn[b]
+				if vSum == 0 {
+					vWeights[bits.OnesCount32(vErr)]++
+				}
+			}
+			vHD := 0
+			for k := len(vWeights) - 1; k > 0; k-- {
+				if vWeights[k] > 0 {
+					vHD = k
+				}
+			}
+			vProfile := ErrorProfile(a, 1)
+			So(vProfile.Bits, ShouldEqual, cBits)
+			So(vProfile.HammingDistance, ShouldEqual, vHD)
+			So(vProfile.Undetected[1:], ShouldResemble, vWeights[1:5])
+
+			// Four-bit patterns of a longer codeword.
+			vSyn = errorSyndromes(a.Poly, 40)
+			var vW4 int64
+			for i := range vSyn {
+				for j := range i {
+					for k := range j {
+						for l := range k {
+							if vSyn[i]^vSyn[j]^vSyn[k]^vSyn[l] == 0 {
+								vW4++
+							}
+						}
+					}
+				}
+			}
+			So(UndetectedErrors(a, 3, 4), ShouldEqual, vW4)
+		}
+
+		// Polynomials with the x+1 factor detect odd numbers of errors within their period.
+		So(HammingDistance(CRC16_XMODEM, 100), ShouldEqual, 4)
+		So(UndetectedErrors(CRC16_XMODEM, 100, 3), ShouldEqual, 0)
+		So(HammingDistance(CRC16_XMODEM, 4096), ShouldEqual, 2)
+		So(UndetectedErrors(CRC16_XMODEM, 4096, 2), ShouldEqual, 8*4096+16-Period(CRC16_XMODEM))
+		So(HammingDistance(CRC16_XMODEM, 0), ShouldEqual, 0)
+		So(func() { UndetectedErrors(CRC16_XMODEM, 1, 5) }, ShouldPanic)
+
+		So(BurstDetection(CRC16_ARC, 16), ShouldEqual, 1)
+		So(BurstDetection(CRC16_ARC, 17), ShouldEqual, 1-1.0/32768)
+		So(BurstDetection(CRC16_ARC, 40), ShouldEqual, 1-1.0/65536)
+		So(BurstDetection(TAlgo{Poly: 0x1020}, 8), ShouldEqual, 0)
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,127 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import (
+	"errors"
+	"math/bits"
+)
+
+//-----------------------------------------------------------------------------
+
+// ErrEngineDivergence is returned by ChecksumRedundant when the engines disagree.
+var ErrEngineDivergence = errors.New("crc16: table and bitwise engines diverge")
+
+//-----------------------------------------------------------------------------
+
+// ChecksumBitwise returns CRC checksum of data using the specified algorithm, computed
+// one bit at a time straight from the parameters of the Rocksoft model. It is much slower
+// than Checksum and serves as the reference to validate table-driven implementations against.
+func ChecksumBitwise(data []byte, aAlgo TAlgo) uint16 {
+	return aAlgo.complete(updateBitwise(aAlgo.Init<<aAlgo.pad(), data, &aAlgo))
+}
+
+//--------------------------------------
+
+// Returns the register crc after shifting in the bytes of data one bit at a time.
+func updateBitwise(crc uint16, data []byte, aAlgo *TAlgo) uint16 {
+	vPoly := aAlgo.Poly << aAlgo.pad()
+	for _, d := range data {
+		for i := 0; i < 8; i++ {
+			var vBit uint16
+			if aAlgo.RefIn {
+				vBit = uint16(d>>i) & 1
+			} else {
+				vBit = uint16(d>>(7-i)) & 1
+			}
+			vHigh := crc>>15 ^ vBit
+			crc <<= 1
+			if vHigh != 0 {
+				crc ^= vPoly
+			}
+		}
+	}
+	return crc
+}
+
+//--------------------------------------
+
+// UpdateBits returns the result of adding the first nbits bits of data to the crc, for
+// messages whose length is not a multiple of 8 bits as in some radio and telecom framings.
+// The bits of the last, partial byte are taken most significant first from its high bits,
+// or least significant first from its low bits for algorithms with reflected input,
+// as they are transmitted. It panics if data holds fewer than nbits bits.
+func UpdateBits(crc uint16, data []byte, nbits int, aTable *TTable) uint16 {
+	if nbits < 0 || nbits > 8*len(data) {
+		panic("crc16: bit count out of range")
+	}
+	crc = Update(crc, data[:nbits/8], aTable)
+	if n := nbits % 8; n > 0 {
+		d := data[nbits/8]
+		if aTable.algo.RefIn {
+			d = bits.Reverse8(d)
+		}
+		vPoly := aTable.algo.Poly << aTable.algo.pad()
+		for i := 7; i >= 8-n; i-- {
+			vHigh := crc>>15 ^ uint16(d>>i)&1
+			crc <<= 1
+			if vHigh != 0 {
+				crc ^= vPoly
+			}
+		}
+	}
+	return crc
+}
+
+//--------------------------------------
+
+// Augmented returns the algorithm in the direct form used by the package equivalent to aAlgo
+// defined in the classical augmented form, where the register is loaded with Init, the message
+// bits are shifted into it followed by 16 zero bits, and the remainder is the CRC.
+// Some legacy specifications and hardware shift registers are defined that way, e.g. the
+// augmented Init 0xFFFF of the CCITT polynomial is the direct Init 0x1D0F of CRC-16/SPI-FUJITSU.
+func Augmented(aAlgo TAlgo) TAlgo {
+	aAlgo.Init = aAlgo.shiftZeros(aAlgo.Init)
+	return aAlgo
+}
+
+//--------------------------------------
+
+// NewAlgo returns the algorithm of the specified parameters with the Check value computed,
+// bitwise, as the checksum of "123456789", for custom algorithms to pass validation without
+// it being worked out separately. Checksums are conventionally transmitted per TrailerAuto.
+func NewAlgo(aName string, aPoly, aInit uint16, aRefIn, aRefOut bool, aXorOut uint16) TAlgo {
+	vRet := TAlgo{Poly: aPoly, Init: aInit, RefIn: aRefIn, RefOut: aRefOut, XorOut: aXorOut, Name: aName}
+	vRet.Check = ChecksumBitwise([]byte("123456789"), vRet)
+	return vRet
+}
+
+//--------------------------------------
+
+// ChecksumRedundant returns CRC checksum of data computed by both Checksum and ChecksumBitwise,
+// or ErrEngineDivergence if they differ, for functional-safety builds guarding against
+// systematic faults such as a corrupt table. It costs the speed of the bitwise engine.
+func ChecksumRedundant(data []byte, aTable *TTable) (uint16, error) {
+	vSum := Checksum(data, aTable)
+	if ChecksumBitwise(data, aTable.algo) != vSum {
+		return 0, ErrEngineDivergence
+	}
+	return vSum, nil
+}
+
+//--------------------------------------
+
+// Returns the register after shifting in the 16 bits of v, most significant first,
+// one bit at a time.
+func shiftBitwise(aReg, v, aPoly uint16) uint16 {
+	for i := 15; i >= 0; i-- {
+		vHigh := aReg>>15 ^ v>>i&1
+		aReg <<= 1
+		if vHigh != 0 {
+			aReg ^= aPoly
+		}
+	}
+	return aReg
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,127 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nocatalogue || (crc16_ccitt && crc16_ibm)
+
+package crc16
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestChecksumBitwise(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vRand := rand.New(rand.NewSource(11))
+		for _, a := range Algorithms() {
+			So(ChecksumBitwise([]byte("123456789"), a), ShouldEqual, a.Check)
+
+			vTable := MakeTable(a)
+			for n := 0; n < 40; n++ {
+				vData := make([]byte, n)
+				vRand.Read(vData)
+				So(ChecksumBitwise(vData, a), ShouldEqual, Checksum(vData, vTable))
+			}
+		}
+	})
+}
+
+//--------------------------------------
+
+func TestChecksumRedundant(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vData := []byte("123456789")
+		for _, a := range Algorithms() {
+			vSum, err := ChecksumRedundant(vData, MakeTable(a))
+			So(err, ShouldBeNil)
+			So(vSum, ShouldEqual, a.Check)
+		}
+
+		vTable := MakeTable(TAlgo{Poly: 0x1021, Init: 0xFFFF})
+		for i := range vTable.data {
+			vTable.data[i] ^= 0x0010
+		}
+		_, err := ChecksumRedundant(vData, vTable)
+		So(err, ShouldEqual, ErrEngineDivergence)
+	})
+}
+
+//--------------------------------------
+
+func TestAugmented(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		// The classical definition: message bits enter the register from the bottom.
+		vAugmented := func(data []byte, aPoly, aInit uint16) uint16 {
+			vReg := aInit
+			for _, d := range append(data, 0, 0) {
+				for i := 7; i >= 0; i-- {
+					vTop := vReg >> 15
+					vReg = vReg<<1 | uint16(d>>i&1)
+					if vTop != 0 {
+						vReg ^= aPoly
+					}
+				}
+			}
+			return vReg
+		}
+
+		vAlgo := Augmented(TAlgo{Poly: 0x1021, Init: 0xFFFF})
+		So(vAlgo.Init, ShouldEqual, 0x1D0F)
+		So(Checksum([]byte("123456789"), MakeTable(vAlgo)), ShouldEqual, CRC16_SPI_FUJITSU.Check)
+		for _, vInit := range []uint16{0, 0xFFFF, 0x1234, 0x8000} {
+			for _, vPoly := range []uint16{0x1021, 0x8005, 0x3D65} {
+				vTable := MakeTable(Augmented(TAlgo{Poly: vPoly, Init: vInit}))
+				for _, vData := range []string{"", "a", "123456789"} {
+					So(Checksum([]byte(vData), vTable), ShouldEqual, vAugmented([]byte(vData), vPoly, vInit))
+				}
+			}
+		}
+	})
+}
+
+//--------------------------------------
+
+func TestUpdateBits(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vData := []byte("123456789")
+		for _, vAlgo := range []TAlgo{{Poly: 0x1021, Init: 0xFFFF}, {Poly: 0x8005, Init: 0xFFFF, RefIn: true, RefOut: true}} {
+			vTable := MakeTable(vAlgo)
+			So(UpdateBits(Init(vTable), vData, 72, vTable), ShouldEqual, Update(Init(vTable), vData, vTable))
+
+			// Shifting in the rest of the last byte as zeros is feeding it with those bits cleared.
+			for n := 1; n < 8; n++ {
+				vCrc := UpdateBits(Init(vTable), vData, 64+n, vTable)
+				vCrc = UpdateBits(vCrc, []byte{0}, 8-n, vTable)
+				vLast := vData[8] &^ (0xFF >> n)
+				if vAlgo.RefIn {
+					vLast = vData[8] & (0xFF >> (8 - n))
+				}
+				So(vCrc, ShouldEqual, Update(Init(vTable), append(vData[:8:8], vLast), vTable))
+			}
+		}
+		So(func() { UpdateBits(0, vData, 73, MakeTable(CRC16_XMODEM)) }, ShouldPanic)
+	})
+}
+
+//--------------------------------------
+
+func TestNewAlgo(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		for _, a := range []TAlgo{CRC16_MODBUS, CRC16_XMODEM, CRC16_X_25} {
+			vAlgo := NewAlgo(a.Name, a.Poly, a.Init, a.RefIn, a.RefOut, a.XorOut)
+			So(vAlgo.Check, ShouldEqual, a.Check)
+			So(vAlgo.Name, ShouldEqual, a.Name)
+			So(vAlgo.Validate(), ShouldBeNil)
+		}
+
+		vAlgo := NewAlgo("CRC-16/VENDOR", 0x3D65, 0x1234, true, false, 0x00FF)
+		So(vAlgo.Validate(), ShouldBeNil)
+		So(vAlgo.Trailer, ShouldEqual, TrailerAuto)
+		So(Checksum([]byte("123456789"), MakeTable(vAlgo)), ShouldEqual, vAlgo.Check)
+	})
+}
+
+//-----------------------------------------------------------------------------
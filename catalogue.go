@@ -0,0 +1,95 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import (
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+//-----------------------------------------------------------------------------
+
+// This file contains the catalogue of predefined algorithms, which is split into families
+// by polynomial so that size-critical programs can leave out the ones they do not use.
+// Building with the crc16_nocatalogue tag leaves out all families except those selected
+// by the crc16_ccitt, crc16_ibm and crc16_misc tags.
+//
+// Related links:
+// - http://www.zlib.net/crc_v3.txt
+// - http://reveng.sourceforge.net/crc-catalogue/16.htm
+// - https://crccalc.com/?crc=123456789&method=CRC-16&datatype=ascii&outtype=hex
+
+// The predefined algorithms of the families built in, each in the catalogue order.
+// Families left out are empty.
+var ccittAlgos, ibmAlgos, miscAlgos []*TAlgo
+
+// The custom algorithms registered by LoadCatalog, in the loading order. The slice is replaced
+// as a whole under registeredMu, so that it can be read without locking.
+var (
+	registered   atomic.Pointer[[]*TAlgo]
+	registeredMu sync.Mutex
+)
+
+//-----------------------------------------------------------------------------
+
+// Returns the predefined algorithms built in, in the catalogue order, followed by
+// the registered ones. The catalogue is ordered by polynomial and no two families share one,
+// so the families are merged by polynomial.
+func predefined() iter.Seq[*TAlgo] {
+	return func(yield func(*TAlgo) bool) {
+		vFamilies := [...][]*TAlgo{ccittAlgos, ibmAlgos, miscAlgos}
+		for {
+			vNext := -1
+			for i, f := range vFamilies {
+				if len(f) > 0 && (vNext < 0 || f[0].Poly < vFamilies[vNext][0].Poly) {
+					vNext = i
+				}
+			}
+			if vNext < 0 {
+				break
+			}
+			if !yield(vFamilies[vNext][0]) {
+				return
+			}
+			vFamilies[vNext] = vFamilies[vNext][1:]
+		}
+		if r := registered.Load(); r != nil {
+			for _, a := range *r {
+				if !yield(a) {
+					return
+				}
+			}
+		}
+	}
+}
+
+//--------------------------------------
+
+// Algorithms returns the predefined algorithms in the catalogue order, followed by
+// the custom ones loaded by LoadCatalog. Of the predefined ones, only the families built in
+// are included.
+func Algorithms() []TAlgo {
+	var vRet []TAlgo
+	for a := range predefined() {
+		vRet = append(vRet, *a)
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// FindByCheck returns the algorithms whose checksum of "123456789", the Check value quoted
+// by datasheets, is aCheck, in the order of Algorithms: the predefined ones of the families
+// built in, then the custom ones loaded by LoadCatalog.
+func FindByCheck(aCheck uint16) []TAlgo {
+	var vRet []TAlgo
+	for a := range predefined() {
+		if a.Check == aCheck {
+			vRet = append(vRet, *a)
+		}
+	}
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,80 @@
+//-----------------------------------------------------------------------------
+
+package catalogue
+
+import "strings"
+
+//-----------------------------------------------------------------------------
+
+// The historical and deprecated names of the algorithms, from the CRC RevEng catalogue and
+// other libraries, by canonical name. Names are matched in their normalized form.
+var aliases = map[string][]string{
+	"CRC-8/I-432-1":            {"CRC-8/ITU"},
+	"CRC-8/MAXIM-DOW":          {"DOW-CRC"},
+	"CRC-8/SMBUS":              {"CRC-8"},
+	"CRC-8/TECH-3250":          {"CRC-8/AES", "CRC-8/EBU"},
+	"CRC-16/ARC":               {"ARC", "CRC-16", "CRC-16/LHA", "CRC-IBM", "CRC-16/ANSI", "CRC-16/IBM"},
+	"CRC-16/DECT-R":            {"R-CRC-16"},
+	"CRC-16/DECT-X":            {"X-CRC-16"},
+	"CRC-16/GENIBUS":           {"CRC-16/DARC", "CRC-16/EPC", "CRC-16/EPC-C1G2", "CRC-16/I-CODE"},
+	"CRC-16/IBM-3740":          {"CRC-16/AUTOSAR", "CRC-16/CCITT-FALSE"},
+	"CRC-16/IBM-SDLC":          {"CRC-16/ISO-HDLC", "CRC-16/ISO-IEC-14443-3-B", "CRC-16/X-25", "CRC-B", "X-25"},
+	"CRC-16/ISO-IEC-14443-3-A": {"CRC-A", "CRC-16/CRC-A"},
+	"CRC-16/KERMIT":            {"CRC-16/BLUETOOTH", "CRC-16/CCITT", "CRC-16/CCITT-TRUE", "CRC-16/V-41-LSB", "CRC-CCITT", "KERMIT"},
+	"CRC-16/MAXIM-DOW":         {"CRC-16/MAXIM"},
+	"CRC-16/MODBUS":            {"MODBUS"},
+	"CRC-16/PROFIBUS":          {"CRC-16/IEC-61158-2"},
+	"CRC-16/SPI-FUJITSU":       {"CRC-16/AUG-CCITT"},
+	"CRC-16/UMTS":              {"CRC-16/BUYPASS", "CRC-16/VERIFONE"},
+	"CRC-16/XMODEM":            {"CRC-16/ACORN", "CRC-16/LTE", "CRC-16/V-41-MSB", "XMODEM", "ZMODEM"},
+	"CRC-24/OPENPGP":           {"CRC-24"},
+	"CRC-32/AIXM":              {"CRC-32Q"},
+	"CRC-32/BASE91-D":          {"CRC-32D"},
+	"CRC-32/BZIP2":             {"CRC-32/AAL5", "CRC-32/DECT-B", "B-CRC-32"},
+	"CRC-32/CKSUM":             {"CKSUM", "CRC-32/POSIX"},
+	"CRC-32/ISCSI":             {"CRC-32/BASE91-C", "CRC-32/CASTAGNOLI", "CRC-32/INTERLAKEN", "CRC-32C"},
+	"CRC-32/ISO-HDLC":          {"CRC-32", "CRC-32/ADCCP", "CRC-32/V-42", "CRC-32/XZ", "PKZIP"},
+	"CRC-32/JAMCRC":            {"JAMCRC"},
+	"CRC-32/XFER":              {"XFER"},
+	"CRC-64/ECMA-182":          {"CRC-64"},
+	"CRC-64/XZ":                {"CRC-64/GO-ECMA"},
+}
+
+// The canonical names by normalized alias.
+var canonicalNames = func() map[string]string {
+	vRet := make(map[string]string)
+	for c, vNames := range aliases {
+		for _, n := range vNames {
+			vRet[normalize(n)] = c
+		}
+	}
+	return vRet
+}()
+
+//-----------------------------------------------------------------------------
+
+// Returns the name in upper case without the separators spelled inconsistently
+// across libraries, so that e.g. "crc16-ansi" matches "CRC-16/ANSI".
+func normalize(aName string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', '_', '/', ' ', '.':
+			return -1
+		}
+		return r
+	}, strings.ToUpper(aName))
+}
+
+//--------------------------------------
+
+// Canonical returns the canonical name of the algorithm the specified name refers to,
+// and whether the name is a historical or deprecated alias of it, so that callers
+// can report the mapping. Canonical names are returned unchanged.
+func Canonical(aName string) (string, bool) {
+	if c, vFound := canonicalNames[normalize(aName)]; vFound {
+		return c, true
+	}
+	return aName, false
+}
+
+//-----------------------------------------------------------------------------
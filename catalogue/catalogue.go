@@ -0,0 +1,159 @@
+//-----------------------------------------------------------------------------
+
+// Package catalogue gathers the predefined algorithms of all widths, from CRC-5 to CRC-64,
+// in one namespace, so that configuration files can name any algorithm uniformly,
+// e.g. "CRC-8/SMBUS", "CRC-16/MODBUS" or "CRC-32/ISCSI".
+//
+// Algorithms are described width-agnostically and computed by the generic engine
+// of package crc with 64-bit registers.
+package catalogue
+
+import (
+	"github.com/mbsulliv/crc16"
+	"github.com/mbsulliv/crc16/crc"
+	"github.com/mbsulliv/crc16/crc32"
+	"github.com/mbsulliv/crc16/crc64"
+	"github.com/mbsulliv/crc16/crc8"
+)
+
+//-----------------------------------------------------------------------------
+
+// TAlgo represents parameters of CRC algorithms of any width up to 64 bits.
+type TAlgo struct {
+	Width  int
+	Poly   uint64
+	Init   uint64
+	RefIn  bool
+	RefOut bool
+	XorOut uint64
+	Check  uint64
+	Name   string
+}
+
+// TTable is a 256-word table representing polinomial and algorithm settings for efficient processing.
+type TTable = crc.TTable[uint64]
+
+//-----------------------------------------------------------------------------
+
+// Returns the algorithm of the generic engine in its width-agnostic form.
+func fromGeneric[T crc.TWord](aAlgo crc.TAlgo[T]) TAlgo {
+	vWidth := aAlgo.Width
+	if vWidth == 0 {
+		vWidth = crc.Width[T]()
+	}
+	return TAlgo{vWidth, uint64(aAlgo.Poly), uint64(aAlgo.Init), aAlgo.RefIn, aAlgo.RefOut,
+		uint64(aAlgo.XorOut), uint64(aAlgo.Check), aAlgo.Name}
+}
+
+//--------------------------------------
+
+// Algorithms returns the predefined algorithms of all widths, narrowest first,
+// each width in the catalogue order of its package.
+func Algorithms() []TAlgo {
+	var vRet []TAlgo
+	for _, a := range []crc.TAlgo[uint8]{crc.CRC5_EPC_C1G2, crc.CRC5_G_704, crc.CRC5_USB, crc.CRC7_MMC} {
+		vRet = append(vRet, fromGeneric(a))
+	}
+	for _, a := range crc8.Algorithms() {
+		vRet = append(vRet, TAlgo{8, uint64(a.Poly), uint64(a.Init), a.RefIn, a.RefOut, uint64(a.XorOut), uint64(a.Check), a.Name})
+	}
+	for _, a := range []crc.TAlgo[uint16]{
+		crc.CRC10_ATM, crc.CRC10_CDMA2000, crc.CRC10_GSM, crc.CRC11_FLEXRAY, crc.CRC11_UMTS,
+		crc.CRC12_CDMA2000, crc.CRC12_DECT, crc.CRC12_GSM, crc.CRC12_UMTS, crc.CRC13_BBC,
+		crc.CRC14_DARC, crc.CRC14_GSM, crc.CRC15_CAN, crc.CRC15_MPT1327,
+	} {
+		vRet = append(vRet, fromGeneric(a))
+	}
+	for _, a := range crc16.Algorithms() {
+		vRet = append(vRet, TAlgo{16, uint64(a.Poly), uint64(a.Init), a.RefIn, a.RefOut, uint64(a.XorOut), uint64(a.Check), a.Name})
+	}
+	for _, a := range []crc.TAlgo[uint32]{
+		crc.CRC17_CAN_FD, crc.CRC21_CAN_FD, crc.CRC24_BLE, crc.CRC24_FLEXRAY_A,
+		crc.CRC24_INTERLAKEN, crc.CRC24_LTE_A, crc.CRC24_OPENPGP,
+	} {
+		vRet = append(vRet, fromGeneric(a))
+	}
+	for _, a := range crc32.Algorithms() {
+		vRet = append(vRet, TAlgo{32, uint64(a.Poly), uint64(a.Init), a.RefIn, a.RefOut, uint64(a.XorOut), uint64(a.Check), a.Name})
+	}
+	for _, a := range crc64.Algorithms() {
+		vRet = append(vRet, TAlgo{64, a.Poly, a.Init, a.RefIn, a.RefOut, a.XorOut, a.Check, a.Name})
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Lookup returns the predefined algorithm with the specified name. Names are matched
+// case-insensitively and regardless of separators, e.g. "crc16-modbus" matches "CRC-16/MODBUS".
+// Names not predefined are looked up in the vendored snapshot of the CRC RevEng catalogue,
+// and historical or deprecated names, e.g. "CRC-CCITT" or "CRC16-ANSI", are resolved
+// to their canonical algorithm when no algorithm bears them, as reported by Canonical.
+func Lookup(aName string) (TAlgo, bool) {
+	if a, vFound := lookupName(aName); vFound {
+		return a, true
+	}
+	if c, vAlias := Canonical(aName); vAlias {
+		return lookupName(c)
+	}
+	return TAlgo{}, false
+}
+
+//--------------------------------------
+
+// Returns the predefined or RevEng algorithm named aName, in normalized form.
+func lookupName(aName string) (TAlgo, bool) {
+	vName := normalize(aName)
+	for _, a := range Algorithms() {
+		if normalize(a.Name) == vName {
+			return a, true
+		}
+	}
+	for _, a := range revengAlgos() {
+		if normalize(a.Name) == vName {
+			return a, true
+		}
+	}
+	return TAlgo{}, false
+}
+
+//-----------------------------------------------------------------------------
+
+// MakeTable returns the TTable constructed from the specified algorithm.
+// It panics if the width of the algorithm is negative or exceeds 64 bits.
+func MakeTable(aAlgo TAlgo) *TTable {
+	return crc.MakeTable(crc.TAlgo[uint64]{
+		Poly: aAlgo.Poly, Init: aAlgo.Init, RefIn: aAlgo.RefIn, RefOut: aAlgo.RefOut,
+		XorOut: aAlgo.XorOut, Check: aAlgo.Check, Name: aAlgo.Name, Width: aAlgo.Width,
+	})
+}
+
+//--------------------------------------
+
+// Init returns the initial value for CRC register corresponding to the specified algorithm.
+func Init(aTable *TTable) uint64 {
+	return crc.Init(aTable)
+}
+
+//--------------------------------------
+
+// Update returns the result of adding the bytes in data to the crc.
+func Update(aCrc uint64, data []byte, aTable *TTable) uint64 {
+	return crc.Update(aCrc, data, aTable)
+}
+
+//--------------------------------------
+
+// Complete returns the result of CRC calculation and post-calculation processing of the crc.
+func Complete(aCrc uint64, aTable *TTable) uint64 {
+	return crc.Complete(aCrc, aTable)
+}
+
+//--------------------------------------
+
+// Checksum returns CRC checksum of data using specified algorithm represented by the TTable.
+func Checksum(data []byte, aTable *TTable) uint64 {
+	return crc.Checksum(data, aTable)
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,103 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nocatalogue || (crc16_ccitt && crc16_ibm && crc16_misc)
+
+package catalogue
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestAlgorithms(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vNames := map[string]bool{}
+		vWidth := 0
+		for _, a := range Algorithms() {
+			So(vNames[a.Name], ShouldBeFalse)
+			vNames[a.Name] = true
+			So(a.Width, ShouldBeGreaterThanOrEqualTo, vWidth)
+			vWidth = a.Width
+			So(Checksum([]byte("123456789"), MakeTable(a)), ShouldEqual, a.Check)
+		}
+		for _, vName := range []string{"CRC-5/USB", "CRC-8/SMBUS", "CRC-10/CDMA2000", "CRC-14/DARC", "CRC-15/MPT1327", "CRC-16/MODBUS", "CRC-24/OPENPGP", "CRC-32/ISCSI", "CRC-64/XZ"} {
+			So(vNames[vName], ShouldBeTrue)
+		}
+
+		vAlgo, vFound := Lookup("crc-16/xmodem")
+		So(vFound, ShouldBeTrue)
+		So(vAlgo, ShouldResemble, TAlgo{16, 0x1021, 0, false, false, 0, 0x31C3, "CRC-16/XMODEM"})
+		_, vFound = Lookup("CRC-16/NONE")
+		So(vFound, ShouldBeFalse)
+	})
+}
+
+//-----------------------------------------------------------------------------
+
+func TestParseAlgo(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vAlgo, err := ParseAlgo(`width=24 poly=0x864cfb init=0xb704ce refin=false refout=false xorout=0x000000 check=0x21cf02 residue=0x000000 name="CRC-24/OPENPGP"`)
+		So(err, ShouldBeNil)
+		vPredefined, _ := Lookup("CRC-24/OPENPGP")
+		So(vAlgo, ShouldResemble, vPredefined)
+
+		vAlgo, err = ParseAlgo(" CRC-8/smbus ")
+		So(err, ShouldBeNil)
+		So(vAlgo.Poly, ShouldEqual, 0x07)
+
+		vAlgo, err = ParseAlgo("width=64 poly=0x42f0e1eba9ea3693 init=0xffffffffffffffff refin=true refout=true xorout=0xffffffffffffffff")
+		So(err, ShouldBeNil)
+		So(Checksum([]byte("123456789"), MakeTable(vAlgo)), ShouldEqual, uint64(0x995DC9BBDF1939FA))
+
+		for _, vBad := range []string{
+			"", "CRC-16/NONE", "poly=0x1021", "width=16", "width=0 poly=1", "width=65 poly=1",
+			"width=5 poly=0x25", "width=16 poly=0x1021 refin=maybe", "width=16 poly=0x1021 colour=red",
+			`width=16 poly=0x1021 name="open`, "width=16 poly=0x1021 =1",
+		} {
+			_, err = ParseAlgo(vBad)
+			So(err, ShouldNotBeNil)
+		}
+	})
+}
+
+//-----------------------------------------------------------------------------
+
+func TestAliases(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		for vAlias, vCanonical := range map[string]string{
+			"CRC-CCITT": "CRC-16/KERMIT", "CRC16-ANSI": "CRC-16/ARC", "crc_16_buypass": "CRC-16/UMTS",
+			"CRC-32C": "CRC-32/ISCSI", "PKZIP": "CRC-32/ISO-HDLC", "CRC-64": "CRC-64/ECMA-182",
+		} {
+			vName, vIsAlias := Canonical(vAlias)
+			So(vIsAlias, ShouldBeTrue)
+			So(vName, ShouldEqual, vCanonical)
+			vAlgo, vFound := Lookup(vAlias)
+			So(vFound, ShouldBeTrue)
+			vWant, _ := Lookup(vCanonical)
+			So(vAlgo.Check, ShouldEqual, vWant.Check)
+			So(vAlgo.Poly, ShouldEqual, vWant.Poly)
+		}
+
+		vName, vIsAlias := Canonical("CRC-16/MODBUS")
+		So(vIsAlias, ShouldBeFalse)
+		So(vName, ShouldEqual, "CRC-16/MODBUS")
+
+		// Deprecated names resolve to the canonical algorithm.
+		vAlgo, _ := Lookup("CRC-16/CCITT-FALSE")
+		So(vAlgo.Name, ShouldEqual, "CRC-16/IBM-3740")
+		vAlgo, vFound := Lookup("crc16 modbus")
+		So(vFound, ShouldBeTrue)
+		So(vAlgo.Name, ShouldEqual, "CRC-16/MODBUS")
+
+		// Every alias resolves.
+		for c := range aliases {
+			_, vFound = Lookup(c)
+			So(vFound, ShouldBeTrue)
+		}
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,22 @@
+//-----------------------------------------------------------------------------
+
+package catalogue
+
+import (
+	"path"
+	"runtime"
+)
+
+//-----------------------------------------------------------------------------
+
+// Returns the function name of the calling function.
+func funcName() string {
+	vRet := "?"
+	vPc, _, _, vOk := runtime.Caller(1)
+	if vOk {
+		vRet = path.Base(runtime.FuncForPC(vPc).Name())
+	}
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
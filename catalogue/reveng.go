@@ -0,0 +1,71 @@
+//-----------------------------------------------------------------------------
+
+package catalogue
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//-----------------------------------------------------------------------------
+
+// This file contains the loader of the CRC RevEng catalogue text, with a vendored
+// snapshot of it in reveng.txt, one definition per line.
+//
+// Related links:
+// - https://reveng.sourceforge.io/crc-catalogue/all.txt
+
+//go:embed reveng.txt
+var revengSnapshot string
+
+// The algorithms of the vendored snapshot, parsed on first use.
+var revengAlgos = sync.OnceValue(func() []TAlgo {
+	vRet, err := LoadRevEng(strings.NewReader(revengSnapshot))
+	if err != nil {
+		panic(err)
+	}
+	return vRet
+})
+
+//-----------------------------------------------------------------------------
+
+// LoadRevEng parses the algorithm definitions of a CRC RevEng catalogue text, as published
+// in the all.txt listing. Lines not starting with "width=" are skipped, as are the algorithms
+// wider than 64 bits, so that the text may be fed as published.
+func LoadRevEng(r io.Reader) ([]TAlgo, error) {
+	var vRet []TAlgo
+	vScanner := bufio.NewScanner(r)
+	for n := 1; vScanner.Scan(); n++ {
+		vLine := strings.TrimSpace(vScanner.Text())
+		if !strings.HasPrefix(vLine, "width=") {
+			continue
+		}
+		if w, err := strconv.Atoi(strings.TrimPrefix(strings.Fields(vLine)[0], "width=")); err == nil && w > 64 {
+			continue
+		}
+		vAlgo, err := parseDefinition(vLine)
+		if err != nil {
+			return nil, fmt.Errorf("%w on line %d", err, n)
+		}
+		vRet = append(vRet, vAlgo)
+	}
+	if err := vScanner.Err(); err != nil {
+		return nil, err
+	}
+	return vRet, nil
+}
+
+//--------------------------------------
+
+// RevEng returns the algorithms of the vendored snapshot of the CRC RevEng catalogue,
+// ordered by width and name like the catalogue.
+func RevEng() []TAlgo {
+	return append([]TAlgo(nil), revengAlgos()...)
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,46 @@
+//-----------------------------------------------------------------------------
+
+package catalogue
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestRevEng(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vAlgos := RevEng()
+		So(len(vAlgos), ShouldBeGreaterThan, 100)
+		for _, a := range vAlgos {
+			So(a.Width, ShouldBeBetweenOrEqual, 3, 64)
+			So(Checksum([]byte("123456789"), MakeTable(a)), ShouldEqual, a.Check)
+		}
+		for _, a := range Algorithms() {
+			if vAlgo, vFound := Lookup(a.Name); vFound {
+				So(vAlgo, ShouldResemble, a)
+			}
+		}
+
+		vAlgo, vFound := Lookup("crc-40/gsm")
+		So(vFound, ShouldBeTrue)
+		So(vAlgo.Check, ShouldEqual, uint64(0xD4164FC646))
+
+		vAlgos, err := LoadRevEng(strings.NewReader(`CRC RevEng catalogue
+
+width=3  poly=0x3  init=0x0  refin=false  refout=false  xorout=0x7  check=0x4  residue=0x2  name="CRC-3/GSM"
+width=82  poly=0x0308c0111011401440411  init=0x000000000000000000000  name="CRC-82/DARC"
+`))
+		So(err, ShouldBeNil)
+		So(vAlgos, ShouldResemble, []TAlgo{{3, 0x3, 0, false, false, 0x7, 0x4, "CRC-3/GSM"}})
+
+		_, err = LoadRevEng(strings.NewReader("\nwidth=3 poly=0x13\n"))
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEndWith, "line 2")
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,135 @@
+//-----------------------------------------------------------------------------
+
+package catalogue
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//-----------------------------------------------------------------------------
+
+// This file contains the parser of algorithm definitions in the notation
+// of the CRC RevEng catalogue, e.g.
+//
+//	width=24 poly=0x864cfb init=0xb704ce refin=false refout=false xorout=0x000000 check=0x21cf02 name="CRC-24/OPENPGP"
+
+//-----------------------------------------------------------------------------
+
+// Splits a specification into its key=value fields; values may be double-quoted.
+func specFields(aSpec string) ([][2]string, error) {
+	var vRet [][2]string
+	s := aSpec
+	for {
+		s = strings.TrimLeft(s, " \t\r\n")
+		if s == "" {
+			return vRet, nil
+		}
+		vKey, vRest, vOk := strings.Cut(s, "=")
+		if !vOk || vKey == "" || strings.ContainsAny(vKey, " \t\r\n") {
+			return nil, fmt.Errorf("catalogue: malformed field %q in algorithm specification", strings.Fields(s)[0])
+		}
+		var vVal string
+		if strings.HasPrefix(vRest, `"`) {
+			vEnd := strings.IndexByte(vRest[1:], '"')
+			if vEnd < 0 {
+				return nil, fmt.Errorf("catalogue: unterminated quoted value of %q in algorithm specification", vKey)
+			}
+			vVal, s = vRest[1:vEnd+1], vRest[vEnd+2:]
+		} else {
+			vEnd := strings.IndexAny(vRest, " \t\r\n")
+			if vEnd < 0 {
+				vEnd = len(vRest)
+			}
+			vVal, s = vRest[:vEnd], vRest[vEnd:]
+		}
+		vRet = append(vRet, [2]string{strings.ToLower(vKey), vVal})
+	}
+}
+
+//-----------------------------------------------------------------------------
+
+// ParseAlgo parses an algorithm given by the name of a predefined algorithm, as accepted
+// by Lookup, or by its definition in the notation of the CRC RevEng catalogue.
+//
+// The width and poly fields of a definition are mandatory; init and xorout default to 0,
+// refin and refout to false, and check is left zero when omitted. The residue field
+// is accepted and ignored. Values are hexadecimal with the 0x prefix or decimal and
+// must fit in the width; the name may be double-quoted.
+func ParseAlgo(aSpec string) (TAlgo, error) {
+	if vName := strings.TrimSpace(aSpec); vName != "" && !strings.Contains(vName, "=") {
+		vAlgo, vFound := Lookup(vName)
+		if !vFound {
+			return TAlgo{}, fmt.Errorf("catalogue: unknown algorithm %q", vName)
+		}
+		return vAlgo, nil
+	}
+	return parseDefinition(aSpec)
+}
+
+//--------------------------------------
+
+// Parses an algorithm definition in the notation of the CRC RevEng catalogue.
+func parseDefinition(aSpec string) (TAlgo, error) {
+	vFields, err := specFields(aSpec)
+	if err != nil {
+		return TAlgo{}, err
+	}
+	var vAlgo TAlgo
+	vHasPoly := false
+	vValues := map[string]string{}
+	for _, f := range vFields {
+		vKey, vVal := f[0], f[1]
+		switch vKey {
+		case "width":
+			v, err := strconv.ParseUint(vVal, 10, 7)
+			if err != nil || v < 1 || v > 64 {
+				return TAlgo{}, fmt.Errorf("catalogue: unsupported width %q in algorithm specification", vVal)
+			}
+			vAlgo.Width = int(v)
+		case "poly", "init", "xorout", "check", "residue":
+			// Parsed once the width is known.
+			vValues[vKey] = vVal
+			vHasPoly = vHasPoly || vKey == "poly"
+		case "refin", "refout":
+			v, err := strconv.ParseBool(vVal)
+			if err != nil {
+				return TAlgo{}, fmt.Errorf("catalogue: invalid %s value %q in algorithm specification", vKey, vVal)
+			}
+			if vKey == "refin" {
+				vAlgo.RefIn = v
+			} else {
+				vAlgo.RefOut = v
+			}
+		case "name":
+			vAlgo.Name = vVal
+		default:
+			return TAlgo{}, fmt.Errorf("catalogue: unknown field %q in algorithm specification", vKey)
+		}
+	}
+	if vAlgo.Width == 0 {
+		return TAlgo{}, errors.New("catalogue: missing width in algorithm specification")
+	}
+	if !vHasPoly {
+		return TAlgo{}, errors.New("catalogue: missing poly in algorithm specification")
+	}
+
+	for vKey, vDst := range map[string]*uint64{"poly": &vAlgo.Poly, "init": &vAlgo.Init, "xorout": &vAlgo.XorOut, "check": &vAlgo.Check, "residue": nil} {
+		vVal, vOk := vValues[vKey]
+		if !vOk {
+			continue
+		}
+		v, err := strconv.ParseUint(vVal, 0, vAlgo.Width)
+		if err != nil {
+			return TAlgo{}, fmt.Errorf("catalogue: invalid %s value %q in algorithm specification", vKey, vVal)
+		}
+		if vDst != nil {
+			*vDst = v
+		}
+	}
+	return vAlgo, nil
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,80 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nocatalogue || (crc16_ccitt && crc16_ibm && crc16_misc)
+
+package crc16
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestCatalogueOrder(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vAlgos := Algorithms()
+		So(len(vAlgos), ShouldEqual, 31)
+		So(vAlgos[0].Name, ShouldEqual, CRC16_DECT_R.Name)
+		So(vAlgos[3].Name, ShouldEqual, CRC16_GSM.Name)
+		So(vAlgos[14].Name, ShouldEqual, CRC16_PROFIBUS.Name)
+		So(vAlgos[21].Name, ShouldEqual, CRC16_ARC.Name)
+		So(vAlgos[30].Name, ShouldEqual, CRC16_CDMA2000.Name)
+	})
+}
+
+//--------------------------------------
+
+func TestFindByCheck(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		var vNames []string
+		for _, a := range FindByCheck(0x29B1) {
+			vNames = append(vNames, a.Name)
+		}
+		So(vNames, ShouldResemble, []string{"CRC-16/IBM-3740"})
+		So(FindByCheck(0x4B37), ShouldResemble, []TAlgo{CRC16_MODBUS})
+		So(FindByCheck(0x0000), ShouldBeEmpty)
+	})
+}
+
+//--------------------------------------
+
+func TestAlgoByName(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		for _, n := range []string{"CRC-16/MODBUS", "crc-16/modbus", "crc16_modbus", "modbus", "MODBUS"} {
+			vAlgo, vFound := AlgoByName(n)
+			So(vFound, ShouldBeTrue)
+			So(vAlgo, ShouldEqual, &CRC16_MODBUS)
+		}
+		vAlgo, vFound := AlgoByName("CRC-16/ISO-HDLC")
+		So(vFound, ShouldBeTrue)
+		So(vAlgo.Name, ShouldEqual, "CRC-16/IBM-SDLC")
+		vAlgo, _ = AlgoByName("crc-16/autosar")
+		So(vAlgo.Name, ShouldEqual, "CRC-16/IBM-3740")
+		vAlgo, _ = AlgoByName("CRC-16/MAXIM")
+		So(vAlgo, ShouldEqual, &CRC16_MAXIM_DOW)
+		vAlgo, _ = AlgoByName("crc-a")
+		So(vAlgo, ShouldEqual, &CRC16_ISO_IEC_14443_3_A)
+
+		// The legacy names of the package resolve to the algorithms of the catalogue.
+		vAlgo, _ = AlgoByName("X-25")
+		So(vAlgo, ShouldEqual, &CRC16_IBM_SDLC)
+		vAlgo, _ = AlgoByName("CRC-16/CCITT-FALSE")
+		So(vAlgo, ShouldEqual, &CRC16_IBM_3740)
+		vAlgo, _ = AlgoByName("buypass")
+		So(vAlgo, ShouldEqual, &CRC16_UMTS)
+
+		So(Aliases("CRC-16/IBM-SDLC"), ShouldContain, "CRC-16/X-25")
+		So(Aliases("CRC-16/MODBUS"), ShouldBeEmpty)
+
+		_, vFound = AlgoByName("CRC-16/NONE")
+		So(vFound, ShouldBeFalse)
+		for _, a := range aliases {
+			_, vFound = AlgoByName(a[1])
+			So(vFound, ShouldBeTrue)
+		}
+	})
+}
+
+//-----------------------------------------------------------------------------
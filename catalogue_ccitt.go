@@ -0,0 +1,113 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nocatalogue || crc16_ccitt
+
+package crc16
+
+//-----------------------------------------------------------------------------
+
+// Predefined CRC-16 algorithms of the CCITT polynomial 0x1021.
+var (
+	CRC16_GSM               = TAlgo{0x1021, 0x0000, false, false, 0xFFFF, 0xCE3C, "CRC-16/GSM", TrailerBigEndian, 16}
+	CRC16_KERMIT            = TAlgo{0x1021, 0x0000, true, true, 0x0000, 0x2189, "CRC-16/KERMIT", TrailerLittleEndian, 16}
+	CRC16_XMODEM            = TAlgo{0x1021, 0x0000, false, false, 0x0000, 0x31C3, "CRC-16/XMODEM", TrailerBigEndian, 16}
+	CRC16_SPI_FUJITSU       = TAlgo{0x1021, 0x1D0F, false, false, 0x0000, 0xE5CC, "CRC-16/SPI-FUJITSU", TrailerBigEndian, 16}
+	CRC16_TMS37157          = TAlgo{0x1021, 0x89EC, true, true, 0x0000, 0x26B1, "CRC-16/TMS37157", TrailerLittleEndian, 16}
+	CRC16_RIELLO            = TAlgo{0x1021, 0xB2AA, true, true, 0x0000, 0x63D0, "CRC-16/RIELLO", TrailerLittleEndian, 16}
+	CRC16_ISO_IEC_14443_3_A = TAlgo{0x1021, 0xC6C6, true, true, 0x0000, 0xBF05, "CRC-16/ISO-IEC-14443-3-A", TrailerLittleEndian, 16}
+	CRC16_GENIBUS           = TAlgo{0x1021, 0xFFFF, false, false, 0xFFFF, 0xD64E, "CRC-16/GENIBUS", TrailerBigEndian, 16}
+	CRC16_IBM_3740          = TAlgo{0x1021, 0xFFFF, false, false, 0x0000, 0x29B1, "CRC-16/IBM-3740", TrailerBigEndian, 16}
+	CRC16_IBM_SDLC          = TAlgo{0x1021, 0xFFFF, true, true, 0xFFFF, 0x906E, "CRC-16/IBM-SDLC", TrailerLittleEndian, 16}
+	CRC16_MCRF4XX           = TAlgo{0x1021, 0xFFFF, true, true, 0x0000, 0x6F91, "CRC-16/MCRF4XX", TrailerLittleEndian, 16}
+)
+
+// Predefined algorithms of the family under legacy names, which resolve as aliases of
+// the algorithms of the catalogue.
+var (
+	// Deprecated: use CRC16_ISO_IEC_14443_3_A.
+	CRC16_CRC_A = TAlgo{0x1021, 0xC6C6, true, true, 0x0000, 0xBF05, "CRC-16/CRC-A", TrailerLittleEndian, 16}
+	// CRC16_CCITT_FALSE is CRC16_IBM_3740 under its name in the first releases of the package.
+	CRC16_CCITT_FALSE = TAlgo{0x1021, 0xFFFF, false, false, 0x0000, 0x29B1, "CRC-16/CCITT-FALSE", TrailerBigEndian, 16}
+	// CRC16_X_25 is CRC16_IBM_SDLC under its name in the first releases of the package.
+	CRC16_X_25 = TAlgo{0x1021, 0xFFFF, true, true, 0xFFFF, 0x906E, "CRC-16/X-25", TrailerLittleEndian, 16}
+)
+
+// The algorithms of the family in the catalogue order.
+var ccittCatalogue = [...]*TAlgo{
+	&CRC16_GSM,
+	&CRC16_KERMIT,
+	&CRC16_XMODEM,
+	&CRC16_SPI_FUJITSU,
+	&CRC16_TMS37157,
+	&CRC16_RIELLO,
+	&CRC16_ISO_IEC_14443_3_A,
+	&CRC16_GENIBUS,
+	&CRC16_IBM_3740,
+	&CRC16_IBM_SDLC,
+	&CRC16_MCRF4XX,
+}
+
+//-----------------------------------------------------------------------------
+
+// Adds the family to the catalogue.
+func init() {
+	ccittAlgos = ccittCatalogue[:]
+}
+
+//-----------------------------------------------------------------------------
+
+// NewXModem creates a new CRC16 digest of CRC-16/XMODEM using the shared table.
+func NewXModem() Hash16 {
+	return New(Table(CRC16_XMODEM))
+}
+
+//--------------------------------------
+
+// ChecksumXModem returns the CRC-16/XMODEM checksum of data using the shared table.
+func ChecksumXModem(data []byte) uint16 {
+	return Checksum(data, Table(CRC16_XMODEM))
+}
+
+//--------------------------------------
+
+// NewKermit creates a new CRC16 digest of CRC-16/KERMIT using the shared table.
+func NewKermit() Hash16 {
+	return New(Table(CRC16_KERMIT))
+}
+
+//--------------------------------------
+
+// ChecksumKermit returns the CRC-16/KERMIT checksum of data using the shared table.
+func ChecksumKermit(data []byte) uint16 {
+	return Checksum(data, Table(CRC16_KERMIT))
+}
+
+//--------------------------------------
+
+// NewX25 creates a new CRC16 digest of CRC-16/X-25 using the shared table.
+func NewX25() Hash16 {
+	return New(Table(CRC16_X_25))
+}
+
+//--------------------------------------
+
+// ChecksumX25 returns the CRC-16/X-25 checksum of data using the shared table.
+func ChecksumX25(data []byte) uint16 {
+	return Checksum(data, Table(CRC16_X_25))
+}
+
+//--------------------------------------
+
+// NewCCITTFalse creates a new CRC16 digest of CRC-16/CCITT-FALSE using the shared table.
+func NewCCITTFalse() Hash16 {
+	return New(Table(CRC16_CCITT_FALSE))
+}
+
+//--------------------------------------
+
+// ChecksumCCITTFalse returns the CRC-16/CCITT-FALSE checksum of data using the shared table.
+func ChecksumCCITTFalse(data []byte) uint16 {
+	return Checksum(data, Table(CRC16_CCITT_FALSE))
+}
+
+//-----------------------------------------------------------------------------
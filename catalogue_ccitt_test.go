@@ -0,0 +1,43 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nocatalogue || crc16_ccitt
+
+package crc16
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+// Counts the algorithms of the family.
+func init() {
+	predefinedBuiltIn += 11
+}
+
+//-----------------------------------------------------------------------------
+
+func TestShortcutsCCITT(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vCheck := []byte("123456789")
+		for _, c := range []struct {
+			algo     TAlgo
+			checksum func([]byte) uint16
+			new      func() Hash16
+		}{
+			{CRC16_XMODEM, ChecksumXModem, NewXModem},
+			{CRC16_KERMIT, ChecksumKermit, NewKermit},
+			{CRC16_X_25, ChecksumX25, NewX25},
+			{CRC16_CCITT_FALSE, ChecksumCCITTFalse, NewCCITTFalse},
+		} {
+			So(c.checksum(vCheck), ShouldEqual, c.algo.Check)
+			vH := c.new()
+			vH.Write(vCheck)
+			So(vH.Sum16(), ShouldEqual, c.algo.Check)
+		}
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,89 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nocatalogue || crc16_ibm
+
+package crc16
+
+//-----------------------------------------------------------------------------
+
+// Predefined CRC-16 algorithms of the IBM polynomial 0x8005.
+var (
+	CRC16_ARC       = TAlgo{0x8005, 0x0000, true, true, 0x0000, 0xBB3D, "CRC-16/ARC", TrailerLittleEndian, 16}
+	CRC16_MAXIM_DOW = TAlgo{0x8005, 0x0000, true, true, 0xFFFF, 0x44C2, "CRC-16/MAXIM-DOW", TrailerLittleEndian, 16}
+	CRC16_UMTS      = TAlgo{0x8005, 0x0000, false, false, 0x0000, 0xFEE8, "CRC-16/UMTS", TrailerBigEndian, 16}
+	CRC16_DDS_110   = TAlgo{0x8005, 0x800D, false, false, 0x0000, 0x9ECF, "CRC-16/DDS-110", TrailerBigEndian, 16}
+	CRC16_CMS       = TAlgo{0x8005, 0xFFFF, false, false, 0x0000, 0xAEE7, "CRC-16/CMS", TrailerBigEndian, 16}
+	CRC16_MODBUS    = TAlgo{0x8005, 0xFFFF, true, true, 0x0000, 0x4B37, "CRC-16/MODBUS", TrailerLittleEndian, 16}
+	CRC16_USB       = TAlgo{0x8005, 0xFFFF, true, true, 0xFFFF, 0xB4C8, "CRC-16/USB", TrailerLittleEndian, 16}
+)
+
+// Predefined algorithms of the family under legacy names, which resolve as aliases of
+// the algorithms of the catalogue.
+var (
+	// Deprecated: use CRC16_MAXIM_DOW.
+	CRC16_MAXIM = TAlgo{0x8005, 0x0000, true, true, 0xFFFF, 0x44C2, "CRC-16/MAXIM", TrailerLittleEndian, 16}
+	// Deprecated: use CRC16_UMTS.
+	CRC16_BUYPASS = TAlgo{0x8005, 0x0000, false, false, 0x0000, 0xFEE8, "CRC-16/BUYPASS", TrailerBigEndian, 16}
+)
+
+// The algorithms of the family in the catalogue order.
+var ibmCatalogue = [...]*TAlgo{
+	&CRC16_ARC,
+	&CRC16_MAXIM_DOW,
+	&CRC16_UMTS,
+	&CRC16_DDS_110,
+	&CRC16_CMS,
+	&CRC16_MODBUS,
+	&CRC16_USB,
+}
+
+//-----------------------------------------------------------------------------
+
+// Adds the family to the catalogue.
+func init() {
+	ibmAlgos = ibmCatalogue[:]
+}
+
+//-----------------------------------------------------------------------------
+
+// NewModbus creates a new CRC16 digest of CRC-16/MODBUS using the shared table.
+func NewModbus() Hash16 {
+	return New(Table(CRC16_MODBUS))
+}
+
+//--------------------------------------
+
+// ChecksumModbus returns the CRC-16/MODBUS checksum of data using the shared table.
+func ChecksumModbus(data []byte) uint16 {
+	return Checksum(data, Table(CRC16_MODBUS))
+}
+
+//--------------------------------------
+
+// NewARC creates a new CRC16 digest of CRC-16/ARC using the shared table.
+func NewARC() Hash16 {
+	return New(Table(CRC16_ARC))
+}
+
+//--------------------------------------
+
+// ChecksumARC returns the CRC-16/ARC checksum of data using the shared table.
+func ChecksumARC(data []byte) uint16 {
+	return Checksum(data, Table(CRC16_ARC))
+}
+
+//--------------------------------------
+
+// NewUSB creates a new CRC16 digest of CRC-16/USB using the shared table.
+func NewUSB() Hash16 {
+	return New(Table(CRC16_USB))
+}
+
+//--------------------------------------
+
+// ChecksumUSB returns the CRC-16/USB checksum of data using the shared table.
+func ChecksumUSB(data []byte) uint16 {
+	return Checksum(data, Table(CRC16_USB))
+}
+
+//-----------------------------------------------------------------------------
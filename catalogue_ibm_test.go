@@ -0,0 +1,43 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nocatalogue || crc16_ibm
+
+package crc16
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+// Counts the algorithms of the family.
+func init() {
+	predefinedBuiltIn += 7
+}
+
+//-----------------------------------------------------------------------------
+
+func TestShortcutsIBM(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vCheck := []byte("123456789")
+		for _, c := range []struct {
+			algo     TAlgo
+			checksum func([]byte) uint16
+			new      func() Hash16
+		}{
+			{CRC16_MODBUS, ChecksumModbus, NewModbus},
+			{CRC16_ARC, ChecksumARC, NewARC},
+			{CRC16_USB, ChecksumUSB, NewUSB},
+		} {
+			So(c.checksum(vCheck), ShouldEqual, c.algo.Check)
+			vH := c.new()
+			vH.Write(vCheck)
+			So(vH.Sum16(), ShouldEqual, c.algo.Check)
+		}
+		So(testing.AllocsPerRun(100, func() { ChecksumModbus(vCheck) }), ShouldEqual, 0)
+	})
+}
+
+//-----------------------------------------------------------------------------
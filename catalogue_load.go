@@ -0,0 +1,307 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package crc16
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+//-----------------------------------------------------------------------------
+
+// This file contains the loader of external catalogues of custom algorithms, so that
+// site-specific variants can be deployed as configuration, e.g. in TOML
+//
+//	[[algorithm]]
+//	name = "CRC-16/ACME"
+//	poly = 0x1021
+//	init = 0x1D0F
+//	check = 0xE5CC
+//
+// or in JSON
+//
+//	[{"name": "CRC-16/ACME", "poly": "0x1021", "init": "0x1D0F", "check": "0xE5CC"}]
+//
+// or in YAML
+//
+//	- name: CRC-16/ACME
+//	  poly: 0x1021
+//	  init: 0x1D0F
+//	  check: 0xE5CC
+//
+// The keys are those of the algorithm specifications parsed by ParseAlgo, plus trailer,
+// which is "auto", "little" or "big". A JSON catalogue may also be an object whose
+// "algorithms" member is the array, and a YAML one a mapping whose algorithms key
+// holds the sequence.
+
+// TCatalogFormat is the format of an external catalogue.
+type TCatalogFormat byte
+
+const (
+	CatalogJSON TCatalogFormat = iota
+	CatalogTOML
+	CatalogYAML
+)
+
+//-----------------------------------------------------------------------------
+
+// ReadCatalog reads a catalogue of custom algorithms in the specified format and returns
+// them in order, without registering them. Every algorithm must have a name and a check
+// value matching its checksum of "123456789".
+func ReadCatalog(r io.Reader, aFormat TCatalogFormat) ([]TAlgo, error) {
+	var vEntries [][][2]string
+	var err error
+	switch aFormat {
+	case CatalogJSON:
+		vEntries, err = readJSONCatalog(r)
+	case CatalogTOML:
+		vEntries, err = readTOMLCatalog(r)
+	case CatalogYAML:
+		vEntries, err = readYAMLCatalog(r)
+	default:
+		return nil, errors.New("crc16: unknown catalogue format")
+	}
+	if err != nil {
+		return nil, err
+	}
+	vRet := make([]TAlgo, len(vEntries))
+	for n, e := range vEntries {
+		if vRet[n], err = parseCatalogEntry(e); err != nil {
+			return nil, fmt.Errorf("%w in catalogue entry %d", err, n+1)
+		}
+	}
+	return vRet, nil
+}
+
+//--------------------------------------
+
+// LoadCatalog reads a catalogue of custom algorithms like ReadCatalog and registers
+// them, so that Algorithms and the facilities searching the catalogue see them.
+// An algorithm named like a known one, case-insensitively, must have the same parameters
+// and is skipped. Nothing is registered if any algorithm is invalid.
+func LoadCatalog(r io.Reader, aFormat TCatalogFormat) error {
+	vAlgos, err := ReadCatalog(r, aFormat)
+	if err != nil {
+		return err
+	}
+
+	registeredMu.Lock()
+	defer registeredMu.Unlock()
+	var vKnown []*TAlgo
+	for a := range predefined() {
+		vKnown = append(vKnown, a)
+	}
+	vLoaded := len(vKnown)
+	for n := range vAlgos {
+		vAlgo := &vAlgos[n]
+		vNew := true
+		for _, a := range vKnown {
+			if strings.EqualFold(a.Name, vAlgo.Name) {
+				if !sameParams(a, vAlgo) {
+					return fmt.Errorf("crc16: algorithm %q in catalogue entry %d conflicts with a known one", vAlgo.Name, n+1)
+				}
+				vNew = false
+			}
+		}
+		if vNew {
+			vKnown = append(vKnown, vAlgo)
+		}
+	}
+	if len(vKnown) > vLoaded {
+		var vRet []*TAlgo
+		if r := registered.Load(); r != nil {
+			vRet = append(vRet, *r...)
+		}
+		vRet = append(vRet, vKnown[vLoaded:]...)
+		registered.Store(&vRet)
+	}
+	return nil
+}
+
+//--------------------------------------
+
+// Returns the algorithm defined by the fields of a catalogue entry, checked against its check value.
+func parseCatalogEntry(aFields [][2]string) (TAlgo, error) {
+	var vSpec [][2]string
+	vTrailer, vHasCheck := TrailerAuto, false
+	for _, f := range aFields {
+		switch f[0] {
+		case "trailer":
+			switch strings.ToLower(f[1]) {
+			case "auto":
+				vTrailer = TrailerAuto
+			case "little":
+				vTrailer = TrailerLittleEndian
+			case "big":
+				vTrailer = TrailerBigEndian
+			default:
+				return TAlgo{}, fmt.Errorf("crc16: invalid trailer value %q", f[1])
+			}
+			continue
+		case "check":
+			vHasCheck = true
+		}
+		vSpec = append(vSpec, f)
+	}
+	vAlgo, err := parseSpec(vSpec)
+	if err != nil {
+		return TAlgo{}, err
+	}
+	vAlgo.Trailer = vTrailer
+	if vAlgo.Name == "" {
+		return TAlgo{}, errors.New("crc16: missing name")
+	}
+	if !vHasCheck {
+		return TAlgo{}, fmt.Errorf("crc16: missing check of %q", vAlgo.Name)
+	}
+	if vSum := Checksum([]byte("123456789"), MakeTable(vAlgo)); vSum != vAlgo.Check {
+		return TAlgo{}, fmt.Errorf("crc16: check of %q is %s, computed %s", vAlgo.Name, hex16(vAlgo.Check), hex16(vSum))
+	}
+	return vAlgo, nil
+}
+
+//--------------------------------------
+
+// Returns the fields of the algorithms of a JSON catalogue. Numbers and booleans may be
+// given as such or as strings.
+func readJSONCatalog(r io.Reader) ([][][2]string, error) {
+	vDecoder := json.NewDecoder(r)
+	vDecoder.UseNumber()
+	var vDoc any
+	if err := vDecoder.Decode(&vDoc); err != nil {
+		return nil, fmt.Errorf("crc16: invalid JSON catalogue: %w", err)
+	}
+	if m, vOk := vDoc.(map[string]any); vOk {
+		vDoc = m["algorithms"]
+	}
+	vList, vOk := vDoc.([]any)
+	if !vOk {
+		return nil, errors.New("crc16: JSON catalogue is not an array of algorithms")
+	}
+	var vRet [][][2]string
+	for n, e := range vList {
+		vObject, vOk := e.(map[string]any)
+		if !vOk {
+			return nil, fmt.Errorf("crc16: catalogue entry %d is not an object", n+1)
+		}
+		var vFields [][2]string
+		for k, v := range vObject {
+			var s string
+			switch v := v.(type) {
+			case string:
+				s = v
+			case json.Number:
+				s = v.String()
+			case bool:
+				s = strconv.FormatBool(v)
+			default:
+				return nil, fmt.Errorf("crc16: invalid %s value in catalogue entry %d", k, n+1)
+			}
+			vFields = append(vFields, [2]string{strings.ToLower(k), s})
+		}
+		vRet = append(vRet, vFields)
+	}
+	return vRet, nil
+}
+
+//--------------------------------------
+
+// Returns the fields of the algorithms of a TOML catalogue, an array of tables named
+// "algorithm" holding one-line key = value pairs. Other tables are skipped.
+func readTOMLCatalog(r io.Reader) ([][][2]string, error) {
+	var vRet [][][2]string
+	vInAlgo := false
+	vScanner := bufio.NewScanner(r)
+	for n := 1; vScanner.Scan(); n++ {
+		vLine := strings.TrimSpace(vScanner.Text())
+		if vLine == "" || vLine[0] == '#' {
+			continue
+		}
+		if vLine[0] == '[' {
+			vInAlgo = strings.ReplaceAll(vLine, " ", "") == "[[algorithm]]"
+			if vInAlgo {
+				vRet = append(vRet, nil)
+			}
+			continue
+		}
+		if !vInAlgo {
+			continue
+		}
+		vKey, vVal, vOk := strings.Cut(vLine, "=")
+		if !vOk {
+			return nil, fmt.Errorf("crc16: malformed TOML catalogue line %d", n)
+		}
+		vKey, vVal = strings.Trim(strings.TrimSpace(vKey), `"`), strings.TrimSpace(vVal)
+		if strings.HasPrefix(vVal, `"`) {
+			vEnd := strings.IndexByte(vVal[1:], '"')
+			if vEnd < 0 {
+				return nil, fmt.Errorf("crc16: unterminated string on TOML catalogue line %d", n)
+			}
+			vVal = vVal[1 : vEnd+1]
+		} else if i := strings.IndexByte(vVal, '#'); i >= 0 {
+			vVal = strings.TrimSpace(vVal[:i])
+		}
+		vRet[len(vRet)-1] = append(vRet[len(vRet)-1], [2]string{strings.ToLower(vKey), vVal})
+	}
+	if err := vScanner.Err(); err != nil {
+		return nil, err
+	}
+	return vRet, nil
+}
+
+//--------------------------------------
+
+// Returns the fields of the algorithms of a YAML catalogue, a block sequence of mappings
+// holding one-line key: value pairs, at the top level or under the algorithms key.
+// Flow collections, anchors and multi-line values are not supported.
+func readYAMLCatalog(r io.Reader) ([][][2]string, error) {
+	var vRet [][][2]string
+	vScanner := bufio.NewScanner(r)
+	for n := 1; vScanner.Scan(); n++ {
+		vLine := strings.TrimRight(vScanner.Text(), " \t\r")
+		vItem := strings.TrimLeft(vLine, " ")
+		if vItem == "" || vItem[0] == '#' || vItem == "---" || vLine == "algorithms:" {
+			continue
+		}
+		if vItem == "-" || strings.HasPrefix(vItem, "- ") {
+			vRet = append(vRet, nil)
+			vItem = strings.TrimSpace(vItem[1:])
+			if vItem == "" {
+				continue
+			}
+		}
+		vKey, vVal, vOk := strings.Cut(vItem, ":")
+		if !vOk || len(vRet) == 0 || strings.ContainsAny(vItem[:1], "{[&*|>") {
+			return nil, fmt.Errorf("crc16: unsupported YAML catalogue line %d", n)
+		}
+		vKey, vVal = strings.Trim(strings.TrimSpace(vKey), `"'`), strings.TrimSpace(vVal)
+		switch {
+		case strings.HasPrefix(vVal, `"`) || strings.HasPrefix(vVal, "'"):
+			vEnd := strings.IndexByte(vVal[1:], vVal[0])
+			if vEnd < 0 {
+				return nil, fmt.Errorf("crc16: unterminated string on YAML catalogue line %d", n)
+			}
+			vVal = vVal[1 : vEnd+1]
+		case strings.ContainsAny(vVal[:min(len(vVal), 1)], "{[&*|>"):
+			return nil, fmt.Errorf("crc16: unsupported YAML catalogue line %d", n)
+		default:
+			if i := strings.Index(vVal, " #"); i >= 0 {
+				vVal = strings.TrimSpace(vVal[:i])
+			}
+		}
+		vRet[len(vRet)-1] = append(vRet[len(vRet)-1], [2]string{strings.ToLower(vKey), vVal})
+	}
+	if err := vScanner.Err(); err != nil {
+		return nil, err
+	}
+	return vRet, nil
+}
+
+//-----------------------------------------------------------------------------
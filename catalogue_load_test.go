@@ -0,0 +1,118 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || (crc16_ccitt && crc16_ibm && crc16_misc))
+
+package crc16
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestLoadCatalog(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		defer registered.Store(nil)
+		vCount := len(Algorithms())
+
+		So(LoadCatalog(strings.NewReader(`
+# Site-specific variants
+[site]
+owner = "ops"
+
+[[algorithm]]
+name = "CRC-16/ACME"
+poly = 0x8005
+init = 0x1234   # seeded per device family
+refin = true
+refout = true
+check = 0xF569
+trailer = "big"
+
+[[algorithm]]
+name = "crc-16/modbus"
+poly = 0x8005
+init = 0xFFFF
+refin = true
+refout = true
+check = 0x4B37
+`), CatalogTOML), ShouldBeNil)
+		vAlgos := Algorithms()
+		So(len(vAlgos), ShouldEqual, vCount+1)
+		vAcme := vAlgos[len(vAlgos)-1]
+		So(vAcme, ShouldResemble, TAlgo{0x8005, 0x1234, true, true, 0, 0xF569, "CRC-16/ACME", TrailerBigEndian, 16})
+		So(FindByCheck(0xF569), ShouldContain, vAcme)
+
+		So(LoadCatalog(strings.NewReader(`{"algorithms": [
+			{"name": "CRC-16/ACME", "poly": 32773, "init": "0x1234", "refin": true, "refout": "true", "check": "0xF569"},
+			{"name": "CRC-16/ACME-2", "poly": "0x8005", "init": "0x4321", "check": 0}
+		]}`), CatalogJSON), ShouldNotBeNil)
+		So(len(Algorithms()), ShouldEqual, vCount+1)
+
+		for _, s := range []string{
+			`[{"poly": "0x1021", "check": "0x31C3"}]`,
+			`[{"name": "X", "poly": "0x1021"}]`,
+			`[{"name": "X", "poly": "0x1021", "check": "0x31C4"}]`,
+			`[{"name": "CRC-16/ACME", "poly": "0x1021", "check": "0x31C3"}]`,
+			`[{"name": "X", "poly": "0x1021", "check": "0x31C3", "trailer": "middle"}]`,
+			`[{"name": "X", "poly": "0x1021", "check": [1]}]`,
+			`{"name": "X"}`,
+		} {
+			So(LoadCatalog(strings.NewReader(s), CatalogJSON), ShouldNotBeNil)
+		}
+		So(LoadCatalog(strings.NewReader("[[algorithm]]\nname\n"), CatalogTOML), ShouldNotBeNil)
+		So(LoadCatalog(strings.NewReader(`[{"name": "CRC-16/XMODEM-LIKE", "poly": "0x1021", "check": "0x31C3"}]`), CatalogJSON), ShouldBeNil)
+		So(len(Algorithms()), ShouldEqual, vCount+2)
+	})
+}
+
+//--------------------------------------
+
+func TestReadCatalog(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vCount := len(Algorithms())
+		vAlgos, err := ReadCatalog(strings.NewReader(`---
+# Site-specific variants
+algorithms:
+  - name: "CRC-16/ACME"
+    poly: 0x8005
+    init: 0x1234   # seeded per device family
+    refin: true
+    refout: true
+    check: 0xF569
+    trailer: big
+  -
+    name: 'CRC-16/ACME-X'
+    poly: 4129
+    check: 0x31C3
+`), CatalogYAML)
+		So(err, ShouldBeNil)
+		So(vAlgos, ShouldResemble, []TAlgo{
+			{0x8005, 0x1234, true, true, 0, 0xF569, "CRC-16/ACME", TrailerBigEndian, 16},
+			{0x1021, 0, false, false, 0, 0x31C3, "CRC-16/ACME-X", TrailerAuto, 16},
+		})
+		So(len(Algorithms()), ShouldEqual, vCount)
+
+		vAlgos, err = ReadCatalog(strings.NewReader(`[{"name": "CRC-16/ACME-X", "poly": "0x1021", "check": "0x31C3"}]`), CatalogJSON)
+		So(err, ShouldBeNil)
+		So(len(vAlgos), ShouldEqual, 1)
+
+		for _, s := range []string{
+			"name: X\n",
+			"- {name: X, poly: 0x1021, check: 0x31C3}\n",
+			"- name: X\n  poly: 0x1021\n  check: 0x31C4\n",
+			"- name: \"X\n  poly: 0x1021\n",
+			"- name: X\n  poly: |\n    0x1021\n",
+		} {
+			_, err = ReadCatalog(strings.NewReader(s), CatalogYAML)
+			So(err, ShouldNotBeNil)
+		}
+		_, err = ReadCatalog(strings.NewReader(""), TCatalogFormat(9))
+		So(err, ShouldNotBeNil)
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,64 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nocatalogue || crc16_misc
+
+package crc16
+
+//-----------------------------------------------------------------------------
+
+// Predefined CRC-16 algorithms of the polynomials other than CCITT and IBM.
+var (
+	CRC16_DECT_R       = TAlgo{0x0589, 0x0000, false, false, 0x0001, 0x007E, "CRC-16/DECT-R", TrailerBigEndian, 16}
+	CRC16_DECT_X       = TAlgo{0x0589, 0x0000, false, false, 0x0000, 0x007F, "CRC-16/DECT-X", TrailerBigEndian, 16}
+	CRC16_NRSC_5       = TAlgo{0x080B, 0xFFFF, true, true, 0x0000, 0xA066, "CRC-16/NRSC-5", TrailerLittleEndian, 16}
+	CRC16_PROFIBUS     = TAlgo{0x1DCF, 0xFFFF, false, false, 0xFFFF, 0xA819, "CRC-16/PROFIBUS", TrailerBigEndian, 16}
+	CRC16_DNP          = TAlgo{0x3D65, 0x0000, true, true, 0xFFFF, 0xEA82, "CRC-16/DNP", TrailerLittleEndian, 16}
+	CRC16_EN_13757     = TAlgo{0x3D65, 0x0000, false, false, 0xFFFF, 0xC2B7, "CRC-16/EN-13757", TrailerBigEndian, 16}
+	CRC16_OPENSAFETY_A = TAlgo{0x5935, 0x0000, false, false, 0x0000, 0x5D38, "CRC-16/OPENSAFETY-A", TrailerBigEndian, 16}
+	CRC16_M17          = TAlgo{0x5935, 0xFFFF, false, false, 0x0000, 0x772B, "CRC-16/M17", TrailerBigEndian, 16}
+	CRC16_LJ1200       = TAlgo{0x6F63, 0x0000, false, false, 0x0000, 0xBDF4, "CRC-16/LJ1200", TrailerBigEndian, 16}
+	CRC16_OPENSAFETY_B = TAlgo{0x755B, 0x0000, false, false, 0x0000, 0x20FE, "CRC-16/OPENSAFETY-B", TrailerBigEndian, 16}
+	CRC16_T10_DIF      = TAlgo{0x8BB7, 0x0000, false, false, 0x0000, 0xD0DB, "CRC-16/T10-DIF", TrailerBigEndian, 16}
+	CRC16_TELEDISK     = TAlgo{0xA097, 0x0000, false, false, 0x0000, 0x0FB3, "CRC-16/TELEDISK", TrailerBigEndian, 16}
+	CRC16_CDMA2000     = TAlgo{0xC867, 0xFFFF, false, false, 0x0000, 0x4C06, "CRC-16/CDMA2000", TrailerBigEndian, 16}
+)
+
+// The algorithms of the family in the catalogue order.
+var miscCatalogue = [...]*TAlgo{
+	&CRC16_DECT_R,
+	&CRC16_DECT_X,
+	&CRC16_NRSC_5,
+	&CRC16_PROFIBUS,
+	&CRC16_DNP,
+	&CRC16_EN_13757,
+	&CRC16_OPENSAFETY_A,
+	&CRC16_M17,
+	&CRC16_LJ1200,
+	&CRC16_OPENSAFETY_B,
+	&CRC16_T10_DIF,
+	&CRC16_TELEDISK,
+	&CRC16_CDMA2000,
+}
+
+//-----------------------------------------------------------------------------
+
+// Adds the family to the catalogue.
+func init() {
+	miscAlgos = miscCatalogue[:]
+}
+
+//-----------------------------------------------------------------------------
+
+// NewDNP creates a new CRC16 digest of CRC-16/DNP using the shared table.
+func NewDNP() Hash16 {
+	return New(Table(CRC16_DNP))
+}
+
+//--------------------------------------
+
+// ChecksumDNP returns the CRC-16/DNP checksum of data using the shared table.
+func ChecksumDNP(data []byte) uint16 {
+	return Checksum(data, Table(CRC16_DNP))
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,40 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nocatalogue || crc16_misc
+
+package crc16
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+// Counts the algorithms of the family.
+func init() {
+	predefinedBuiltIn += 13
+}
+
+//-----------------------------------------------------------------------------
+
+func TestShortcutsMisc(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vCheck := []byte("123456789")
+		for _, c := range []struct {
+			algo     TAlgo
+			checksum func([]byte) uint16
+			new      func() Hash16
+		}{
+			{CRC16_DNP, ChecksumDNP, NewDNP},
+		} {
+			So(c.checksum(vCheck), ShouldEqual, c.algo.Check)
+			vH := c.new()
+			vH.Write(vCheck)
+			So(vH.Sum16(), ShouldEqual, c.algo.Check)
+		}
+	})
+}
+
+//-----------------------------------------------------------------------------
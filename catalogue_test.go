@@ -0,0 +1,46 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+// The number of predefined algorithms built in, counted by the tests of their families.
+var predefinedBuiltIn int
+
+//-----------------------------------------------------------------------------
+
+func TestAlgorithms(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vAlgos := Algorithms()
+		So(len(vAlgos), ShouldEqual, len(ccittAlgos)+len(ibmAlgos)+len(miscAlgos))
+		So(len(vAlgos), ShouldEqual, predefinedBuiltIn)
+		for i := 1; i < len(vAlgos); i++ {
+			So(vAlgos[i-1].Poly, ShouldBeLessThanOrEqualTo, vAlgos[i].Poly)
+		}
+
+		// Legacy names are aliases, so no parameter set is found twice.
+		for i := range vAlgos {
+			for j := range i {
+				So(sameParams(&vAlgos[i], &vAlgos[j]), ShouldBeFalse)
+			}
+		}
+
+		// Stopping early leaves the rest of the catalogue alone.
+		vCount := 0
+		for range predefined() {
+			vCount++
+			if vCount == 5 {
+				break
+			}
+		}
+		So(vCount, ShouldEqual, min(5, len(vAlgos)))
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,96 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import "io"
+
+//-----------------------------------------------------------------------------
+
+// This file contains the periodic checkpointing of running checksums, localizing corruption
+// in long transfers to the region between two checkpoints.
+
+// TCheckpointer is a writer computing the running checksum of the data written to it and
+// reporting it every interval bytes. It can be fed by io.TeeReader or io.MultiWriter to
+// checkpoint a reader or a writer.
+type TCheckpointer struct {
+	table    *TTable
+	interval int64
+	fn       func(aOffset int64, aSum uint16)
+	crc      uint16
+	offset   int64
+}
+
+//-----------------------------------------------------------------------------
+
+// NewCheckpointer returns the checkpointer calling aFn with the offset and the checksum
+// of the data up to it at every multiple of aInterval bytes, which must be positive.
+func NewCheckpointer(aTable *TTable, aInterval int64, aFn func(aOffset int64, aSum uint16)) *TCheckpointer {
+	if aInterval <= 0 {
+		panic("crc16: invalid checkpoint interval")
+	}
+	return &TCheckpointer{table: aTable, interval: aInterval, fn: aFn, crc: Init(aTable)}
+}
+
+//--------------------------------------
+
+// Write adds data to the running checksum, reporting the checkpoints crossed.
+// It never returns an error.
+func (aC *TCheckpointer) Write(data []byte) (int, error) {
+	vLen := len(data)
+	for len(data) > 0 {
+		n := min(int64(len(data)), aC.interval-aC.offset%aC.interval)
+		aC.crc = Update(aC.crc, data[:n], aC.table)
+		aC.offset += n
+		data = data[n:]
+		if aC.offset%aC.interval == 0 {
+			aC.fn(aC.offset, Complete(aC.crc, aC.table))
+		}
+	}
+	return vLen, nil
+}
+
+//--------------------------------------
+
+// Sum16 returns the checksum of all the data written.
+func (aC *TCheckpointer) Sum16() uint16 {
+	return Complete(aC.crc, aC.table)
+}
+
+//-----------------------------------------------------------------------------
+
+// Checkpoints returns the index of the running checksums of the content of r
+// at every multiple of aInterval bytes, followed by the checksum of the whole content
+// unless its length is such a multiple.
+func Checkpoints(r io.Reader, aTable *TTable, aInterval int64) ([]uint16, error) {
+	var vRet []uint16
+	vC := NewCheckpointer(aTable, aInterval, func(_ int64, aSum uint16) {
+		vRet = append(vRet, aSum)
+	})
+	n, err := io.Copy(vC, r)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 || n%aInterval != 0 {
+		vRet = append(vRet, vC.Sum16())
+	}
+	return vRet, nil
+}
+
+//--------------------------------------
+
+// LocateMismatch returns the index of the first region, of the interval the indexes were
+// built with, in which the data they describe differ, or -1 if they match.
+// Regions past the end of the shorter index differ.
+func LocateMismatch(a, b []uint16) int {
+	for i := range min(len(a), len(b)) {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	if len(a) != len(b) {
+		return min(len(a), len(b))
+	}
+	return -1
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,51 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestCheckpoints(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(TAlgo{Poly: 0x1021, Init: 0xFFFF})
+		vData := make([]byte, 1000)
+		for i := range vData {
+			vData[i] = byte(i)
+		}
+
+		var vOffsets []int64
+		vC := NewCheckpointer(vTable, 100, func(aOffset int64, aSum uint16) {
+			So(aSum, ShouldEqual, Checksum(vData[:aOffset], vTable))
+			vOffsets = append(vOffsets, aOffset)
+		})
+		io.Copy(vC, bytes.NewReader(vData[:950]))
+		vC.Write(vData[950:])
+		So(vOffsets, ShouldResemble, []int64{100, 200, 300, 400, 500, 600, 700, 800, 900, 1000})
+		So(vC.Sum16(), ShouldEqual, Checksum(vData, vTable))
+
+		vIndex, err := Checkpoints(bytes.NewReader(vData), vTable, 300)
+		So(err, ShouldBeNil)
+		So(len(vIndex), ShouldEqual, 4)
+		So(vIndex[3], ShouldEqual, Checksum(vData, vTable))
+
+		vCorrupt := bytes.Clone(vData)
+		vCorrupt[650] ^= 1
+		vOther, _ := Checkpoints(bytes.NewReader(vCorrupt), vTable, 300)
+		So(LocateMismatch(vIndex, vOther), ShouldEqual, 2)
+		So(LocateMismatch(vIndex, vIndex), ShouldEqual, -1)
+		So(LocateMismatch(vIndex, vIndex[:2]), ShouldEqual, 2)
+
+		vIndex, _ = Checkpoints(bytes.NewReader(nil), vTable, 300)
+		So(vIndex, ShouldResemble, []uint16{0xFFFF})
+		So(func() { NewCheckpointer(vTable, 0, nil) }, ShouldPanic)
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,69 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package crc16
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//-----------------------------------------------------------------------------
+
+// Checksum16 is a checksum value persisted with database/sql. It is stored as an integer
+// and scanned from integers, decimal text or hexadecimal text with the 0x prefix,
+// like its String form.
+type Checksum16 uint16
+
+//-----------------------------------------------------------------------------
+
+// String returns the checksum in hexadecimal with the 0x prefix and four digits.
+func (aC Checksum16) String() string {
+	return hex16(uint16(aC))
+}
+
+//--------------------------------------
+
+// Value implements driver.Valuer.
+func (aC Checksum16) Value() (driver.Value, error) {
+	return int64(aC), nil
+}
+
+//--------------------------------------
+
+// Scan implements sql.Scanner. NULL is rejected; scan into a sql.Null[Checksum16]
+// for nullable columns.
+func (aC *Checksum16) Scan(aSrc any) error {
+	var vText string
+	switch v := aSrc.(type) {
+	case int64:
+		if v < 0 || v > 0xFFFF {
+			return fmt.Errorf("crc16: checksum %d out of range", v)
+		}
+		*aC = Checksum16(v)
+		return nil
+	case []byte:
+		vText = string(v)
+	case string:
+		vText = v
+	default:
+		return fmt.Errorf("crc16: can not scan %T into a checksum", aSrc)
+	}
+
+	vText = strings.TrimSpace(vText)
+	vBase := 10
+	if vHex, vOk := strings.CutPrefix(strings.ToLower(vText), "0x"); vOk {
+		vText, vBase = vHex, 16
+	}
+	v, err := strconv.ParseUint(vText, vBase, 16)
+	if err != nil {
+		return fmt.Errorf("crc16: invalid checksum %q", aSrc)
+	}
+	*aC = Checksum16(v)
+	return nil
+}
+
+//-----------------------------------------------------------------------------
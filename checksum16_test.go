@@ -0,0 +1,44 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || crc16_ibm)
+
+package crc16
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestChecksum16(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vC := Checksum16(Checksum([]byte("123456789"), MakeTable(CRC16_MODBUS)))
+		So(vC.String(), ShouldEqual, "0x4b37")
+		vValue, err := vC.Value()
+		So(err, ShouldBeNil)
+		So(driver.IsValue(vValue), ShouldBeTrue)
+		So(vValue, ShouldEqual, int64(0x4B37))
+
+		for _, vSrc := range []any{int64(0x4B37), "19255", []byte("0x4B37"), vC.String()} {
+			var vScanned Checksum16
+			So(vScanned.Scan(vSrc), ShouldBeNil)
+			So(vScanned, ShouldEqual, vC)
+		}
+		for _, vSrc := range []any{nil, int64(-1), int64(0x10000), "4b37", "0x", 1.5} {
+			var vScanned Checksum16
+			So(vScanned.Scan(vSrc), ShouldNotBeNil)
+		}
+
+		var vNull sql.Null[Checksum16]
+		So(vNull.Scan(nil), ShouldBeNil)
+		So(vNull.Valid, ShouldBeFalse)
+		So(vNull.Scan(int64(7)), ShouldBeNil)
+		So(vNull.V, ShouldEqual, 7)
+	})
+}
+
+//-----------------------------------------------------------------------------
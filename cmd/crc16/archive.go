@@ -0,0 +1,177 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mbsulliv/crc16"
+)
+
+//-----------------------------------------------------------------------------
+
+// Returns the checksums of the regular files in the named tar or zip archive, in archive order.
+// Tar archives may be compressed with gzip; the format is recognized from the content.
+func archiveSums(aName string, aTable *crc16.TTable) ([]TManifestEntry, error) {
+	vFile, err := os.Open(aName)
+	if err != nil {
+		return nil, err
+	}
+	defer vFile.Close()
+
+	vIn := bufio.NewReader(vFile)
+	vMagic, _ := vIn.Peek(4)
+	if bytes.HasPrefix(vMagic, []byte("PK")) {
+		vInfo, err := vFile.Stat()
+		if err != nil {
+			return nil, err
+		}
+		vZip, err := zip.NewReader(vFile, vInfo.Size())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", aName, err)
+		}
+		var vRet []TManifestEntry
+		for _, f := range vZip.File {
+			if !f.Mode().IsRegular() {
+				continue
+			}
+			vEntry, err := f.Open()
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %v", aName, f.Name, err)
+			}
+			vH := crc16.New(aTable)
+			_, err = io.Copy(vH, vEntry)
+			vEntry.Close()
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %v", aName, f.Name, err)
+			}
+			vRet = append(vRet, TManifestEntry{Sum: vH.Sum16(), Name: f.Name})
+		}
+		return vRet, nil
+	}
+
+	var vTar io.Reader = vIn
+	if bytes.HasPrefix(vMagic, []byte{0x1f, 0x8b}) {
+		vZ, err := gzip.NewReader(vIn)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", aName, err)
+		}
+		defer vZ.Close()
+		vTar = vZ
+	}
+	var vRet []TManifestEntry
+	vR := tar.NewReader(vTar)
+	for {
+		vHeader, err := vR.Next()
+		if err == io.EOF {
+			return vRet, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", aName, err)
+		}
+		if vHeader.Typeflag != tar.TypeReg {
+			continue
+		}
+		vH := crc16.New(aTable)
+		if _, err := io.Copy(vH, vR); err != nil {
+			return nil, fmt.Errorf("%s: %s: %v", aName, vHeader.Name, err)
+		}
+		vRet = append(vRet, TManifestEntry{Sum: vH.Sum16(), Name: vHeader.Name})
+	}
+}
+
+//--------------------------------------
+
+// Runs the archive subcommand printing or verifying the checksums of the files in an archive.
+func runArchive(aArgs []string, aIn io.Reader, aOut, aErr io.Writer) int {
+	vFlags := flag.NewFlagSet("crc16 archive", flag.ContinueOnError)
+	vFlags.SetOutput(aErr)
+	var vAlgo string
+	vFlags.StringVar(&vAlgo, "a", cDefaultAlgo, "checksum algorithm (shorthand)")
+	vFlags.StringVar(&vAlgo, "algo", cDefaultAlgo, "checksum algorithm")
+	vSpec := vFlags.String("spec", "", "custom algorithm parameters")
+	vCheck := vFlags.String("c", "", "verify the archive against the checksums listed in the manifest file")
+	if err := vFlags.Parse(aArgs); err != nil {
+		return 2
+	}
+	vTable, err := selectTable(vAlgo, *vSpec)
+	if err == nil && vFlags.NArg() != 1 {
+		err = fmt.Errorf("expected a single archive")
+	}
+	if err != nil {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 2
+	}
+
+	vEntries, err := archiveSums(vFlags.Arg(0), vTable)
+	if err != nil {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 1
+	}
+	if *vCheck == "" {
+		vOpts := &tSumOptions{table: vTable, format: cFormatText}
+		for _, e := range vEntries {
+			if err := writeSum(aOut, vOpts, e.Name, e.Sum); err != nil {
+				fmt.Fprintln(aErr, "crc16:", err)
+				return 1
+			}
+		}
+		return 0
+	}
+
+	vIn := aIn
+	if *vCheck != "-" {
+		vFile, err := os.Open(*vCheck)
+		if err != nil {
+			fmt.Fprintln(aErr, "crc16:", err)
+			return 1
+		}
+		defer vFile.Close()
+		vIn = vFile
+	}
+	vManifest, err := readManifest(vIn)
+	if err != nil {
+		fmt.Fprintf(aErr, "crc16: %s: %v\n", *vCheck, err)
+		return 1
+	}
+	vSums := make(map[string]uint16, len(vEntries))
+	for _, e := range vEntries {
+		vSums[e.Name] = e.Sum
+	}
+	vFailed, vMissing := 0, 0
+	for _, e := range vManifest {
+		vSum, vFound := vSums[e.Name]
+		switch {
+		case !vFound:
+			fmt.Fprintf(aOut, "%s: FAILED missing\n", e.Name)
+			vMissing++
+		case vSum != e.Sum:
+			fmt.Fprintf(aOut, "%s: FAILED\n", e.Name)
+			vFailed++
+		default:
+			fmt.Fprintf(aOut, "%s: OK\n", e.Name)
+		}
+	}
+	if vMissing > 0 {
+		fmt.Fprintf(aErr, "crc16: WARNING: %d listed file(s) are missing from the archive\n", vMissing)
+	}
+	if vFailed > 0 {
+		fmt.Fprintf(aErr, "crc16: WARNING: %d computed checksum(s) did NOT match\n", vFailed)
+	}
+	if vFailed+vMissing > 0 {
+		return 1
+	}
+	return 0
+}
+
+//-----------------------------------------------------------------------------
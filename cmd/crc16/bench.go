@@ -0,0 +1,125 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mbsulliv/crc16"
+)
+
+//-----------------------------------------------------------------------------
+
+// tImpl is an implementation of the checksum measured by the bench subcommand.
+type tImpl struct {
+	name string
+	sum  func(data []byte, aTable *crc16.TTable) uint16
+}
+
+// The implementations measured by the bench subcommand.
+var benchImpls = []tImpl{
+	{"table", crc16.Checksum},
+	{"bitwise", func(data []byte, aTable *crc16.TTable) uint16 {
+		return crc16.ChecksumBitwise(data, aTable.Algo())
+	}},
+}
+
+//-----------------------------------------------------------------------------
+
+// Parses a buffer size in bytes with an optional K, KiB, M or MiB suffix.
+func parseSize(aVal string) (int, error) {
+	vNum, vMul := strings.ToUpper(aVal), 1
+	for _, s := range []struct {
+		suffix string
+		mul    int
+	}{{"KIB", 1 << 10}, {"MIB", 1 << 20}, {"K", 1 << 10}, {"M", 1 << 20}} {
+		if strings.HasSuffix(vNum, s.suffix) {
+			vNum, vMul = strings.TrimSuffix(vNum, s.suffix), s.mul
+			break
+		}
+	}
+	v, err := strconv.Atoi(vNum)
+	if err != nil || v <= 0 {
+		return 0, fmt.Errorf("invalid size %q", aVal)
+	}
+	return v * vMul, nil
+}
+
+//--------------------------------------
+
+// Returns the throughput of the implementation in MB/s, checksumming a buffer
+// of the specified size repeatedly for at least the specified duration.
+func measure(aImpl tImpl, aTable *crc16.TTable, aSize int, aDuration time.Duration) float64 {
+	vBuf := make([]byte, aSize)
+	for i := range vBuf {
+		vBuf[i] = byte(i * 7)
+	}
+	var vBytes int64
+	vStart := time.Now()
+	for vN := 1; ; vN *= 2 {
+		for range vN {
+			aImpl.sum(vBuf, aTable)
+		}
+		vBytes += int64(vN) * int64(aSize)
+		if vElapsed := time.Since(vStart); vElapsed >= aDuration {
+			return float64(vBytes) / 1e6 / vElapsed.Seconds()
+		}
+	}
+}
+
+//--------------------------------------
+
+// Runs the bench subcommand reporting the throughput of each implementation.
+func runBench(aArgs []string, aIn io.Reader, aOut, aErr io.Writer) int {
+	vFlags := flag.NewFlagSet("crc16 bench", flag.ContinueOnError)
+	vFlags.SetOutput(aErr)
+	var vAlgos string
+	vFlags.StringVar(&vAlgos, "a", cDefaultAlgo, "comma-separated algorithms to measure, or all (shorthand)")
+	vFlags.StringVar(&vAlgos, "algo", cDefaultAlgo, "comma-separated algorithms to measure, or all")
+	vSizes := vFlags.String("size", "64,4K,1M", "comma-separated buffer sizes")
+	vDuration := vFlags.Duration("time", time.Second, "minimum duration of each measurement")
+	if err := vFlags.Parse(aArgs); err != nil {
+		return 2
+	}
+
+	vTables, err := lookupTables(vAlgos)
+	if err != nil {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 2
+	}
+	var vSizeList []int
+	for _, s := range strings.Split(*vSizes, ",") {
+		vSize, err := parseSize(strings.TrimSpace(s))
+		if err != nil {
+			fmt.Fprintln(aErr, "crc16:", err)
+			return 2
+		}
+		vSizeList = append(vSizeList, vSize)
+	}
+
+	vW := tabwriter.NewWriter(aOut, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(vW, "ALGORITHM\tIMPL\tSIZE\tMB/s\t")
+	for _, t := range vTables {
+		for _, vImpl := range benchImpls {
+			for _, vSize := range vSizeList {
+				vRate := measure(vImpl, t, vSize, *vDuration)
+				fmt.Fprintf(vW, "%s\t%s\t%d\t%.1f\t\n", t.Algo().Name, vImpl.name, vSize, vRate)
+			}
+		}
+	}
+	if err := vW.Flush(); err != nil {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 1
+	}
+	return 0
+}
+
+//-----------------------------------------------------------------------------
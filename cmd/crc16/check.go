@@ -0,0 +1,177 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mbsulliv/crc16"
+)
+
+//-----------------------------------------------------------------------------
+
+// TManifestEntry is a line of a checksum manifest.
+type TManifestEntry struct {
+	Sum  uint16
+	Name string
+	Algo string // Algorithm named by a tagged line, empty for the one selected by the flags.
+}
+
+//-----------------------------------------------------------------------------
+
+// Parses a manifest line in the md5sum format: checksum, two spaces (or a space
+// and the binary mode asterisk) and the file name, or in the tagged format written by
+// -format bsd, as sha256sum --tag does: "CRC-16/MODBUS (file) = xxxx". The CRC16 tag
+// names no algorithm.
+func parseManifestLine(aLine string) (TManifestEntry, error) {
+	if vTag, vRest, vOk := strings.Cut(aLine, " ("); vOk && vTag != "" && !strings.Contains(vTag, " ") {
+		if i := strings.LastIndex(vRest, ") = "); i >= 0 {
+			vVal, err := strconv.ParseUint(vRest[i+4:], 16, 16)
+			if err != nil {
+				return TManifestEntry{}, fmt.Errorf("improperly formatted checksum %q", vRest[i+4:])
+			}
+			if vTag == cBSDTag {
+				vTag = ""
+			}
+			return TManifestEntry{Sum: uint16(vVal), Name: vRest[:i], Algo: vTag}, nil
+		}
+	}
+	vSum, vName, vOk := strings.Cut(aLine, " ")
+	if !vOk || len(vName) < 2 || (vName[0] != ' ' && vName[0] != '*') {
+		return TManifestEntry{}, fmt.Errorf("improperly formatted line %q", aLine)
+	}
+	vVal, err := strconv.ParseUint(vSum, 16, 16)
+	if err != nil {
+		return TManifestEntry{}, fmt.Errorf("improperly formatted checksum %q", vSum)
+	}
+	return TManifestEntry{Sum: uint16(vVal), Name: vName[1:]}, nil
+}
+
+//--------------------------------------
+
+// Reads all entries of a manifest, skipping empty lines.
+func readManifest(aIn io.Reader) ([]TManifestEntry, error) {
+	var vRet []TManifestEntry
+	vScanner := bufio.NewScanner(aIn)
+	for vScanner.Scan() {
+		vLine := strings.TrimRight(vScanner.Text(), "\r")
+		if vLine == "" {
+			continue
+		}
+		vEntry, err := parseManifestLine(vLine)
+		if err != nil {
+			return nil, err
+		}
+		vRet = append(vRet, vEntry)
+	}
+	return vRet, vScanner.Err()
+}
+
+//--------------------------------------
+
+// Returns the options verifying aEntry: aOpts, unless its line names another algorithm.
+func entryOptions(aEntry TManifestEntry, aOpts *tSumOptions) (*tSumOptions, error) {
+	if aEntry.Algo == "" || strings.EqualFold(aEntry.Algo, aOpts.table.Algo().Name) {
+		return aOpts, nil
+	}
+	vTable, err := lookupTable(aEntry.Algo)
+	if err != nil {
+		return nil, err
+	}
+	vRet := *aOpts
+	vRet.table = vTable
+	return &vRet, nil
+}
+
+//-----------------------------------------------------------------------------
+
+// Verifies the files listed in the named manifest and returns the exit code.
+func checkManifest(aManifest string, aIn io.Reader, aOut, aErr io.Writer, aOpts *tSumOptions) int {
+	vIn := aIn
+	if aManifest != "-" {
+		vFile, err := os.Open(aManifest)
+		if err != nil {
+			fmt.Fprintln(aErr, "crc16:", err)
+			return 1
+		}
+		defer vFile.Close()
+		vIn = vFile
+	}
+	vEntries, err := readManifest(vIn)
+	if err != nil {
+		fmt.Fprintf(aErr, "crc16: %s: %v\n", aManifest, err)
+		return 1
+	}
+	vOpts := make([]*tSumOptions, len(vEntries))
+	for i, e := range vEntries {
+		if vOpts[i], err = entryOptions(e, aOpts); err != nil {
+			fmt.Fprintf(aErr, "crc16: %s: %v\n", aManifest, err)
+			return 1
+		}
+	}
+
+	vFailed, vUnreadable := 0, 0
+	for i, vEntry := range vEntries {
+		var vSum uint16
+		var err error
+		if vEntry.Name == "-" && aManifest == "-" {
+			err = errors.New("-: standard input already read as the manifest")
+		} else {
+			vSum, err = sumFile(vEntry.Name, aIn, vOpts[i])
+		}
+		if aOpts.metrics != nil && err == nil {
+			aOpts.metrics.FramesVerified.Add(1)
+			if vSum != vEntry.Sum {
+				aOpts.metrics.Mismatches.Add(1)
+			}
+		}
+		switch {
+		case err != nil:
+			fmt.Fprintln(aErr, "crc16:", err)
+			fmt.Fprintf(aOut, "%s: FAILED open or read\n", vEntry.Name)
+			vUnreadable++
+		case vSum != vEntry.Sum:
+			fmt.Fprintf(aOut, "%s: FAILED\n", vEntry.Name)
+			vFailed++
+		default:
+			fmt.Fprintf(aOut, "%s: OK\n", vEntry.Name)
+		}
+	}
+	if vUnreadable > 0 {
+		fmt.Fprintf(aErr, "crc16: WARNING: %d listed file(s) could not be read\n", vUnreadable)
+	}
+	if vFailed > 0 {
+		fmt.Fprintf(aErr, "crc16: WARNING: %d computed checksum(s) did NOT match\n", vFailed)
+	}
+	if vFailed+vUnreadable > 0 {
+		return 1
+	}
+	return 0
+}
+
+//-----------------------------------------------------------------------------
+
+// Writes the counters to the named file in the Prometheus text format, replacing it atomically
+// so that a collector never reads a partial file.
+func writeMetrics(aName string, aM *crc16.TMetrics) error {
+	var vB bytes.Buffer
+	if err := aM.WritePrometheus(&vB, "crc16"); err != nil {
+		return err
+	}
+	vTmp := aName + ".tmp"
+	if err := os.WriteFile(vTmp, vB.Bytes(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(vTmp, aName)
+}
+
+//-----------------------------------------------------------------------------
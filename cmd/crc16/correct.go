@@ -0,0 +1,104 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"encoding/binary"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mbsulliv/crc16"
+)
+
+//-----------------------------------------------------------------------------
+
+// Formats the corrected bit positions of a frame with aLen data bytes.
+func formatPositions(aPos []int, aLen int) string {
+	vParts := make([]string, len(aPos))
+	for i, p := range aPos {
+		if p < 8*aLen {
+			vParts[i] = fmt.Sprintf("byte %d bit %d", p/8, p%8)
+		} else {
+			vParts[i] = fmt.Sprintf("checksum bit %d", p-8*aLen)
+		}
+	}
+	return strings.Join(vParts, ", ")
+}
+
+//--------------------------------------
+
+// Runs the correct subcommand repairing a frame whose checksum trailer fails.
+func runCorrect(aArgs []string, aIn io.Reader, aOut, aErr io.Writer) int {
+	vFlags := flag.NewFlagSet("crc16 correct", flag.ContinueOnError)
+	vFlags.SetOutput(aErr)
+	var vAlgo string
+	vFlags.StringVar(&vAlgo, "a", cDefaultAlgo, "checksum algorithm (shorthand)")
+	vFlags.StringVar(&vAlgo, "algo", cDefaultAlgo, "checksum algorithm")
+	vSpec := vFlags.String("spec", "", "custom algorithm parameters")
+	vOrderName := vFlags.String("order", "auto", "trailer byte order: auto, le or be")
+	vBurst := vFlags.Int("burst", 1, "longest burst of consecutive bit errors to correct, at most 16")
+	vOut := vFlags.String("o", "", "write the repaired frame to the file, or - for standard output, instead of in place")
+	if err := vFlags.Parse(aArgs); err != nil {
+		return 2
+	}
+
+	vTable, err := selectTable(vAlgo, *vSpec)
+	var vOrder binary.ByteOrder
+	if err == nil {
+		vOrder, err = parseOrder(*vOrderName, vTable.Algo())
+	}
+	if err == nil && (*vBurst < 1 || *vBurst > 16) {
+		err = fmt.Errorf("correct: burst length %d out of range 1-16", *vBurst)
+	}
+	if err == nil && vFlags.NArg() != 1 {
+		err = fmt.Errorf("correct: a single file must be specified")
+	}
+	if err != nil {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 2
+	}
+
+	vName := vFlags.Arg(0)
+	vFrame, err := readInput(vName, aIn)
+	if err == nil && len(vFrame) < 2 {
+		err = fmt.Errorf("%s: frame shorter than its checksum trailer", vName)
+	}
+	if err != nil {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 1
+	}
+
+	vData := vFrame[:len(vFrame)-2]
+	vReceived := vOrder.Uint16(vFrame[len(vData):])
+	vPos, vOk := crc16.CorrectBurst(vData, vReceived, *vBurst, vTable)
+	if !vOk {
+		fmt.Fprintf(aErr, "crc16: %s: the error can not be corrected unambiguously\n", vName)
+		return 1
+	}
+	vReport := aOut
+	if *vOut == "-" {
+		vReport = aErr
+	}
+	if len(vPos) == 0 {
+		fmt.Fprintf(vReport, "%s: OK\n", vName)
+	} else {
+		fmt.Fprintf(vReport, "%s: corrected %s\n", vName, formatPositions(vPos, len(vData)))
+	}
+
+	// Errors in the trailer are repaired by writing the checksum of the repaired data.
+	vFrame = crc16.AppendChecksum(vData, vTable, vOrder)
+	if *vOut == "" {
+		*vOut = vName
+	}
+	if err := writeOutput(*vOut, aOut, vFrame); err != nil {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 1
+	}
+	return 0
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,150 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+//-----------------------------------------------------------------------------
+
+// The extension of the files diff takes for manifests without -m.
+const cManifestExt = ".crc16"
+
+//-----------------------------------------------------------------------------
+
+// Returns the checksums of the entries of a side of a comparison: the files below
+// a directory by their slash-separated relative paths or the entries of a manifest
+// by their listed names. Files are manifests if aManifest is set or their name has
+// the manifest extension. It returns nil for any other file.
+func diffEntries(aPath string, aManifest bool, aOpts *tSumOptions) (map[string]uint16, error) {
+	vInfo, err := os.Stat(aPath)
+	if err != nil {
+		return nil, err
+	}
+	if !vInfo.IsDir() {
+		if !aManifest && filepath.Ext(aPath) != cManifestExt {
+			return nil, nil
+		}
+		vFile, err := os.Open(aPath)
+		if err != nil {
+			return nil, err
+		}
+		defer vFile.Close()
+		vEntries, err := readManifest(vFile)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", aPath, err)
+		}
+		vRet := make(map[string]uint16, len(vEntries))
+		for _, e := range vEntries {
+			vRet[e.Name] = e.Sum
+		}
+		return vRet, nil
+	}
+
+	vNames, err := expandFiles([]string{aPath}, true, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	vRet := make(map[string]uint16, len(vNames))
+	for i, r := range sumFiles(vNames, nil, aOpts, 1) {
+		vRes := <-r
+		if vRes.err != nil {
+			return nil, vRes.err
+		}
+		vRel, _ := filepath.Rel(aPath, vNames[i])
+		vRet[filepath.ToSlash(vRel)] = vRes.sum
+	}
+	return vRet, nil
+}
+
+//--------------------------------------
+
+// Runs the diff subcommand comparing two files, directories or manifests by checksum.
+// Like diff, it exits with 1 if they differ.
+func runDiff(aArgs []string, aIn io.Reader, aOut, aErr io.Writer) int {
+	vFlags := flag.NewFlagSet("crc16 diff", flag.ContinueOnError)
+	vFlags.SetOutput(aErr)
+	var vAlgo string
+	vFlags.StringVar(&vAlgo, "a", cDefaultAlgo, "checksum algorithm (shorthand)")
+	vFlags.StringVar(&vAlgo, "algo", cDefaultAlgo, "checksum algorithm")
+	vSpec := vFlags.String("spec", "", "custom algorithm parameters")
+	vManifests := vFlags.Bool("m", false, "take files for manifests whatever their extension")
+	if err := vFlags.Parse(aArgs); err != nil {
+		return 2
+	}
+	vTable, err := selectTable(vAlgo, *vSpec)
+	if err == nil && vFlags.NArg() != 2 {
+		err = fmt.Errorf("diff: two files, directories or manifests must be specified")
+	}
+	if err != nil {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 2
+	}
+	vOpts := &tSumOptions{table: vTable, in: cInRaw}
+
+	var vSides [2]map[string]uint16
+	for i := range vSides {
+		vSides[i], err = diffEntries(vFlags.Arg(i), *vManifests, vOpts)
+		if err != nil {
+			fmt.Fprintln(aErr, "crc16:", err)
+			return 2
+		}
+	}
+	if vSides[0] == nil || vSides[1] == nil {
+		if vSides[0] != nil || vSides[1] != nil {
+			fmt.Fprintln(aErr, "crc16: diff: a single file can only be compared with another file")
+			return 2
+		}
+		var vSums [2]uint16
+		for i := range vSums {
+			vSums[i], err = sumFile(vFlags.Arg(i), nil, vOpts)
+			if err != nil {
+				fmt.Fprintln(aErr, "crc16:", err)
+				return 2
+			}
+		}
+		if vSums[0] == vSums[1] {
+			return 0
+		}
+		fmt.Fprintf(aOut, "changed  %s %s: %04x != %04x\n", vFlags.Arg(0), vFlags.Arg(1), vSums[0], vSums[1])
+		return 1
+	}
+
+	var vNames []string
+	for n := range vSides[0] {
+		vNames = append(vNames, n)
+	}
+	for n := range vSides[1] {
+		if _, vOk := vSides[0][n]; !vOk {
+			vNames = append(vNames, n)
+		}
+	}
+	slices.Sort(vNames)
+	vRet := 0
+	for _, n := range vNames {
+		vA, vInA := vSides[0][n]
+		vB, vInB := vSides[1][n]
+		switch {
+		case !vInA:
+			fmt.Fprintf(aOut, "added    %s\n", n)
+		case !vInB:
+			fmt.Fprintf(aOut, "removed  %s\n", n)
+		case vA != vB:
+			fmt.Fprintf(aOut, "changed  %s\n", n)
+		default:
+			continue
+		}
+		vRet = 1
+	}
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,69 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/mbsulliv/crc16"
+)
+
+//-----------------------------------------------------------------------------
+
+// Runs the forge subcommand appending the two bytes giving a file the chosen checksum.
+func runForge(aArgs []string, aIn io.Reader, aOut, aErr io.Writer) int {
+	vFlags := flag.NewFlagSet("crc16 forge", flag.ContinueOnError)
+	vFlags.SetOutput(aErr)
+	var vAlgo string
+	vFlags.StringVar(&vAlgo, "a", cDefaultAlgo, "checksum algorithm (shorthand)")
+	vFlags.StringVar(&vAlgo, "algo", cDefaultAlgo, "checksum algorithm")
+	vSpec := vFlags.String("spec", "", "custom algorithm parameters")
+	vTarget := vFlags.String("target", "", "the checksum to reach, e.g. 0xbeef")
+	vOut := vFlags.String("o", "", "write the result to the file, or - for standard output, instead of in place")
+	if err := vFlags.Parse(aArgs); err != nil {
+		return 2
+	}
+
+	vTable, err := selectTable(vAlgo, *vSpec)
+	var vSum uint64
+	if err == nil {
+		vSum, err = strconv.ParseUint(*vTarget, 0, 16)
+		if err != nil {
+			err = fmt.Errorf("forge: invalid target checksum %q", *vTarget)
+		}
+	}
+	if err == nil && vFlags.NArg() != 1 {
+		err = fmt.Errorf("forge: a single file must be specified")
+	}
+	if err != nil {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 2
+	}
+
+	vName := vFlags.Arg(0)
+	vData, err := readInput(vName, aIn)
+	if err != nil {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 1
+	}
+	vSuffix, vOk := crc16.ForgeSuffix(vData, uint16(vSum), vTable)
+	if !vOk {
+		fmt.Fprintf(aErr, "crc16: forge: checksum 0x%04x cannot be reached with this algorithm\n", vSum)
+		return 1
+	}
+	if *vOut == "" {
+		*vOut = vName
+	}
+	if err := writeOutput(*vOut, aOut, append(vData, vSuffix...)); err != nil {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 1
+	}
+	return 0
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,120 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || (crc16_ccitt && crc16_ibm && crc16_misc))
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mbsulliv/crc16"
+)
+
+//-----------------------------------------------------------------------------
+
+// tProto is a serial protocol supported by the frames subcommand.
+type tProto struct {
+	split  func() bufio.SplitFunc
+	verify func(frame []byte) bool
+	gaps   bool
+}
+
+// The protocols of the frames subcommand by name. Gaps between HDLC frames are flags,
+// not corrupt data, so they are not reported.
+var protos = map[string]tProto{
+	"modbus": {crc16.ModbusSplit, nil, true},
+	"dnp3":   {crc16.DNP3Split, crc16.VerifyDNP3Frame, true},
+	"hdlc":   {crc16.HDLCSplit, crc16.VerifyHDLCFrame, false},
+}
+
+//-----------------------------------------------------------------------------
+
+// Adds the subcommand, which needs the protocol framing of the full catalogue.
+func init() {
+	subcommands["frames"] = runFrames
+}
+
+//--------------------------------------
+
+// Runs the frames subcommand dumping the frames found in a serial capture.
+// It exits with 1 if the capture contains corrupt frames or data.
+func runFrames(aArgs []string, aIn io.Reader, aOut, aErr io.Writer) int {
+	vFlags := flag.NewFlagSet("crc16 frames", flag.ContinueOnError)
+	vFlags.SetOutput(aErr)
+	vProtoName := vFlags.String("proto", "modbus", "protocol of the capture: modbus, dnp3 or hdlc")
+	if err := vFlags.Parse(aArgs); err != nil {
+		return 2
+	}
+	vProto, vOk := protos[*vProtoName]
+	if !vOk || vFlags.NArg() > 1 {
+		if !vOk {
+			fmt.Fprintf(aErr, "crc16: frames: unknown protocol %q\n", *vProtoName)
+		} else {
+			fmt.Fprintln(aErr, "crc16: frames: a single capture must be specified")
+		}
+		return 2
+	}
+
+	vIn := aIn
+	if vName := vFlags.Arg(0); vName != "" && vName != "-" {
+		vFile, err := os.Open(vName)
+		if err != nil {
+			fmt.Fprintln(aErr, "crc16:", err)
+			return 1
+		}
+		defer vFile.Close()
+		vIn = vFile
+	}
+
+	// Frames end where the split advances to, which locates them in the capture.
+	var vPos, vEnd int64
+	var vOffsets []int64
+	vSplit := vProto.split()
+	vScanner := bufio.NewScanner(vIn)
+	vScanner.Buffer(make([]byte, 4096), 1<<16)
+	vScanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		vAdv, vTok, err := vSplit(data, atEOF)
+		if vTok != nil {
+			vOffsets = append(vOffsets, vPos+int64(vAdv-len(vTok)))
+		}
+		vPos += int64(vAdv)
+		return vAdv, vTok, err
+	})
+
+	vW := bufio.NewWriter(aOut)
+	vRet := 0
+	for i := 0; vScanner.Scan(); i++ {
+		vOff, vFrame := vOffsets[i], vScanner.Bytes()
+		if vProto.gaps && vOff > vEnd {
+			fmt.Fprintf(vW, "0x%06x  %4d  GARBAGE\n", vEnd, vOff-vEnd)
+			vRet = 1
+		}
+		vEnd = vOff + int64(len(vFrame))
+		vStatus := "OK"
+		if vProto.verify != nil && !vProto.verify(vFrame) {
+			vStatus = "BAD"
+			vRet = 1
+		}
+		fmt.Fprintf(vW, "0x%06x  %4d  %-7s %x\n", vOff, len(vFrame), vStatus, vFrame)
+	}
+	if vProto.gaps && vPos > vEnd {
+		fmt.Fprintf(vW, "0x%06x  %4d  GARBAGE\n", vEnd, vPos-vEnd)
+		vRet = 1
+	}
+	if err := vScanner.Err(); err != nil {
+		vW.Flush()
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 1
+	}
+	if err := vW.Flush(); err != nil {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 1
+	}
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,44 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || (crc16_ccitt && crc16_ibm && crc16_misc))
+
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/mbsulliv/crc16"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestFrames(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := crc16.MakeTable(crc16.CRC16_MODBUS)
+		vA := crc16.AppendChecksum([]byte{0x01, 0x03, 0x00, 0x00, 0x00, 0x0a}, vTable, binary.LittleEndian)
+		vB := crc16.AppendChecksum([]byte{0x01, 0x03, 0x02, 0x12, 0x34}, vTable, binary.LittleEndian)
+		vCapture := string(vA) + "\x00\x13" + string(vB) + "\xff"
+
+		vCode, vOut, _ := runCmd(vCapture, "frames", "-proto", "modbus")
+		So(vCode, ShouldEqual, 1)
+		So(vOut, ShouldEqual, fmt.Sprintf("0x000000     8  OK      %x\n0x000008     2  GARBAGE\n0x00000a     7  OK      %x\n0x000011     1  GARBAGE\n", vA, vB))
+
+		vSdlc := crc16.MakeTable(crc16.CRC16_IBM_SDLC)
+		vC := crc16.AppendChecksum([]byte{0xff, 0x03, 0xc0, 0x21}, vSdlc, binary.LittleEndian)
+		vD := append([]byte(nil), vC...)
+		vD[3] ^= 1
+		vFile := writeFile(aT.TempDir(), "capture.bin", "\x7e"+string(vC)+"\x7e"+string(vD)+"\x7e")
+		vCode, vOut, _ = runCmd("", "frames", "-proto", "hdlc", vFile)
+		So(vCode, ShouldEqual, 1)
+		So(vOut, ShouldEqual, fmt.Sprintf("0x000001     6  OK      %x\n0x000008     6  BAD     %x\n", vC, vD))
+
+		vCode, _, _ = runCmd("", "frames", "-proto", "can")
+		So(vCode, ShouldEqual, 2)
+	})
+}
+
+//-----------------------------------------------------------------------------
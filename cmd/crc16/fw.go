@@ -0,0 +1,142 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mbsulliv/crc16"
+)
+
+//-----------------------------------------------------------------------------
+
+// tRanges collects the repeated -skip flags.
+type tRanges []crc16.TRange
+
+//-----------------------------------------------------------------------------
+
+// String returns the ranges in the flag notation.
+func (aR *tRanges) String() string {
+	vParts := make([]string, len(*aR))
+	for i, r := range *aR {
+		vParts[i] = fmt.Sprintf("0x%x:%d", r.Offset, r.Len)
+	}
+	return strings.Join(vParts, ",")
+}
+
+//--------------------------------------
+
+// Set parses a range given as the offset and length separated by a colon,
+// each in decimal or hexadecimal with the 0x prefix.
+func (aR *tRanges) Set(aVal string) error {
+	vOff, vLen, vOk := strings.Cut(aVal, ":")
+	if !vOk {
+		return fmt.Errorf("range %q is not in the offset:len form", aVal)
+	}
+	o, err := strconv.ParseUint(vOff, 0, 31)
+	if err != nil {
+		return fmt.Errorf("range %q: invalid offset", aVal)
+	}
+	l, err := strconv.ParseUint(vLen, 0, 31)
+	if err != nil {
+		return fmt.Errorf("range %q: invalid length", aVal)
+	}
+	*aR = append(*aR, crc16.TRange{Offset: int(o), Len: int(l)})
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+
+// Runs the fw subcommand verifying or stamping the checksum field of firmware images.
+func runFirmware(aArgs []string, aIn io.Reader, aOut, aErr io.Writer) int {
+	if len(aArgs) == 0 || (aArgs[0] != "verify" && aArgs[0] != "patch") {
+		fmt.Fprintln(aErr, "crc16: fw: verify or patch must be specified")
+		return 2
+	}
+	vPatch := aArgs[0] == "patch"
+
+	vFlags := flag.NewFlagSet("crc16 fw "+aArgs[0], flag.ContinueOnError)
+	vFlags.SetOutput(aErr)
+	var vAlgo string
+	var vSkip tRanges
+	vFlags.StringVar(&vAlgo, "a", cDefaultAlgo, "checksum algorithm (shorthand)")
+	vFlags.StringVar(&vAlgo, "algo", cDefaultAlgo, "checksum algorithm")
+	vSpec := vFlags.String("spec", "", "custom algorithm parameters")
+	vOffset := vFlags.String("offset", "", "offset of the checksum field, negative to count from the end of the image")
+	vOrderName := vFlags.String("order", "auto", "byte order of the checksum field: auto, le or be")
+	vFlags.Var(&vSkip, "skip", "offset:len range left out of the checksum besides the field itself (repeatable)")
+	if err := vFlags.Parse(aArgs[1:]); err != nil {
+		return 2
+	}
+
+	vTable, err := selectTable(vAlgo, *vSpec)
+	var vLayout crc16.TImageLayout
+	if err == nil {
+		vLayout.Order, err = parseOrder(*vOrderName, vTable.Algo())
+	}
+	var vOff int64
+	if err == nil {
+		vOff, err = strconv.ParseInt(*vOffset, 0, 32)
+		if err != nil {
+			err = fmt.Errorf("fw: invalid checksum field offset %q", *vOffset)
+		}
+	}
+	if err == nil && vFlags.NArg() == 0 {
+		err = fmt.Errorf("fw: no image specified")
+	}
+	if err != nil {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 2
+	}
+	vLayout.Skip = vSkip
+
+	vRet := 0
+	for _, vName := range vFlags.Args() {
+		vImage, err := os.ReadFile(vName)
+		if err != nil {
+			fmt.Fprintln(aErr, "crc16:", err)
+			vRet = 1
+			continue
+		}
+		vLayout.Offset = int(vOff)
+		if vOff < 0 {
+			vLayout.Offset += len(vImage)
+		}
+
+		if vPatch {
+			vSum, err := crc16.PatchImage(vImage, vLayout, vTable)
+			if err == nil {
+				err = os.WriteFile(vName, vImage, 0o644)
+			}
+			if err != nil {
+				fmt.Fprintf(aErr, "crc16: %s: %v\n", vName, err)
+				vRet = 1
+				continue
+			}
+			fmt.Fprintf(aOut, "%s: patched %04x at 0x%x\n", vName, vSum, vLayout.Offset)
+			continue
+		}
+
+		vSum, err := vLayout.Checksum(vImage, vTable)
+		switch {
+		case err != nil:
+			fmt.Fprintf(aErr, "crc16: %s: %v\n", vName, err)
+			vRet = 1
+		case vSum == vLayout.Stored(vImage):
+			fmt.Fprintf(aOut, "%s: OK\n", vName)
+		default:
+			fmt.Fprintf(aOut, "%s: FAILED stored %04x, computed %04x\n", vName, vLayout.Stored(vImage), vSum)
+			vRet = 1
+		}
+	}
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,48 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+//-----------------------------------------------------------------------------
+
+// Returns the function name of the calling function.
+func funcName() string {
+	vRet := "?"
+	vPc, _, _, vOk := runtime.Caller(1)
+	if vOk {
+		vRet = path.Base(runtime.FuncForPC(vPc).Name())
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Runs the command and returns its exit code, standard output and standard error.
+func runCmd(aStdin string, aArgs ...string) (int, string, string) {
+	var vOut, vErr bytes.Buffer
+	vCode := run(aArgs, strings.NewReader(aStdin), &vOut, &vErr)
+	return vCode, vOut.String(), vErr.String()
+}
+
+//--------------------------------------
+
+// Creates a file with the specified content in dir and returns its path.
+func writeFile(aDir, aName, aContent string) string {
+	vPath := filepath.Join(aDir, aName)
+	if err := os.WriteFile(vPath, []byte(aContent), 0o644); err != nil {
+		panic(err)
+	}
+	return vPath
+}
+
+//-----------------------------------------------------------------------------
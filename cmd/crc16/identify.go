@@ -0,0 +1,58 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"encoding/binary"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/mbsulliv/crc16"
+)
+
+//-----------------------------------------------------------------------------
+
+// Runs the identify subcommand printing the predefined algorithms producing an observed checksum.
+// It exits with 1 if there are none.
+func runIdentify(aArgs []string, aIn io.Reader, aOut, aErr io.Writer) int {
+	vFlags := flag.NewFlagSet("crc16 identify", flag.ContinueOnError)
+	vFlags.SetOutput(aErr)
+	vDataHex := vFlags.String("data", "", "the message in hexadecimal")
+	vCrcHex := vFlags.String("crc", "", "the observed checksum bytes in hexadecimal, as transmitted")
+	if err := vFlags.Parse(aArgs); err != nil {
+		return 2
+	}
+	vData, err := decodeHex(*vDataHex)
+	if err != nil {
+		fmt.Fprintln(aErr, "crc16: identify: invalid data:", err)
+		return 2
+	}
+	vCrc, err := decodeHex(*vCrcHex)
+	if err != nil || len(vCrc) != 2 {
+		fmt.Fprintf(aErr, "crc16: identify: invalid checksum %q, 2 bytes expected\n", *vCrcHex)
+		return 2
+	}
+
+	vMatches := crc16.IdentifyChecksum(vData, vCrc)
+	for _, m := range vMatches {
+		vOrder := "be"
+		if m.Order == binary.LittleEndian {
+			vOrder = "le"
+		}
+		if m.Raw {
+			fmt.Fprintf(aOut, "%s  %s  raw\n", m.Algo.Name, vOrder)
+		} else {
+			fmt.Fprintf(aOut, "%s  %s\n", m.Algo.Name, vOrder)
+		}
+	}
+	if len(vMatches) == 0 {
+		fmt.Fprintln(aErr, "crc16: identify: no predefined algorithm matches")
+		return 1
+	}
+	return 0
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,67 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+//-----------------------------------------------------------------------------
+
+// Input encodings accepted by the -in flag.
+const (
+	cInRaw    = "raw"
+	cInHex    = "hex"
+	cInBase64 = "base64"
+)
+
+//-----------------------------------------------------------------------------
+
+// Decodes a hex dump. Whitespace, commas, colons and 0x prefixes separating
+// the bytes, as copied from logic analyzers and debuggers, are ignored.
+func decodeHex(aText string) ([]byte, error) {
+	vFields := strings.FieldsFunc(aText, func(r rune) bool {
+		return unicode.IsSpace(r) || r == ',' || r == ':'
+	})
+	for i, f := range vFields {
+		if len(f) > 2 && (f[:2] == "0x" || f[:2] == "0X") {
+			f = f[2:]
+		}
+		vFields[i] = f
+	}
+	return hex.DecodeString(strings.Join(vFields, ""))
+}
+
+//--------------------------------------
+
+// Decodes base64 in the standard encoding, with or without padding; whitespace is ignored.
+func decodeBase64(aText string) ([]byte, error) {
+	vText := strings.Join(strings.Fields(aText), "")
+	if vRet, err := base64.StdEncoding.DecodeString(vText); err == nil {
+		return vRet, nil
+	}
+	return base64.RawStdEncoding.DecodeString(vText)
+}
+
+//--------------------------------------
+
+// Decodes data in the specified input encoding.
+func decodeInput(aMode string, data []byte) ([]byte, error) {
+	switch aMode {
+	case cInRaw:
+		return data, nil
+	case cInHex:
+		return decodeHex(string(data))
+	case cInBase64:
+		return decodeBase64(string(data))
+	}
+	return nil, fmt.Errorf("unknown input encoding %q", aMode)
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,90 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mbsulliv/crc16"
+)
+
+//-----------------------------------------------------------------------------
+
+// TAlgoInfo is the description of an algorithm printed by the list subcommand.
+type TAlgoInfo struct {
+	Name    string   `json:"name"`
+	Poly    uint16   `json:"poly"`
+	Init    uint16   `json:"init"`
+	RefIn   bool     `json:"refin"`
+	RefOut  bool     `json:"refout"`
+	XorOut  uint16   `json:"xorout"`
+	Check   uint16   `json:"check"`
+	Aliases []string `json:"aliases"`
+}
+
+//-----------------------------------------------------------------------------
+
+// Returns the descriptions of all predefined algorithms. The historical names of
+// an algorithm and the algorithms sharing its parameters are listed as its aliases.
+func algoInfos() []TAlgoInfo {
+	vAlgos := crc16.Algorithms()
+	vRet := make([]TAlgoInfo, 0, len(vAlgos))
+	for _, a := range vAlgos {
+		vInfo := TAlgoInfo{a.Name, a.Poly, a.Init, a.RefIn, a.RefOut, a.XorOut, a.Check, crc16.Aliases(a.Name)}
+		if vInfo.Aliases == nil {
+			vInfo.Aliases = []string{}
+		}
+		for _, b := range vAlgos {
+			if b.Name != a.Name && b.Poly == a.Poly && b.Init == a.Init && b.RefIn == a.RefIn &&
+				b.RefOut == a.RefOut && b.XorOut == a.XorOut {
+				vInfo.Aliases = append(vInfo.Aliases, b.Name)
+			}
+		}
+		vRet = append(vRet, vInfo)
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Runs the list subcommand printing the supported algorithms.
+func runList(aArgs []string, aIn io.Reader, aOut, aErr io.Writer) int {
+	vFlags := flag.NewFlagSet("crc16 list", flag.ContinueOnError)
+	vFlags.SetOutput(aErr)
+	vJSON := vFlags.Bool("json", false, "print the algorithms as a JSON array")
+	if err := vFlags.Parse(aArgs); err != nil {
+		return 2
+	}
+
+	vInfos := algoInfos()
+	if *vJSON {
+		vEnc := json.NewEncoder(aOut)
+		vEnc.SetIndent("", "  ")
+		if err := vEnc.Encode(vInfos); err != nil {
+			fmt.Fprintln(aErr, "crc16:", err)
+			return 1
+		}
+		return 0
+	}
+
+	vW := tabwriter.NewWriter(aOut, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(vW, "NAME\tPOLY\tINIT\tREFIN\tREFOUT\tXOROUT\tCHECK\tALIASES")
+	for _, i := range vInfos {
+		fmt.Fprintf(vW, "%s\t0x%04x\t0x%04x\t%t\t%t\t0x%04x\t0x%04x\t%s\n",
+			i.Name, i.Poly, i.Init, i.RefIn, i.RefOut, i.XorOut, i.Check, strings.Join(i.Aliases, ","))
+	}
+	if err := vW.Flush(); err != nil {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 1
+	}
+	return 0
+}
+
+//-----------------------------------------------------------------------------
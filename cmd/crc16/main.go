@@ -0,0 +1,388 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+// Command crc16 prints or checks CRC-16 checksums of files using the algorithms of package crc16.
+//
+// Usage:
+//
+//	crc16 [-a algo | -spec spec] [-in raw|hex|base64] [-format fmt] [-text] [-r] [-include glob] [-exclude glob] [-j n] [-files-from list [-0]] [file ...]
+//	crc16 [-a algo | -spec spec] [-in raw|hex|base64] [-format fmt] [-text] -d data
+//	crc16 [-a algo] [-text] -c manifest [-metrics file]
+//	crc16 list [-json]
+//	crc16 archive [-a algo | -spec spec] [-c manifest] archive
+//	crc16 bench [-a algo,... | -a all] [-size n,...] [-time d]
+//	crc16 seal [-a algo | -spec spec] [-order auto|le|be] [-o output] file ...
+//	crc16 open [-a algo | -spec spec] [-order auto|le|be] [-o output] file ...
+//	crc16 verify [-a algo | -spec spec] [-order auto|le|be] file ...
+//	crc16 fw verify|patch [-a algo | -spec spec] -offset n [-order auto|le|be] [-skip off:len ...] image ...
+//	crc16 frames [-proto modbus|dnp3|hdlc] [capture]
+//	crc16 correct [-a algo | -spec spec] [-order auto|le|be] [-burst n] [-o output] file
+//	crc16 residue [-a algo | -spec spec] [file ...]
+//	crc16 forge [-a algo | -spec spec] -target crc [-o output] file
+//	crc16 diff [-a algo | -spec spec] [-m] a b
+//	crc16 tag [-a algo | -spec spec] [-r] [-n] file ...
+//	crc16 watch [-a algo | -spec spec] -manifest file [-update] [-interval d] dir
+//	crc16 selftest [-q]
+//	crc16 vectors [-a algo,... | -a all | -spec spec] [-o output]
+//	crc16 identify -data hex -crc hex
+//	crc16 reveng -sample data:crc [-sample data:crc ...]
+//	crc16 table [-a algo | -spec spec] [-format go|c|csv] [-name ident] [-pkg name]
+//
+// With no file, or when file is -, standard input is read. Output lines follow
+// the md5sum format: the checksum in hexadecimal, two spaces and the file name.
+//
+// The -spec flag defines a custom algorithm in the notation of the CRC RevEng catalogue,
+// e.g. -spec "poly=0x1021 init=0xffff refin=true refout=true xorout=0xffff".
+//
+// The -in flag selects how the input is encoded: raw bytes, a hex dump or base64.
+// With -d, the data is taken from the command line and only its checksum is printed.
+//
+// With -text, CRLF line endings are converted to LF before checksumming, so that text files
+// produce the same checksums in Windows and Unix checkouts.
+//
+// The -format flag selects the output format: text (the default), json (an object per line),
+// bsd ("CRC-16/ARC (file) = xxxx", tagged with the algorithm), dec (decimal checksums)
+// or raw-le and raw-be, which write the checksums as binary in little- or big-endian byte order.
+// With -c, lines tagged with an algorithm are verified with it rather than the one selected.
+//
+// With -r, directories are walked recursively. The -include and -exclude flags, which
+// may be repeated, filter the files found by glob patterns matched against their path
+// below the directory or their base name; -j sets the number of files checksummed in parallel.
+// Results are printed in the order of the files regardless.
+//
+// The -files-from flag reads the names of further files from a list, or from standard input
+// for -, one per line or, with -0, separated by NUL characters as written by find -print0.
+//
+// With -c, the files listed in a manifest produced earlier are checksummed again
+// and the command exits with a non-zero code if any of them does not match. The -metrics flag
+// writes the numbers of files verified, mismatches and bytes hashed to a file in the Prometheus
+// text format, e.g. for the textfile collector of the node exporter.
+//
+// The archive subcommand prints the checksums of the regular files in a tar archive, possibly
+// compressed with gzip, or a zip archive without extracting them. With -c, the archive is
+// verified against a manifest instead, and files listed but missing from it fail.
+//
+// The diff subcommand compares two files, directories or manifests by checksum and prints
+// the entries added, removed or changed, keyed by the path below a directory or the name
+// listed in a manifest. Files are taken for manifests if their name ends in .crc16, or with -m.
+// Like diff, it exits with 1 if there are differences.
+//
+// The watch subcommand polls a directory tree and reports files added, modified or removed,
+// verifying them against a manifest or, with -update, rewriting the manifest to match.
+//
+// The tag subcommand stores the checksum and modification time of files in their user.crc16
+// extended attribute, on Linux and macOS, and verifies them on later runs: a file whose content
+// changed while its modification time did not is reported CORRUPT, a sign of silent corruption,
+// while the tags of files modified since are updated. With -n, the tags are left unchanged.
+//
+// The seal subcommand appends the checksum of a file to it as a 2-byte trailer, open verifies
+// and strips the trailer and verify only checks it. They work in place unless -o is given;
+// the auto byte order is little-endian for algorithms with reflected output and big-endian otherwise.
+//
+// The fw subcommand verifies or patches the checksum field embedded at -offset in firmware images.
+// The checksum covers the image except the field and the -skip ranges.
+//
+// The frames subcommand splits a raw serial capture into the frames of a protocol and
+// prints their offsets, lengths, status and contents, along with the ranges of garbage between them.
+//
+// The identify subcommand lists the predefined algorithms producing the checksum observed for
+// a message, in either byte order and with or without the final XOR.
+//
+// The residue subcommand validates streams ending in their own checksum trailer without
+// splitting it off: the checksum of such a stream is a constant of the algorithm, given
+// a little-endian trailer for algorithms with reflected output and a big-endian one otherwise.
+//
+// The correct subcommand repairs a frame with a checksum trailer that fails verification
+// if a single burst of at most -burst bit errors explains the mismatch unambiguously.
+//
+// The vectors subcommand writes golden test vectors of the algorithms in JSON, for validating
+// implementations in other languages: messages of varied lengths and boundary values with their
+// checksums and the offsets an incremental update may be split at.
+//
+// The forge subcommand appends to a file the two bytes giving it the checksum set by -target.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/mbsulliv/crc16"
+)
+
+//-----------------------------------------------------------------------------
+
+// The algorithm used when none is specified.
+const cDefaultAlgo = "CRC-16/ARC"
+
+// tSumOptions are the settings of checksumming shared by the modes of the command.
+type tSumOptions struct {
+	table   *crc16.TTable
+	in      string
+	format  string
+	text    bool
+	metrics *crc16.TMetrics
+}
+
+// The subcommands by name; without one the command checksums files.
+var subcommands = map[string]func(aArgs []string, aIn io.Reader, aOut, aErr io.Writer) int{
+	"archive":  runArchive,
+	"bench":    runBench,
+	"correct":  runCorrect,
+	"diff":     runDiff,
+	"forge":    runForge,
+	"fw":       runFirmware,
+	"identify": runIdentify,
+	"list":     runList,
+	"open":     runOpen,
+	"residue":  runResidue,
+	"reveng":   runReveng,
+	"seal":     runSeal,
+	"selftest": runSelfTest,
+	"table":    runTable,
+	"tag":      runTag,
+	"vectors":  runVectors,
+	"verify":   runVerify,
+	"watch":    runWatch,
+}
+
+//-----------------------------------------------------------------------------
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+//--------------------------------------
+
+// Runs the command with the specified arguments and streams and returns the exit code.
+func run(aArgs []string, aIn io.Reader, aOut, aErr io.Writer) int {
+	if len(aArgs) > 0 {
+		if vCmd, vOk := subcommands[aArgs[0]]; vOk {
+			return vCmd(aArgs[1:], aIn, aOut, aErr)
+		}
+	}
+
+	vFlags := flag.NewFlagSet("crc16", flag.ContinueOnError)
+	vFlags.SetOutput(aErr)
+	var vAlgo, vSpec, vCheck, vData, vMetrics string
+	var vOpts tSumOptions
+	var vRecursive bool
+	var vInclude, vExclude tPatterns
+	var vJobs int
+	var vFilesFrom string
+	var vNul bool
+	vFlags.StringVar(&vAlgo, "a", cDefaultAlgo, "checksum algorithm (shorthand)")
+	vFlags.StringVar(&vAlgo, "algo", cDefaultAlgo, "checksum algorithm, e.g. CRC-16/MODBUS or modbus")
+	vFlags.StringVar(&vSpec, "spec", "", "custom algorithm parameters, e.g. \"poly=0x1021 init=0xffff\"")
+	vFlags.StringVar(&vCheck, "c", "", "verify the checksums listed in the manifest file")
+	vFlags.StringVar(&vMetrics, "metrics", "", "with -c, write the verification counters to the file in the Prometheus text format")
+	vFlags.StringVar(&vOpts.in, "in", cInRaw, "input encoding: raw, hex or base64")
+	vFlags.StringVar(&vOpts.format, "format", cFormatText, "output format: text, json, bsd, dec, raw-le or raw-be")
+	vFlags.BoolVar(&vRecursive, "r", false, "checksum the files in directories recursively")
+	vFlags.Var(&vInclude, "include", "checksum only files matching the glob pattern (repeatable)")
+	vFlags.Var(&vExclude, "exclude", "skip files and directories matching the glob pattern (repeatable)")
+	vFlags.IntVar(&vJobs, "j", runtime.NumCPU(), "number of files checksummed in parallel")
+	vFlags.StringVar(&vFilesFrom, "files-from", "", "read the names of the files to checksum from the file, or - for standard input")
+	vFlags.BoolVar(&vNul, "0", false, "names read with -files-from are separated by NUL characters instead of newlines")
+	vFlags.StringVar(&vData, "d", "", "checksum the data given on the command line")
+	vFlags.BoolVar(&vOpts.text, "text", false, "convert CRLF line endings to LF before checksumming")
+	if err := vFlags.Parse(aArgs); err != nil {
+		return 2
+	}
+
+	var err error
+	vOpts.table, err = selectTable(vAlgo, vSpec)
+	if err == nil {
+		_, err = decodeInput(vOpts.in, nil)
+	}
+	if err == nil {
+		err = checkFormat(vOpts.format)
+	}
+	if err != nil {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 2
+	}
+	if vCheck != "" {
+		if vMetrics == "" {
+			return checkManifest(vCheck, aIn, aOut, aErr, &vOpts)
+		}
+		vOpts.metrics = new(crc16.TMetrics)
+		vRet := checkManifest(vCheck, aIn, aOut, aErr, &vOpts)
+		if err := writeMetrics(vMetrics, vOpts.metrics); err != nil {
+			fmt.Fprintln(aErr, "crc16:", err)
+			return 1
+		}
+		return vRet
+	}
+	if vData != "" {
+		vBytes, err := decodeInput(vOpts.in, []byte(vData))
+		if err != nil {
+			fmt.Fprintln(aErr, "crc16:", err)
+			return 1
+		}
+		vH := newHash(&vOpts)
+		vH.Write(vBytes)
+		if err := writeSum(aOut, &vOpts, "", vH.Sum16()); err != nil {
+			fmt.Fprintln(aErr, "crc16:", err)
+			return 1
+		}
+		return 0
+	}
+
+	vArgs := vFlags.Args()
+	if vFilesFrom != "" {
+		vList, err := readInput(vFilesFrom, aIn)
+		if err != nil {
+			fmt.Fprintln(aErr, "crc16:", err)
+			return 1
+		}
+		vArgs = append(vArgs, splitFileList(vList, vNul)...)
+	} else if len(vArgs) == 0 {
+		vArgs = []string{"-"}
+	}
+	vFiles, err := expandFiles(vArgs, vRecursive, vInclude, vExclude)
+	if err != nil {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 1
+	}
+	vRet := 0
+	for i, vResult := range sumFiles(vFiles, aIn, &vOpts, vJobs) {
+		vRes := <-vResult
+		if vRes.err != nil {
+			fmt.Fprintln(aErr, "crc16:", vRes.err)
+			vRet = 1
+			continue
+		}
+		if err := writeSum(aOut, &vOpts, vFiles[i], vRes.sum); err != nil {
+			fmt.Fprintln(aErr, "crc16:", err)
+			return 1
+		}
+	}
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
+
+// Returns the table of the predefined algorithm with the specified name or alias.
+// Names are matched as by crc16.AlgoByName.
+func lookupTable(aName string) (*crc16.TTable, error) {
+	if vAlgo, vFound := crc16.AlgoByName(aName); vFound {
+		return crc16.MakeTable(*vAlgo), nil
+	}
+	return nil, fmt.Errorf("unknown algorithm %q", aName)
+}
+
+//--------------------------------------
+
+// Returns the tables of the predefined algorithms in a comma-separated list, or of all of them for "all".
+func lookupTables(aNames string) ([]*crc16.TTable, error) {
+	var vRet []*crc16.TTable
+	if strings.EqualFold(aNames, "all") {
+		for _, a := range crc16.Algorithms() {
+			vRet = append(vRet, crc16.MakeTable(a))
+		}
+		return vRet, nil
+	}
+	for _, vName := range strings.Split(aNames, ",") {
+		vTable, err := lookupTable(strings.TrimSpace(vName))
+		if err != nil {
+			return nil, err
+		}
+		vRet = append(vRet, vTable)
+	}
+	return vRet, nil
+}
+
+//--------------------------------------
+
+// Returns the table of the custom algorithm defined by aSpec if it is not empty,
+// or of the predefined algorithm aName otherwise.
+func selectTable(aName, aSpec string) (*crc16.TTable, error) {
+	if aSpec == "" {
+		return lookupTable(aName)
+	}
+	vAlgo, err := crc16.ParseAlgo(aSpec)
+	if err != nil {
+		return nil, err
+	}
+	return crc16.MakeTable(vAlgo), nil
+}
+
+//--------------------------------------
+
+// Returns the names in a file list, separated by NUL characters if aNul is set or by
+// newlines otherwise. Empty names are skipped.
+func splitFileList(aList []byte, aNul bool) []string {
+	vSep := "\n"
+	if aNul {
+		vSep = "\x00"
+	}
+	var vRet []string
+	for _, n := range strings.Split(string(aList), vSep) {
+		if !aNul {
+			n = strings.TrimSuffix(n, "\r")
+		}
+		if n != "" {
+			vRet = append(vRet, n)
+		}
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Returns the digest computing checksums with the options, in text mode if set.
+func newHash(aOpts *tSumOptions) crc16.Hash16 {
+	if aOpts.text {
+		return crc16.NewText(aOpts.table)
+	}
+	return crc16.New(aOpts.table)
+}
+
+//--------------------------------------
+
+// Returns the checksum of the named file, or of aIn for "-".
+func sumFile(aName string, aIn io.Reader, aOpts *tSumOptions) (uint16, error) {
+	vIn := aIn
+	if aName != "-" {
+		vFile, err := os.Open(aName)
+		if err != nil {
+			return 0, err
+		}
+		defer vFile.Close()
+		vIn = vFile
+	}
+
+	if aOpts.in != cInRaw {
+		vText, err := io.ReadAll(vIn)
+		if err != nil {
+			return 0, err
+		}
+		vData, err := decodeInput(aOpts.in, vText)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %v", aName, err)
+		}
+		if aOpts.metrics != nil {
+			aOpts.metrics.BytesHashed.Add(uint64(len(vData)))
+		}
+		vH := newHash(aOpts)
+		vH.Write(vData)
+		return vH.Sum16(), nil
+	}
+
+	vH := newHash(aOpts)
+	n, err := io.Copy(vH, vIn)
+	if err != nil {
+		return 0, err
+	}
+	if aOpts.metrics != nil {
+		aOpts.metrics.BytesHashed.Add(uint64(n))
+	}
+	return vH.Sum16(), nil
+}
+
+//-----------------------------------------------------------------------------
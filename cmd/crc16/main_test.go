@@ -0,0 +1,739 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || (crc16_ccitt && crc16_ibm))
+
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mbsulliv/crc16"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestSum(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vCode, vOut, _ := runCmd("123456789")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, "bb3d  -\n")
+
+		vCode, vOut, _ = runCmd("123456789", "-a", "modbus", "-")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, "4b37  -\n")
+
+		vFile := writeFile(aT.TempDir(), "check.txt", "123456789")
+		vCode, vOut, _ = runCmd("", "--algo", "CRC-16/XMODEM", vFile)
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, "31c3  "+vFile+"\n")
+
+		vCode, _, vErr := runCmd("", "-a", "CRC-16/NONE")
+		So(vCode, ShouldEqual, 2)
+		So(vErr, ShouldContainSubstring, "unknown algorithm")
+
+		vCode, _, _ = runCmd("", filepath.Join(aT.TempDir(), "missing"))
+		So(vCode, ShouldEqual, 1)
+	})
+}
+
+//--------------------------------------
+
+func TestCheck(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vDir := aT.TempDir()
+		vA := writeFile(vDir, "a.bin", "123456789")
+		vB := writeFile(vDir, "b.bin", "hello")
+
+		_, vManifest, _ := runCmd("", "-a", "modbus", vA, vB)
+		vPath := writeFile(vDir, "m.crc16", vManifest)
+
+		vCode, vOut, _ := runCmd("", "-a", "modbus", "-c", vPath)
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, vA+": OK\n"+vB+": OK\n")
+
+		vCode, _, _ = runCmd(vManifest, "-a", "modbus", "-c", "-")
+		So(vCode, ShouldEqual, 0)
+
+		writeFile(vDir, "b.bin", "hellO")
+		vCode, vOut, vErr := runCmd("", "-a", "modbus", "-c", vPath)
+		So(vCode, ShouldEqual, 1)
+		So(vOut, ShouldContainSubstring, vB+": FAILED\n")
+		So(vErr, ShouldContainSubstring, "1 computed checksum(s) did NOT match")
+
+		vMetrics := filepath.Join(vDir, "crc16.prom")
+		vCode, _, _ = runCmd("", "-a", "modbus", "-c", vPath, "-metrics", vMetrics)
+		So(vCode, ShouldEqual, 1)
+		vProm, err := os.ReadFile(vMetrics)
+		So(err, ShouldBeNil)
+		So(string(vProm), ShouldContainSubstring, "# TYPE crc16_frames_verified_total counter\ncrc16_frames_verified_total 2\n")
+		So(string(vProm), ShouldContainSubstring, "\ncrc16_mismatches_total 1\n")
+		So(string(vProm), ShouldContainSubstring, "\ncrc16_bytes_hashed_total 14\n")
+
+		// Standard input listed in the manifest is read unless it holds the manifest.
+		_, vStdin, _ := runCmd("123456789", "-a", "modbus", "-")
+		vStdinManifest := writeFile(vDir, "stdin.crc16", vStdin)
+		vCode, vOut, _ = runCmd("123456789", "-a", "modbus", "-c", vStdinManifest)
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, "-: OK\n")
+		vCode, vOut, vErr = runCmd(vStdin, "-a", "modbus", "-c", "-")
+		So(vCode, ShouldEqual, 1)
+		So(vOut, ShouldEqual, "-: FAILED open or read\n")
+		So(vErr, ShouldContainSubstring, "already read as the manifest")
+
+		vCode, _, vErr = runCmd("zzzz  file\n", "-c", "-")
+		So(vCode, ShouldEqual, 1)
+		So(vErr, ShouldContainSubstring, "improperly formatted")
+
+		// Tagged lines are verified with the algorithm they name.
+		writeFile(vDir, "b.bin", "hello")
+		_, vTagged, _ := runCmd("", "-a", "modbus", "-format", "bsd", vA, vB)
+		So(vTagged, ShouldStartWith, "CRC-16/MODBUS ("+vA+") = 4b37\n")
+		vCode, vOut, _ = runCmd(vTagged, "-c", "-")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, vA+": OK\n"+vB+": OK\n")
+		vCode, vOut, _ = runCmd("CRC16 ("+vA+") = bb3d\n", "-c", "-")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, vA+": OK\n")
+		vCode, _, vErr = runCmd("CRC-16/ACME ("+vA+") = 4b37\n", "-c", "-")
+		So(vCode, ShouldEqual, 1)
+		So(vErr, ShouldContainSubstring, `unknown algorithm "CRC-16/ACME"`)
+	})
+}
+
+//--------------------------------------
+
+func TestList(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vCode, vOut, _ := runCmd("", "list")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldStartWith, "NAME")
+		So(vOut, ShouldContainSubstring, "CRC-16/MODBUS")
+		So(vOut, ShouldContainSubstring, "CRC-16/IBM-3740")
+
+		vCode, vOut, _ = runCmd("", "list", "-json")
+		So(vCode, ShouldEqual, 0)
+		var vInfos []TAlgoInfo
+		So(json.Unmarshal([]byte(vOut), &vInfos), ShouldBeNil)
+		So(len(vInfos), ShouldEqual, len(crc16.Algorithms()))
+		for _, i := range vInfos {
+			if i.Name == "CRC-16/IBM-SDLC" {
+				So(i.Aliases, ShouldContain, "CRC-16/X-25")
+				So(i.Check, ShouldEqual, 0x906E)
+			}
+		}
+	})
+}
+
+//--------------------------------------
+
+func TestTable(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vCode, vOut, _ := runCmd("", "table", "-a", "xmodem")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldContainSubstring, "package main\n")
+		So(vOut, ShouldContainSubstring, "var crc16XmodemTable = [256]uint16{")
+
+		vCode, vOut, _ = runCmd("", "table", "--algo", "CRC-16/XMODEM", "--format", "c", "-name", "tbl")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldContainSubstring, "static const uint16_t tbl[256]")
+
+		vCode, vOut, _ = runCmd("", "table", "-a", "xmodem", "-format", "csv")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldStartWith, "index,value\n0,0x0000\n1,0x1021\n")
+		So(strings.Count(vOut, "\n"), ShouldEqual, 257)
+
+		vCode, _, _ = runCmd("", "table", "-format", "rust")
+		So(vCode, ShouldEqual, 2)
+	})
+}
+
+//--------------------------------------
+
+func TestInputEncoding(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vCode, vOut, _ := runCmd("31 32 33 34 35\n36 37 38 39\n", "-in", "hex")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, "bb3d  -\n")
+
+		vCode, vOut, _ = runCmd("MTIzNDU2Nzg5", "-in", "base64", "-a", "modbus")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, "4b37  -\n")
+
+		vCode, vOut, _ = runCmd("", "-in", "hex", "-d", "0x31,0x32,0x33:34:35:36:37:38:39")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, "bb3d\n")
+
+		vCode, vOut, _ = runCmd("", "-d", "123456789")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, "bb3d\n")
+
+		vCode, _, _ = runCmd("3g", "-in", "hex")
+		So(vCode, ShouldEqual, 1)
+
+		vCode, _, _ = runCmd("", "-in", "octal")
+		So(vCode, ShouldEqual, 2)
+	})
+}
+
+//--------------------------------------
+
+func TestFormat(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vCode, vOut, _ := runCmd("123456789", "-format", "bsd")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, "CRC-16/ARC (-) = bb3d\n")
+
+		vCode, vOut, _ = runCmd("123456789", "-format", "dec")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, "47933  -\n")
+
+		vCode, vOut, _ = runCmd("123456789", "-format", "raw-le")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, "\x3d\xbb")
+
+		vCode, vOut, _ = runCmd("", "-format", "raw-be", "-d", "123456789")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, "\xbb\x3d")
+
+		vCode, vOut, _ = runCmd("123456789", "-format", "json", "-a", "xmodem")
+		So(vCode, ShouldEqual, 0)
+		var vSum tJSONSum
+		So(json.Unmarshal([]byte(vOut), &vSum), ShouldBeNil)
+		So(vSum, ShouldResemble, tJSONSum{Name: "-", Algo: "CRC-16/XMODEM", Crc: "31c3", Value: 0x31c3})
+
+		vCode, _, _ = runCmd("", "-format", "yaml")
+		So(vCode, ShouldEqual, 2)
+	})
+}
+
+//--------------------------------------
+
+func TestRecursive(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vDir := aT.TempDir()
+		So(os.MkdirAll(filepath.Join(vDir, "fw", "build"), 0o755), ShouldBeNil)
+		writeFile(vDir, "a.bin", "123456789")
+		writeFile(vDir, "b.txt", "hello")
+		writeFile(vDir, "fw/c.bin", "")
+		writeFile(vDir, "fw/build/d.bin", "x")
+
+		vCode, vOut, _ := runCmd("", "-r", "-j", "3", vDir)
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, fmt.Sprintf("bb3d  %s\n34d2  %s\n%04x  %s\n0000  %s\n",
+			filepath.Join(vDir, "a.bin"), filepath.Join(vDir, "b.txt"),
+			crc16.Checksum([]byte("x"), crc16.MakeTable(crc16.CRC16_ARC)), filepath.Join(vDir, "fw", "build", "d.bin"),
+			filepath.Join(vDir, "fw", "c.bin")))
+
+		vCode, vOut, _ = runCmd("", "-r", "-include", "*.bin", "-exclude", "build", vDir)
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, fmt.Sprintf("bb3d  %s\n0000  %s\n", filepath.Join(vDir, "a.bin"), filepath.Join(vDir, "fw", "c.bin")))
+
+		vCode, _, _ = runCmd("", "-include", "[", vDir)
+		So(vCode, ShouldEqual, 2)
+	})
+}
+
+//--------------------------------------
+
+func TestBench(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vSize, err := parseSize("4KiB")
+		So(err, ShouldBeNil)
+		So(vSize, ShouldEqual, 4096)
+		vSize, err = parseSize("2m")
+		So(err, ShouldBeNil)
+		So(vSize, ShouldEqual, 2<<20)
+		_, err = parseSize("0")
+		So(err, ShouldNotBeNil)
+
+		vCode, vOut, _ := runCmd("", "bench", "-a", "modbus,xmodem", "-size", "16,1K", "-time", "1ms")
+		So(vCode, ShouldEqual, 0)
+		vLines := strings.Split(strings.TrimSpace(vOut), "\n")
+		So(len(vLines), ShouldEqual, 1+2*len(benchImpls)*2)
+		So(vLines[1], ShouldContainSubstring, "CRC-16/MODBUS")
+
+		vCode, _, _ = runCmd("", "bench", "-a", "nope")
+		So(vCode, ShouldEqual, 2)
+	})
+}
+
+//--------------------------------------
+
+func TestSelfTest(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vCode, vOut, _ := runCmd("", "selftest")
+		So(vCode, ShouldEqual, 0)
+		So(strings.Count(vOut, ": OK\n"), ShouldEqual, len(crc16.Algorithms()))
+
+		vCode, vOut, _ = runCmd("", "selftest", "-q")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldBeEmpty)
+
+		vAlgo := crc16.CRC16_MODBUS
+		vAlgo.Check++
+		So(selfTest(vAlgo), ShouldHaveLength, 2)
+	})
+}
+
+//--------------------------------------
+
+func TestSeal(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vDir := aT.TempDir()
+		vFile := writeFile(vDir, "fw.bin", "123456789")
+
+		vCode, _, _ := runCmd("", "seal", "-a", "modbus", vFile)
+		So(vCode, ShouldEqual, 0)
+		vData, _ := os.ReadFile(vFile)
+		So(string(vData), ShouldEqual, "123456789\x37\x4b")
+
+		vCode, vOut, _ := runCmd("", "verify", "-a", "modbus", vFile)
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, vFile+": OK\n")
+		vCode, vOut, _ = runCmd("", "verify", "-a", "modbus", "-order", "be", vFile)
+		So(vCode, ShouldEqual, 1)
+		So(vOut, ShouldEqual, vFile+": FAILED\n")
+
+		vCode, vOut, _ = runCmd("", "open", "-a", "modbus", "-o", "-", vFile)
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, "123456789")
+		vCode, _, vErr := runCmd("", "open", "-a", "xmodem", vFile)
+		So(vCode, ShouldEqual, 1)
+		So(vErr, ShouldContainSubstring, "checksum trailer mismatch: stored 374b, computed 31c3")
+		vCode, _, _ = runCmd("", "open", "-a", "modbus", vFile)
+		So(vCode, ShouldEqual, 0)
+		vData, _ = os.ReadFile(vFile)
+		So(string(vData), ShouldEqual, "123456789")
+
+		vCode, vOut, _ = runCmd("123456789", "seal", "-a", "xmodem", "-o", "-", "-")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, "123456789\x31\xc3")
+
+		vCode, _, _ = runCmd("", "seal", "-order", "middle", vFile)
+		So(vCode, ShouldEqual, 2)
+		vCode, _, _ = runCmd("", "seal")
+		So(vCode, ShouldEqual, 2)
+	})
+}
+
+//--------------------------------------
+
+func TestForge(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vDir := aT.TempDir()
+		vFile := writeFile(vDir, "fixture.bin", "123456789")
+
+		vCode, _, _ := runCmd("", "forge", "-a", "xmodem", "-target", "0xBEEF", vFile)
+		So(vCode, ShouldEqual, 0)
+		vCode, vOut, _ := runCmd("", "-a", "xmodem", vFile)
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, "beef  "+vFile+"\n")
+
+		vCode, vOut, _ = runCmd("abc", "forge", "-target", "4660", "-o", "-", "-")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldStartWith, "abc")
+		So(crc16.Checksum([]byte(vOut), crc16.MakeTable(crc16.CRC16_ARC)), ShouldEqual, 0x1234)
+
+		vCode, _, _ = runCmd("", "forge", "-target", "0x10000", vFile)
+		So(vCode, ShouldEqual, 2)
+		vCode, _, _ = runCmd("", "forge", "-spec", "poly=0x1020", "-target", "1", vFile)
+		So(vCode, ShouldEqual, 1)
+	})
+}
+
+//--------------------------------------
+
+func TestCorrect(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vDir := aT.TempDir()
+		vFrame := crc16.AppendChecksum([]byte("corrupted frame"), crc16.MakeTable(crc16.CRC16_MODBUS), binary.LittleEndian)
+		vBad := append([]byte(nil), vFrame...)
+		vBad[3] ^= 0x0c
+		vFile := writeFile(vDir, "bad.frame", string(vBad))
+
+		vCode, _, _ := runCmd("", "correct", "-a", "modbus", vFile)
+		So(vCode, ShouldEqual, 1)
+		vCode, vOut, _ := runCmd("", "correct", "-a", "modbus", "-burst", "2", vFile)
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, vFile+": corrected byte 3 bit 2, byte 3 bit 3\n")
+		vData, _ := os.ReadFile(vFile)
+		So(vData, ShouldResemble, vFrame)
+
+		vCode, vOut, _ = runCmd("", "correct", "-a", "modbus", vFile)
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, vFile+": OK\n")
+
+		vBad = append([]byte(nil), vFrame...)
+		vBad[len(vBad)-1] ^= 0x80
+		vCode, vOut, vErr := runCmd(string(vBad), "correct", "-a", "modbus", "-o", "-", "-")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, string(vFrame))
+		So(vErr, ShouldEqual, "-: corrected checksum bit 15\n")
+
+		vCode, _, _ = runCmd("", "correct", "-burst", "17", vFile)
+		So(vCode, ShouldEqual, 2)
+	})
+}
+
+//--------------------------------------
+
+func TestWatch(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vDir := aT.TempDir()
+		vTree := filepath.Join(vDir, "tree")
+		So(os.Mkdir(vTree, 0o755), ShouldBeNil)
+		vA := writeFile(vTree, "a.bin", "123456789")
+		vB := writeFile(vTree, "b.bin", "hello")
+		vManifest := filepath.Join(vDir, "tree.crc16")
+
+		vCode, _, _ := runCmd("", "watch", "-manifest", vManifest, "-count", "1", vTree)
+		So(vCode, ShouldEqual, 1)
+
+		vCode, vOut, _ := runCmd("", "watch", "-manifest", vManifest, "-update", "-count", "1", vTree)
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, vA+": ADDED\n"+vB+": ADDED\n")
+		vData, _ := os.ReadFile(vManifest)
+		So(string(vData), ShouldEqual, "bb3d  "+vA+"\n34d2  "+vB+"\n")
+
+		writeFile(vTree, "b.bin", "hello!")
+		vCode, vOut, _ = runCmd("", "watch", "-manifest", vManifest, "-count", "1", vTree)
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, vA+": OK\n"+vB+": FAILED\n")
+
+		vW := &tWatcher{
+			dir:      vTree,
+			manifest: vManifest,
+			update:   true,
+			opts:     &tSumOptions{table: crc16.MakeTable(crc16.CRC16_ARC), in: cInRaw},
+			files:    map[string]tFileState{},
+			entries:  map[string]uint16{},
+			out:      new(bytes.Buffer),
+			err:      new(bytes.Buffer),
+		}
+		So(vW.poll(), ShouldBeNil)
+		So(os.Remove(vA), ShouldBeNil)
+		vW.out = new(bytes.Buffer)
+		So(vW.poll(), ShouldBeNil)
+		So(vW.out.(*bytes.Buffer).String(), ShouldEqual, vA+": REMOVED\n")
+		vData, _ = os.ReadFile(vManifest)
+		So(string(vData), ShouldEqual, fmt.Sprintf("%04x  %s\n", crc16.Checksum([]byte("hello!"), crc16.MakeTable(crc16.CRC16_ARC)), vB))
+
+		// Listed files missing at startup are reported once, and pruned when updating.
+		So(os.WriteFile(vManifest, []byte("bb3d  "+vA+"\n"+string(vData)), 0o644), ShouldBeNil)
+		vCode, vOut, _ = runCmd("", "watch", "-a", "arc", "-manifest", vManifest, "-count", "2", "-interval", "1ms", vTree)
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, vB+": OK\n"+vA+": REMOVED\n")
+		vCode, vOut, _ = runCmd("", "watch", "-a", "arc", "-manifest", vManifest, "-update", "-count", "1", vTree)
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, vB+": OK\n"+vA+": REMOVED\n")
+		vData, _ = os.ReadFile(vManifest)
+		So(string(vData), ShouldEqual, fmt.Sprintf("%04x  %s\n", crc16.Checksum([]byte("hello!"), crc16.MakeTable(crc16.CRC16_ARC)), vB))
+	})
+}
+
+//--------------------------------------
+
+func TestDiff(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vDir := aT.TempDir()
+		vA, vB := filepath.Join(vDir, "a"), filepath.Join(vDir, "b")
+		So(os.MkdirAll(filepath.Join(vA, "lib"), 0o755), ShouldBeNil)
+		So(os.MkdirAll(filepath.Join(vB, "lib"), 0o755), ShouldBeNil)
+		writeFile(vA, "boot.bin", "boot")
+		writeFile(vB, "boot.bin", "boot")
+		writeFile(vA, "lib/app.bin", "v1")
+		writeFile(vB, "lib/app.bin", "v2")
+		writeFile(vA, "old.bin", "old")
+		writeFile(vB, "new.bin", "new")
+
+		vCode, vOut, _ := runCmd("", "diff", vA, vB)
+		So(vCode, ShouldEqual, 1)
+		So(vOut, ShouldEqual, "changed  lib/app.bin\nadded    new.bin\nremoved  old.bin\n")
+
+		vCode, vOut, _ = runCmd("", "diff", vA, vA)
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldBeEmpty)
+
+		vManifest := writeFile(vDir, "a.crc16", fmt.Sprintf("%04x  boot.bin\n", crc16.Checksum([]byte("boot"), crc16.MakeTable(crc16.CRC16_ARC))))
+		vCode, vOut, _ = runCmd("", "diff", vManifest, vB)
+		So(vCode, ShouldEqual, 1)
+		So(vOut, ShouldEqual, "added    lib/app.bin\nadded    new.bin\n")
+
+		// Text files parsing as manifests are only taken for ones with -m.
+		vText := writeFile(vDir, "a.txt", fmt.Sprintf("%04x  boot.bin\n", crc16.Checksum([]byte("boot"), crc16.MakeTable(crc16.CRC16_ARC))))
+		vOther := writeFile(vDir, "b.txt", "text\n")
+		vCode, vOut, _ = runCmd("", "diff", vText, vOther)
+		So(vCode, ShouldEqual, 1)
+		So(vOut, ShouldStartWith, "changed  "+vText+" "+vOther+":")
+		vCode, _, _ = runCmd("", "diff", vText, vB)
+		So(vCode, ShouldEqual, 2)
+		vCode, vOut, _ = runCmd("", "diff", "-m", vText, vB)
+		So(vCode, ShouldEqual, 1)
+		So(vOut, ShouldEqual, "added    lib/app.bin\nadded    new.bin\n")
+		vCode, _, _ = runCmd("", "diff", "-m", vOther, vB)
+		So(vCode, ShouldEqual, 2)
+
+		vCode, vOut, _ = runCmd("", "diff", filepath.Join(vA, "boot.bin"), filepath.Join(vB, "lib", "app.bin"))
+		So(vCode, ShouldEqual, 1)
+		So(vOut, ShouldStartWith, "changed  ")
+		vCode, _, _ = runCmd("", "diff", filepath.Join(vA, "boot.bin"), filepath.Join(vB, "boot.bin"))
+		So(vCode, ShouldEqual, 0)
+
+		vCode, _, _ = runCmd("", "diff", filepath.Join(vA, "boot.bin"), vB)
+		So(vCode, ShouldEqual, 2)
+	})
+}
+
+//--------------------------------------
+
+func TestFilesFrom(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vDir := aT.TempDir()
+		vA := writeFile(vDir, "name with spaces.bin", "123456789")
+		vB := writeFile(vDir, "line\nbreak.bin", "")
+
+		vCode, vOut, _ := runCmd(vA+"\x00"+vB+"\x00", "-files-from", "-", "-0")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, "bb3d  "+vA+"\n0000  "+vB+"\n")
+
+		vList := writeFile(vDir, "list.txt", vA+"\r\n\n")
+		vCode, vOut, _ = runCmd("", "-files-from", vList)
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, "bb3d  "+vA+"\n")
+
+		vCode, vOut, _ = runCmd("", "-files-from", "-")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldBeEmpty)
+	})
+}
+
+//--------------------------------------
+
+func TestFirmware(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vDir := aT.TempDir()
+		vImage := make([]byte, 0x200)
+		for i := range vImage {
+			vImage[i] = byte(i * 3)
+		}
+		vFile := writeFile(vDir, "image.bin", string(vImage))
+		vArgs := []string{"-a", "ccitt-false", "-offset", "0x1FC", "-order", "le", "-skip", "0x1FC:2", "-skip", "0x1fe:2", vFile}
+
+		vCode, vOut, _ := runCmd("", append([]string{"fw", "verify"}, vArgs...)...)
+		So(vCode, ShouldEqual, 1)
+		So(vOut, ShouldStartWith, vFile+": FAILED")
+
+		vSum := crc16.Checksum(vImage[:0x1fc], crc16.MakeTable(crc16.CRC16_CCITT_FALSE))
+		vCode, vOut, _ = runCmd("", append([]string{"fw", "patch"}, vArgs...)...)
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, fmt.Sprintf("%s: patched %04x at 0x1fc\n", vFile, vSum))
+		vData, _ := os.ReadFile(vFile)
+		So(binary.LittleEndian.Uint16(vData[0x1fc:]), ShouldEqual, vSum)
+
+		vCode, vOut, _ = runCmd("", append([]string{"fw", "verify"}, vArgs...)...)
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, vFile+": OK\n")
+
+		vCode, _, _ = runCmd("", "fw", "verify", "-a", "ccitt-false", "-offset", "-4", "-order", "le", "-skip", "0x1fe:2", vFile)
+		So(vCode, ShouldEqual, 0)
+
+		vCode, _, _ = runCmd("", "fw", "check", vFile)
+		So(vCode, ShouldEqual, 2)
+		vCode, _, _ = runCmd("", "fw", "verify", "-offset", "0x1000", vFile)
+		So(vCode, ShouldEqual, 1)
+	})
+}
+
+//--------------------------------------
+
+func TestResidue(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		for _, a := range crc16.Algorithms() {
+			vTable := crc16.MakeTable(a)
+			vOrder, _ := parseOrder("auto", a)
+			vFrame := crc16.AppendChecksum([]byte("residue"), vTable, vOrder)
+			So(crc16.Checksum(vFrame, vTable), ShouldEqual, codewordResidue(vTable))
+		}
+
+		vFrame := crc16.AppendChecksum([]byte("123456789"), crc16.MakeTable(crc16.CRC16_X_25), binary.LittleEndian)
+		vCode, vOut, _ := runCmd(string(vFrame), "residue", "-a", "x-25")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, "-: OK\n")
+
+		vFrame[0] ^= 0x10
+		vCode, vOut, _ = runCmd(string(vFrame), "residue", "-a", "x-25")
+		So(vCode, ShouldEqual, 1)
+		So(vOut, ShouldEqual, "-: FAILED\n")
+	})
+}
+
+//--------------------------------------
+
+func TestIdentify(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vCode, vOut, _ := runCmd("", "identify", "-data", "313233343536373839", "-crc", "374b")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, "CRC-16/MODBUS  le\nCRC-16/USB  le  raw\n")
+
+		vCode, _, _ = runCmd("", "identify", "-data", "31", "-crc", "0000")
+		So(vCode, ShouldEqual, 1)
+		vCode, _, _ = runCmd("", "identify", "-data", "31", "-crc", "00")
+		So(vCode, ShouldEqual, 2)
+	})
+}
+
+//--------------------------------------
+
+func TestVectors(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vCode, vOut, _ := runCmd("", "vectors", "-a", "modbus,xmodem")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldStartWith, "[\n  {\n    \"name\": \"CRC-16/MODBUS\",\n")
+		So(vOut, ShouldContainSubstring, "\"data\": \"313233343536373839\",\n        \"crc\": 19255,")
+		So(strings.Count(vOut, "\"name\": \"CRC-16/"), ShouldEqual, 2)
+
+		vCode, vOut, _ = runCmd("", "vectors")
+		So(vCode, ShouldEqual, 0)
+		So(strings.Count(vOut, "\"name\": \"CRC-16/"), ShouldEqual, len(crc16.Algorithms()))
+
+		vCode, vOut, _ = runCmd("", "vectors", "-spec", "poly=0x1021 init=0xffff")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldContainSubstring, "\"poly\": 4129,")
+
+		vFile := filepath.Join(aT.TempDir(), "vectors.json")
+		vCode, vOut, _ = runCmd("", "vectors", "-a", "arc", "-o", vFile)
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldBeEmpty)
+		vData, err := os.ReadFile(vFile)
+		So(err, ShouldBeNil)
+		So(string(vData), ShouldContainSubstring, "\"name\": \"CRC-16/ARC\"")
+
+		vCode, _, _ = runCmd("", "vectors", "-a", "nope")
+		So(vCode, ShouldEqual, 2)
+	})
+}
+
+//--------------------------------------
+
+func TestArchive(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vDir := aT.TempDir()
+		vFiles := []struct{ name, body string }{{"boot.bin", "boot"}, {"lib/app.bin", "app"}}
+		vArc := crc16.MakeTable(crc16.CRC16_ARC)
+		vWant := fmt.Sprintf("%04x  boot.bin\n%04x  lib/app.bin\n",
+			crc16.Checksum([]byte("boot"), vArc), crc16.Checksum([]byte("app"), vArc))
+
+		var vTar, vZip bytes.Buffer
+		vZ := gzip.NewWriter(&vTar)
+		vT := tar.NewWriter(vZ)
+		vT.WriteHeader(&tar.Header{Name: "lib/", Typeflag: tar.TypeDir, Mode: 0o755})
+		vW := zip.NewWriter(&vZip)
+		for _, f := range vFiles {
+			vT.WriteHeader(&tar.Header{Name: f.name, Mode: 0o644, Size: int64(len(f.body))})
+			vT.Write([]byte(f.body))
+			vE, _ := vW.Create(f.name)
+			vE.Write([]byte(f.body))
+		}
+		vT.Close()
+		vZ.Close()
+		vW.Close()
+
+		for _, vName := range []string{writeFile(vDir, "release.tar.gz", vTar.String()), writeFile(vDir, "release.zip", vZip.String())} {
+			vCode, vOut, _ := runCmd("", "archive", vName)
+			So(vCode, ShouldEqual, 0)
+			So(vOut, ShouldEqual, vWant)
+
+			vCode, vOut, _ = runCmd(vWant, "archive", "-c", "-", vName)
+			So(vCode, ShouldEqual, 0)
+			So(vOut, ShouldEqual, "boot.bin: OK\nlib/app.bin: OK\n")
+
+			vCode, vOut, _ = runCmd("0000  boot.bin\n0000  gone.bin\n", "archive", "-c", "-", vName)
+			So(vCode, ShouldEqual, 1)
+			So(vOut, ShouldEqual, "boot.bin: FAILED\ngone.bin: FAILED missing\n")
+		}
+
+		vCode, _, _ := runCmd("", "archive", writeFile(vDir, "plain.txt", "not an archive"))
+		So(vCode, ShouldEqual, 1)
+		vCode, _, _ = runCmd("", "archive")
+		So(vCode, ShouldEqual, 2)
+	})
+}
+
+//--------------------------------------
+
+func TestTag(aT *testing.T) {
+	vDir := aT.TempDir()
+	vName := writeFile(vDir, "data.bin", "precious")
+	if err := setXattr(vName, "user.probe", []byte{1}); err != nil {
+		aT.Skip("extended attributes not supported:", err)
+	}
+	Convey(funcName(), aT, func() {
+		vCode, vOut, _ := runCmd("", "tag", vName)
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, vName+": NEW\n")
+		vCode, vOut, _ = runCmd("", "tag", "-r", vDir)
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, vName+": OK\n")
+
+		// Silent corruption keeps the modification time.
+		vInfo, _ := os.Stat(vName)
+		writeFile(vDir, "data.bin", "prekious")
+		So(os.Chtimes(vName, vInfo.ModTime(), vInfo.ModTime()), ShouldBeNil)
+		vCode, vOut, _ = runCmd("", "tag", vName)
+		So(vCode, ShouldEqual, 1)
+		So(vOut, ShouldEqual, vName+": CORRUPT\n")
+
+		vLater := vInfo.ModTime().Add(time.Second)
+		So(os.Chtimes(vName, vLater, vLater), ShouldBeNil)
+		vCode, vOut, _ = runCmd("", "tag", "-n", vName)
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, vName+": UPDATED\n")
+		vCode, vOut, _ = runCmd("", "tag", vName)
+		So(vOut, ShouldEqual, vName+": UPDATED\n")
+		vCode, vOut, _ = runCmd("", "tag", "-a", "modbus", vName)
+		So(vOut, ShouldEqual, vName+": NEW\n")
+
+		vCode, _, _ = runCmd("", "tag")
+		So(vCode, ShouldEqual, 2)
+	})
+}
+
+//--------------------------------------
+
+func TestText(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		_, vUnix, _ := runCmd("one\ntwo\n")
+		vCode, vOut, _ := runCmd("one\r\ntwo\r\n", "-text")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, vUnix)
+		_, vOut, _ = runCmd("one\r\ntwo\r\n")
+		So(vOut, ShouldNotEqual, vUnix)
+
+		vCode, vOut, _ = runCmd("", "-text", "-in", "hex", "-d", "6f6e650d0a74776f0d0a")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, strings.Replace(vUnix, "  -", "", 1))
+
+		vDir := aT.TempDir()
+		vFile := writeFile(vDir, "notes.txt", "one\r\ntwo\r\n")
+		vManifest := writeFile(vDir, "sums", strings.Replace(vUnix, "-", vFile, 1))
+		vCode, _, _ = runCmd("", "-text", "-c", vManifest)
+		So(vCode, ShouldEqual, 0)
+		vCode, _, _ = runCmd("", "-c", vManifest)
+		So(vCode, ShouldEqual, 1)
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,89 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+//-----------------------------------------------------------------------------
+
+// Output formats accepted by the -format flag.
+const (
+	cFormatText  = "text"
+	cFormatJSON  = "json"
+	cFormatBSD   = "bsd"
+	cFormatRawLE = "raw-le"
+	cFormatRawBE = "raw-be"
+	cFormatDec   = "dec"
+)
+
+// cBSDTag tags lines of the bsd format when the algorithm has no name fit for the tag.
+const cBSDTag = "CRC16"
+
+// tJSONSum is a checksum as written in the json format.
+type tJSONSum struct {
+	Name  string `json:"name,omitempty"`
+	Algo  string `json:"algo,omitempty"`
+	Crc   string `json:"crc"`
+	Value uint16 `json:"value"`
+}
+
+//-----------------------------------------------------------------------------
+
+// Returns an error if aFormat is not a known output format.
+func checkFormat(aFormat string) error {
+	switch aFormat {
+	case cFormatText, cFormatJSON, cFormatBSD, cFormatRawLE, cFormatRawBE, cFormatDec:
+		return nil
+	}
+	return fmt.Errorf("unknown output format %q", aFormat)
+}
+
+//--------------------------------------
+
+// Writes the checksum of the named input in the selected format. An empty name stands
+// for data given on the command line and is omitted from the text and dec formats.
+//
+// The raw formats write just the two checksum bytes, without any separator.
+func writeSum(w io.Writer, aOpts *tSumOptions, aName string, aSum uint16) error {
+	var err error
+	switch aOpts.format {
+	case cFormatJSON:
+		err = json.NewEncoder(w).Encode(tJSONSum{Name: aName, Algo: aOpts.table.Algo().Name, Crc: fmt.Sprintf("%04x", aSum), Value: aSum})
+	case cFormatBSD:
+		vTag := aOpts.table.Algo().Name
+		if vTag == "" || strings.Contains(vTag, " ") {
+			vTag = cBSDTag
+		}
+		if aName == "" {
+			aName = "-"
+		}
+		_, err = fmt.Fprintf(w, "%s (%s) = %04x\n", vTag, aName, aSum)
+	case cFormatRawLE:
+		_, err = w.Write(binary.LittleEndian.AppendUint16(nil, aSum))
+	case cFormatRawBE:
+		_, err = w.Write(binary.BigEndian.AppendUint16(nil, aSum))
+	case cFormatDec:
+		if aName == "" {
+			_, err = fmt.Fprintf(w, "%d\n", aSum)
+		} else {
+			_, err = fmt.Fprintf(w, "%d  %s\n", aSum, aName)
+		}
+	default:
+		if aName == "" {
+			_, err = fmt.Fprintf(w, "%04x\n", aSum)
+		} else {
+			_, err = fmt.Fprintf(w, "%04x  %s\n", aSum, aName)
+		}
+	}
+	return err
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,69 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/mbsulliv/crc16"
+)
+
+//-----------------------------------------------------------------------------
+
+// Returns the checksum of every message followed by its own checksum trailer.
+//
+// With the trailer in the byte order the register is shifted in, little-endian for
+// algorithms with reflected output and big-endian otherwise, the checksum of such a
+// codeword does not depend on the message, so codewords can be validated as a whole.
+func codewordResidue(aTable *crc16.TTable) uint16 {
+	vOrder, _ := parseOrder("auto", aTable.Algo())
+	return crc16.Checksum(crc16.AppendChecksum(nil, aTable, vOrder), aTable)
+}
+
+//--------------------------------------
+
+// Runs the residue subcommand validating streams which end in their own checksum.
+func runResidue(aArgs []string, aIn io.Reader, aOut, aErr io.Writer) int {
+	vFlags := flag.NewFlagSet("crc16 residue", flag.ContinueOnError)
+	vFlags.SetOutput(aErr)
+	var vAlgo string
+	vFlags.StringVar(&vAlgo, "a", cDefaultAlgo, "checksum algorithm (shorthand)")
+	vFlags.StringVar(&vAlgo, "algo", cDefaultAlgo, "checksum algorithm")
+	vSpec := vFlags.String("spec", "", "custom algorithm parameters")
+	if err := vFlags.Parse(aArgs); err != nil {
+		return 2
+	}
+	vTable, err := selectTable(vAlgo, *vSpec)
+	if err != nil {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 2
+	}
+
+	vOpts := &tSumOptions{table: vTable, in: cInRaw}
+	vResidue := codewordResidue(vTable)
+	vFiles := vFlags.Args()
+	if len(vFiles) == 0 {
+		vFiles = []string{"-"}
+	}
+	vRet := 0
+	for _, vName := range vFiles {
+		vSum, err := sumFile(vName, aIn, vOpts)
+		switch {
+		case err != nil:
+			fmt.Fprintln(aErr, "crc16:", err)
+			vRet = 1
+		case vSum == vResidue:
+			fmt.Fprintf(aOut, "%s: OK\n", vName)
+		default:
+			fmt.Fprintf(aOut, "%s: FAILED\n", vName)
+			vRet = 1
+		}
+	}
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
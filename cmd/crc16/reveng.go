@@ -0,0 +1,81 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/mbsulliv/crc16"
+)
+
+//-----------------------------------------------------------------------------
+
+// tSamples collects the repeated -sample flags.
+type tSamples []crc16.TSample
+
+//-----------------------------------------------------------------------------
+
+// String returns the samples in the flag notation.
+func (aS *tSamples) String() string {
+	vParts := make([]string, len(*aS))
+	for i, s := range *aS {
+		vParts[i] = fmt.Sprintf("%x:%04x", s.Data, s.Crc)
+	}
+	return strings.Join(vParts, " ")
+}
+
+//--------------------------------------
+
+// Set parses a sample given as the hexadecimal message and checksum separated by a colon.
+func (aS *tSamples) Set(aVal string) error {
+	vData, vCrc, vOk := strings.Cut(aVal, ":")
+	if !vOk {
+		return fmt.Errorf("sample %q is not in the data:crc form", aVal)
+	}
+	vBytes, err := hex.DecodeString(vData)
+	if err != nil {
+		return fmt.Errorf("sample %q: %v", aVal, err)
+	}
+	vSum, err := strconv.ParseUint(strings.TrimPrefix(strings.ToLower(vCrc), "0x"), 16, 16)
+	if err != nil {
+		return fmt.Errorf("sample %q: invalid checksum", aVal)
+	}
+	*aS = append(*aS, crc16.TSample{Data: vBytes, Crc: uint16(vSum)})
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+
+// Runs the reveng subcommand recovering algorithm parameters from samples.
+func runReveng(aArgs []string, aIn io.Reader, aOut, aErr io.Writer) int {
+	vFlags := flag.NewFlagSet("crc16 reveng", flag.ContinueOnError)
+	vFlags.SetOutput(aErr)
+	var vSamples tSamples
+	vFlags.Var(&vSamples, "sample", "message and its checksum in hexadecimal as data:crc (repeatable)")
+	if err := vFlags.Parse(aArgs); err != nil {
+		return 2
+	}
+
+	vAlgos, err := crc16.RecoverAlgos(vSamples)
+	if err != nil {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 2
+	}
+	if len(vAlgos) == 0 {
+		fmt.Fprintln(aErr, "crc16: no algorithm matches the samples")
+		return 1
+	}
+	for _, a := range vAlgos {
+		fmt.Fprintln(aOut, a.String())
+	}
+	return 0
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,38 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || (crc16_ccitt && crc16_ibm && crc16_misc))
+
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mbsulliv/crc16"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestReveng(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := crc16.MakeTable(crc16.CRC16_CDMA2000)
+		var vArgs []string
+		for _, m := range []string{"123456789", "hello, world", "x", "0123456789abcdef", "the quick brown fox"} {
+			vArgs = append(vArgs, "-sample", fmt.Sprintf("%x:%04x", m, crc16.Checksum([]byte(m), vTable)))
+		}
+		vCode, vOut, _ := runCmd("", append([]string{"reveng"}, vArgs...)...)
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, "width=16 poly=0xc867 init=0xffff refin=false refout=false xorout=0x0000 check=0x4c06 residue=0x0000 name=\"CRC-16/CDMA2000\"\n")
+
+		vCode, _, vErr := runCmd("", "reveng", "-sample", "313233")
+		So(vCode, ShouldEqual, 2)
+		So(vErr, ShouldContainSubstring, "data:crc")
+
+		vCode, _, _ = runCmd("", "reveng", "-sample", "3132:0000")
+		So(vCode, ShouldEqual, 2)
+	})
+}
+
+//-----------------------------------------------------------------------------
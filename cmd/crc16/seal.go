@@ -0,0 +1,194 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mbsulliv/crc16"
+)
+
+//-----------------------------------------------------------------------------
+
+// tTrailerOptions are the settings of the seal, open and verify subcommands.
+type tTrailerOptions struct {
+	table *crc16.TTable
+	order binary.ByteOrder
+	out   string
+}
+
+//-----------------------------------------------------------------------------
+
+// Returns the byte order named by aName. The auto order is the conventional trailer order
+// of the algorithm: unless recorded otherwise, little-endian for algorithms with reflected
+// output, as used by e.g. Modbus, and big-endian otherwise.
+func parseOrder(aName string, aAlgo crc16.TAlgo) (binary.ByteOrder, error) {
+	switch strings.ToLower(aName) {
+	case "auto":
+		if aAlgo.LittleEndian() {
+			return binary.LittleEndian, nil
+		}
+		return binary.BigEndian, nil
+	case "le", "little":
+		return binary.LittleEndian, nil
+	case "be", "big":
+		return binary.BigEndian, nil
+	}
+	return nil, fmt.Errorf("unknown byte order %q", aName)
+}
+
+//--------------------------------------
+
+// Parses the flags of a trailer subcommand and returns its options and file arguments,
+// or the exit code if parsing fails.
+func parseTrailerFlags(aCmd string, aArgs []string, aErr io.Writer) (tTrailerOptions, []string, int) {
+	vFlags := flag.NewFlagSet("crc16 "+aCmd, flag.ContinueOnError)
+	vFlags.SetOutput(aErr)
+	var vAlgo string
+	vFlags.StringVar(&vAlgo, "a", cDefaultAlgo, "checksum algorithm (shorthand)")
+	vFlags.StringVar(&vAlgo, "algo", cDefaultAlgo, "checksum algorithm")
+	vSpec := vFlags.String("spec", "", "custom algorithm parameters")
+	vOrder := vFlags.String("order", "auto", "trailer byte order: auto, le or be")
+	var vOpts tTrailerOptions
+	if aCmd != "verify" {
+		vFlags.StringVar(&vOpts.out, "o", "", "write the result to the file, or - for standard output, instead of in place")
+	}
+	if err := vFlags.Parse(aArgs); err != nil {
+		return vOpts, nil, 2
+	}
+
+	var err error
+	vOpts.table, err = selectTable(vAlgo, *vSpec)
+	if err == nil {
+		vOpts.order, err = parseOrder(*vOrder, vOpts.table.Algo())
+	}
+	if err == nil && vFlags.NArg() == 0 {
+		err = fmt.Errorf("%s: no file specified", aCmd)
+	}
+	if err == nil && vOpts.out != "" && vFlags.NArg() > 1 {
+		err = fmt.Errorf("%s: -o requires a single file", aCmd)
+	}
+	if err != nil {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return vOpts, nil, 2
+	}
+	return vOpts, vFlags.Args(), 0
+}
+
+//--------------------------------------
+
+// Returns the content of the named file, or of aIn for "-".
+func readInput(aName string, aIn io.Reader) ([]byte, error) {
+	if aName == "-" {
+		return io.ReadAll(aIn)
+	}
+	return os.ReadFile(aName)
+}
+
+//--------------------------------------
+
+// Writes data to the named output, or to aOut for "-".
+func writeOutput(aName string, aOut io.Writer, data []byte) error {
+	if aName == "-" {
+		_, err := aOut.Write(data)
+		return err
+	}
+	return os.WriteFile(aName, data, 0o644)
+}
+
+//-----------------------------------------------------------------------------
+
+// Runs the seal subcommand appending a checksum trailer to files.
+func runSeal(aArgs []string, aIn io.Reader, aOut, aErr io.Writer) int {
+	vOpts, vFiles, vCode := parseTrailerFlags("seal", aArgs, aErr)
+	if vCode != 0 {
+		return vCode
+	}
+	vRet := 0
+	for _, vName := range vFiles {
+		vData, err := readInput(vName, aIn)
+		if err == nil {
+			vOut := vOpts.out
+			if vOut == "" {
+				vOut = vName
+			}
+			err = writeOutput(vOut, aOut, crc16.AppendChecksum(vData, vOpts.table, vOpts.order))
+		}
+		if err != nil {
+			fmt.Fprintln(aErr, "crc16:", err)
+			vRet = 1
+		}
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Runs the open subcommand verifying the checksum trailer of files and stripping it.
+// Files failing verification are left untouched.
+func runOpen(aArgs []string, aIn io.Reader, aOut, aErr io.Writer) int {
+	vOpts, vFiles, vCode := parseTrailerFlags("open", aArgs, aErr)
+	if vCode != 0 {
+		return vCode
+	}
+	vRet := 0
+	for _, vName := range vFiles {
+		vData, err := readInput(vName, aIn)
+		if err == nil {
+			var vMismatch *crc16.ChecksumError
+			err = crc16.CheckTrailer(vData, vOpts.table, vOpts.order)
+			if errors.As(err, &vMismatch) {
+				err = fmt.Errorf("%s: checksum trailer mismatch: stored %04x, computed %04x", vName, vMismatch.Expected, vMismatch.Actual)
+			} else if err != nil {
+				err = fmt.Errorf("%s: file shorter than its checksum trailer", vName)
+			}
+		}
+		if err == nil {
+			vOut := vOpts.out
+			if vOut == "" {
+				vOut = vName
+			}
+			err = writeOutput(vOut, aOut, vData[:len(vData)-2])
+		}
+		if err != nil {
+			fmt.Fprintln(aErr, "crc16:", err)
+			vRet = 1
+		}
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Runs the verify subcommand checking the checksum trailer of files.
+func runVerify(aArgs []string, aIn io.Reader, aOut, aErr io.Writer) int {
+	vOpts, vFiles, vCode := parseTrailerFlags("verify", aArgs, aErr)
+	if vCode != 0 {
+		return vCode
+	}
+	vRet := 0
+	for _, vName := range vFiles {
+		vData, err := readInput(vName, aIn)
+		switch {
+		case err != nil:
+			fmt.Fprintln(aErr, "crc16:", err)
+			vRet = 1
+		case crc16.VerifyTrailer(vData, vOpts.table, vOpts.order):
+			fmt.Fprintf(aOut, "%s: OK\n", vName)
+		default:
+			fmt.Fprintf(aOut, "%s: FAILED\n", vName)
+			vRet = 1
+		}
+	}
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,84 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+
+	"github.com/mbsulliv/crc16"
+)
+
+//-----------------------------------------------------------------------------
+
+// Returns the discrepancies found validating an algorithm: the table-driven and bitwise
+// checksums of the check message against its Check value, both engines against each other
+// on pseudo-random messages, and streaming through the hash against one-shot checksums.
+func selfTest(aAlgo crc16.TAlgo) []string {
+	var vRet []string
+	vTable := crc16.MakeTable(aAlgo)
+	vCheck := []byte("123456789")
+	if v := crc16.Checksum(vCheck, vTable); v != aAlgo.Check {
+		vRet = append(vRet, fmt.Sprintf("table check 0x%04x, expected 0x%04x", v, aAlgo.Check))
+	}
+	if v := crc16.ChecksumBitwise(vCheck, aAlgo); v != aAlgo.Check {
+		vRet = append(vRet, fmt.Sprintf("bitwise check 0x%04x, expected 0x%04x", v, aAlgo.Check))
+	}
+
+	vRand := rand.New(rand.NewSource(1))
+	for n := 0; n <= 64; n++ {
+		vData := make([]byte, n)
+		vRand.Read(vData)
+		vTab, vBit := crc16.Checksum(vData, vTable), crc16.ChecksumBitwise(vData, aAlgo)
+		if vTab != vBit {
+			vRet = append(vRet, fmt.Sprintf("table 0x%04x and bitwise 0x%04x differ for %d bytes", vTab, vBit, n))
+			break
+		}
+		vH := crc16.New(vTable)
+		vH.Write(vData[:n/2])
+		vH.Write(vData[n/2:])
+		if v := vH.Sum16(); v != vTab {
+			vRet = append(vRet, fmt.Sprintf("hash 0x%04x and table 0x%04x differ for %d bytes", v, vTab, n))
+			break
+		}
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Runs the selftest subcommand validating every predefined algorithm.
+func runSelfTest(aArgs []string, aIn io.Reader, aOut, aErr io.Writer) int {
+	vFlags := flag.NewFlagSet("crc16 selftest", flag.ContinueOnError)
+	vFlags.SetOutput(aErr)
+	vQuiet := vFlags.Bool("q", false, "print only failures")
+	if err := vFlags.Parse(aArgs); err != nil {
+		return 2
+	}
+
+	vFailed := 0
+	for _, a := range crc16.Algorithms() {
+		vErrs := selfTest(a)
+		if len(vErrs) == 0 {
+			if !*vQuiet {
+				fmt.Fprintf(aOut, "%s: OK\n", a.Name)
+			}
+			continue
+		}
+		vFailed++
+		for _, e := range vErrs {
+			fmt.Fprintf(aOut, "%s: FAILED: %s\n", a.Name, e)
+		}
+	}
+	if vFailed > 0 {
+		fmt.Fprintf(aErr, "crc16: WARNING: %d of %d algorithms failed the self-test\n", vFailed, len(crc16.Algorithms()))
+		return 1
+	}
+	return 0
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,27 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestSpec(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vCode, vOut, _ := runCmd("123456789", "-spec", "poly=0x1021 init=0xffff refin=true refout=true xorout=0xffff")
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldEqual, "906e  -\n")
+
+		vCode, _, vErr := runCmd("", "-spec", "poly=0x1021 init=oops")
+		So(vCode, ShouldEqual, 2)
+		So(vErr, ShouldContainSubstring, "invalid init value")
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,85 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+//-----------------------------------------------------------------------------
+
+// Returns an identifier derived from the algorithm name, e.g. crc16ModbusTable
+// for CRC-16/MODBUS, or crc16_modbus_table when aSnake is set.
+func tableIdent(aName string, aSnake bool) string {
+	vWords := strings.FieldsFunc(strings.ToLower(aName), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	vWords = append(vWords, "table")
+	if aSnake {
+		return strings.Join(vWords, "_")
+	}
+	for i := 1; i < len(vWords); i++ {
+		vWords[i] = strings.ToUpper(vWords[i][:1]) + vWords[i][1:]
+	}
+	return strings.Join(vWords, "")
+}
+
+//--------------------------------------
+
+// Runs the table subcommand printing the lookup table of an algorithm.
+func runTable(aArgs []string, aIn io.Reader, aOut, aErr io.Writer) int {
+	vFlags := flag.NewFlagSet("crc16 table", flag.ContinueOnError)
+	vFlags.SetOutput(aErr)
+	var vAlgo string
+	vFlags.StringVar(&vAlgo, "a", cDefaultAlgo, "checksum algorithm (shorthand)")
+	vFlags.StringVar(&vAlgo, "algo", cDefaultAlgo, "checksum algorithm")
+	vSpec := vFlags.String("spec", "", "custom algorithm parameters")
+	vFormat := vFlags.String("format", "go", "output format: go, c or csv")
+	vName := vFlags.String("name", "", "name of the generated variable (derived from the algorithm by default)")
+	vPkg := vFlags.String("pkg", "main", "package of the generated Go source")
+	if err := vFlags.Parse(aArgs); err != nil {
+		return 2
+	}
+	vTable, err := selectTable(vAlgo, *vSpec)
+	if err != nil {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 2
+	}
+
+	switch *vFormat {
+	case "go":
+		if *vName == "" {
+			*vName = tableIdent(vTable.Algo().Name, false)
+		}
+		err = vTable.WriteGoSource(aOut, *vPkg, *vName)
+	case "c":
+		if *vName == "" {
+			*vName = tableIdent(vTable.Algo().Name, true)
+		}
+		err = vTable.WriteCSource(aOut, *vName)
+	case "csv":
+		vW := bufio.NewWriter(aOut)
+		fmt.Fprintln(vW, "index,value")
+		for i, v := range vTable.Entries() {
+			fmt.Fprintf(vW, "%d,0x%04x\n", i, v)
+		}
+		err = vW.Flush()
+	default:
+		fmt.Fprintf(aErr, "crc16: unknown table format %q\n", *vFormat)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 1
+	}
+	return 0
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,111 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+//-----------------------------------------------------------------------------
+
+// The extended attribute holding the tag of a file: the algorithm name,
+// the checksum in hexadecimal and the modification time in nanoseconds, separated by spaces.
+const cTagAttr = "user.crc16"
+
+//-----------------------------------------------------------------------------
+
+// Returns the status of the named file against its tag and the tag to store, or an empty one
+// if the stored tag is current: OK, NEW, UPDATED if the file was modified since it was tagged,
+// or CORRUPT if its content changed while its modification time did not.
+func tagFile(aName string, aOpts *tSumOptions) (string, string, error) {
+	vBefore, err := os.Stat(aName)
+	if err != nil {
+		return "", "", err
+	}
+	vSum, err := sumFile(aName, nil, aOpts)
+	if err != nil {
+		return "", "", err
+	}
+	vAfter, err := os.Stat(aName)
+	if err != nil {
+		return "", "", err
+	}
+	if !vAfter.ModTime().Equal(vBefore.ModTime()) {
+		return "", "", fmt.Errorf("%s: modified while checksumming", aName)
+	}
+	vStored, err := getXattr(aName, cTagAttr)
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %v", aName, err)
+	}
+
+	vTime := strconv.FormatInt(vAfter.ModTime().UnixNano(), 10)
+	vTag := fmt.Sprintf("%s %04x %s", aOpts.table.Algo().Name, vSum, vTime)
+	vFields := strings.Fields(string(vStored))
+	switch {
+	case len(vFields) != 3 || vFields[0] != aOpts.table.Algo().Name:
+		return "NEW", vTag, nil
+	case vFields[2] != vTime:
+		return "UPDATED", vTag, nil
+	case vFields[1] != fmt.Sprintf("%04x", vSum):
+		return "CORRUPT", "", nil
+	}
+	return "OK", "", nil
+}
+
+//--------------------------------------
+
+// Runs the tag subcommand storing the checksums of files in extended attributes and verifying them.
+func runTag(aArgs []string, aIn io.Reader, aOut, aErr io.Writer) int {
+	vFlags := flag.NewFlagSet("crc16 tag", flag.ContinueOnError)
+	vFlags.SetOutput(aErr)
+	var vAlgo string
+	vFlags.StringVar(&vAlgo, "a", cDefaultAlgo, "checksum algorithm (shorthand)")
+	vFlags.StringVar(&vAlgo, "algo", cDefaultAlgo, "checksum algorithm")
+	vSpec := vFlags.String("spec", "", "custom algorithm parameters")
+	vRecursive := vFlags.Bool("r", false, "tag the files in directories recursively")
+	vDryRun := vFlags.Bool("n", false, "only verify, leaving the tags unchanged")
+	if err := vFlags.Parse(aArgs); err != nil {
+		return 2
+	}
+	vTable, err := selectTable(vAlgo, *vSpec)
+	if err == nil && vFlags.NArg() == 0 {
+		err = fmt.Errorf("no file specified")
+	}
+	if err != nil {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 2
+	}
+
+	vFiles, err := expandFiles(vFlags.Args(), *vRecursive, nil, nil)
+	if err != nil {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 1
+	}
+	vOpts := &tSumOptions{table: vTable, in: cInRaw}
+	vRet := 0
+	for _, vName := range vFiles {
+		vStatus, vTag, err := tagFile(vName, vOpts)
+		if err == nil && vTag != "" && !*vDryRun {
+			err = setXattr(vName, cTagAttr, []byte(vTag))
+		}
+		if err != nil {
+			fmt.Fprintln(aErr, "crc16:", err)
+			vRet = 1
+			continue
+		}
+		fmt.Fprintf(aOut, "%s: %s\n", vName, vStatus)
+		if vStatus == "CORRUPT" {
+			vRet = 1
+		}
+	}
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
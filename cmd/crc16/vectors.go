@@ -0,0 +1,61 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/mbsulliv/crc16"
+)
+
+//-----------------------------------------------------------------------------
+
+// Runs the vectors subcommand writing the golden test vectors of the algorithms.
+func runVectors(aArgs []string, aIn io.Reader, aOut, aErr io.Writer) int {
+	vFlags := flag.NewFlagSet("crc16 vectors", flag.ContinueOnError)
+	vFlags.SetOutput(aErr)
+	var vAlgos string
+	vFlags.StringVar(&vAlgos, "a", "all", "comma-separated algorithms, or all (shorthand)")
+	vFlags.StringVar(&vAlgos, "algo", "all", "comma-separated algorithms, or all")
+	vSpec := vFlags.String("spec", "", "custom algorithm parameters, e.g. \"poly=0x1021 init=0xffff\"")
+	vOut := vFlags.String("o", "-", "write the vectors to the file instead of standard output")
+	if err := vFlags.Parse(aArgs); err != nil {
+		return 2
+	}
+
+	var vTables []*crc16.TTable
+	var err error
+	if *vSpec != "" {
+		var vTable *crc16.TTable
+		vTable, err = selectTable("", *vSpec)
+		vTables = []*crc16.TTable{vTable}
+	} else {
+		vTables, err = lookupTables(vAlgos)
+	}
+	if err != nil {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 2
+	}
+
+	var vAlgoList []crc16.TAlgo
+	for _, t := range vTables {
+		vAlgoList = append(vAlgoList, t.Algo())
+	}
+	var vB bytes.Buffer
+	if err := crc16.WriteVectors(&vB, vAlgoList); err != nil {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 1
+	}
+	if err := writeOutput(*vOut, aOut, vB.Bytes()); err != nil {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 1
+	}
+	return 0
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,140 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+//-----------------------------------------------------------------------------
+
+// tPatterns collects repeated glob pattern flags.
+type tPatterns []string
+
+// tSumResult is the outcome of checksumming a file.
+type tSumResult struct {
+	sum uint16
+	err error
+}
+
+//-----------------------------------------------------------------------------
+
+// String returns the patterns separated by commas.
+func (aP *tPatterns) String() string {
+	return strings.Join(*aP, ",")
+}
+
+//--------------------------------------
+
+// Set adds a pattern after checking its syntax.
+func (aP *tPatterns) Set(aVal string) error {
+	if _, err := path.Match(aVal, ""); err != nil {
+		return err
+	}
+	*aP = append(*aP, aVal)
+	return nil
+}
+
+//--------------------------------------
+
+// Returns true if any of the patterns matches the slash-separated path or its base name.
+func (aP tPatterns) match(aPath string) bool {
+	for _, p := range aP {
+		if vOk, _ := path.Match(p, aPath); vOk {
+			return true
+		}
+		if vOk, _ := path.Match(p, path.Base(aPath)); vOk {
+			return true
+		}
+	}
+	return false
+}
+
+//-----------------------------------------------------------------------------
+
+// Returns the files to checksum for the command line arguments. With aRecursive, directories
+// are walked in lexical order; files below them are kept if they match an include pattern,
+// or if there are none, and do not match an exclude pattern. Excluded directories are skipped.
+// Files named explicitly are always kept.
+func expandFiles(aArgs []string, aRecursive bool, aInclude, aExclude tPatterns) ([]string, error) {
+	var vRet []string
+	for _, vArg := range aArgs {
+		vInfo, err := os.Stat(vArg)
+		if vArg == "-" || !aRecursive || err != nil || !vInfo.IsDir() {
+			vRet = append(vRet, vArg)
+			continue
+		}
+		err = filepath.WalkDir(vArg, func(aPath string, aEntry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			vRel, _ := filepath.Rel(vArg, aPath)
+			vRel = filepath.ToSlash(vRel)
+			if aEntry.IsDir() {
+				if aPath != vArg && aExclude.match(vRel) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !aEntry.Type().IsRegular() || aExclude.match(vRel) {
+				return nil
+			}
+			if len(aInclude) == 0 || aInclude.match(vRel) {
+				vRet = append(vRet, aPath)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return vRet, nil
+}
+
+//--------------------------------------
+
+// Checksums the named files with aJobs workers and returns a channel per file
+// delivering its result, so results can be reported in order as they complete.
+// The channels are closed once all the workers are done.
+func sumFiles(aNames []string, aIn io.Reader, aOpts *tSumOptions, aJobs int) []chan tSumResult {
+	vRet := make([]chan tSumResult, len(aNames))
+	for i := range vRet {
+		vRet[i] = make(chan tSumResult, 1)
+	}
+
+	vNext := make(chan int)
+	go func() {
+		for i := range aNames {
+			vNext <- i
+		}
+		close(vNext)
+	}()
+	var vWG sync.WaitGroup
+	for range max(aJobs, 1) {
+		vWG.Add(1)
+		go func() {
+			defer vWG.Done()
+			for i := range vNext {
+				vSum, err := sumFile(aNames[i], aIn, aOpts)
+				vRet[i] <- tSumResult{vSum, err}
+			}
+		}()
+	}
+	go func() {
+		vWG.Wait()
+		for _, c := range vRet {
+			close(c)
+		}
+	}()
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
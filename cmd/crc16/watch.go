@@ -0,0 +1,211 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+//-----------------------------------------------------------------------------
+
+// tFileState is what the watch subcommand remembers of a file between polls.
+type tFileState struct {
+	modTime time.Time
+	size    int64
+	sum     uint16
+}
+
+// tWatcher polls a directory tree and keeps a manifest of it in sync.
+type tWatcher struct {
+	dir      string
+	manifest string
+	update   bool
+	opts     *tSumOptions
+	files    map[string]tFileState
+	entries  map[string]uint16
+	polled   bool
+	out, err io.Writer
+}
+
+//-----------------------------------------------------------------------------
+
+// Writes the entries to the named manifest in the md5sum format, sorted by name.
+func writeManifest(aName string, aEntries map[string]uint16) error {
+	vFile, err := os.Create(aName)
+	if err != nil {
+		return err
+	}
+	vW := bufio.NewWriter(vFile)
+	vNames := make([]string, 0, len(aEntries))
+	for n := range aEntries {
+		vNames = append(vNames, n)
+	}
+	slices.Sort(vNames)
+	for _, n := range vNames {
+		fmt.Fprintf(vW, "%04x  %s\n", aEntries[n], n)
+	}
+	if err := vW.Flush(); err != nil {
+		vFile.Close()
+		return err
+	}
+	return vFile.Close()
+}
+
+//--------------------------------------
+
+// Checksums the files added or modified since the previous poll and reports them against
+// the manifest, updating it if enabled. Files are considered modified when their size or
+// modification time changes. Files removed, or listed but missing at the first poll,
+// are reported as removed.
+func (aW *tWatcher) poll() error {
+	vNames, err := expandFiles([]string{aW.dir}, true, nil, nil)
+	if err != nil {
+		return err
+	}
+	vManifest, _ := filepath.Abs(aW.manifest)
+	vSeen := make(map[string]bool, len(vNames))
+	vChanged := false
+	for _, vName := range vNames {
+		if vAbs, _ := filepath.Abs(vName); vAbs == vManifest {
+			continue
+		}
+		vSeen[vName] = true
+		vInfo, err := os.Stat(vName)
+		if err != nil {
+			continue
+		}
+		vOld, vKnown := aW.files[vName]
+		if vKnown && vOld.size == vInfo.Size() && vOld.modTime.Equal(vInfo.ModTime()) {
+			continue
+		}
+		vSum, err := sumFile(vName, nil, aW.opts)
+		if err != nil {
+			fmt.Fprintln(aW.err, "crc16:", err)
+			continue
+		}
+		aW.files[vName] = tFileState{vInfo.ModTime(), vInfo.Size(), vSum}
+
+		vWant, vListed := aW.entries[vName]
+		switch {
+		case vListed && vWant == vSum:
+			fmt.Fprintf(aW.out, "%s: OK\n", vName)
+		case aW.update:
+			aW.entries[vName] = vSum
+			vChanged = true
+			if vListed {
+				fmt.Fprintf(aW.out, "%s: UPDATED\n", vName)
+			} else {
+				fmt.Fprintf(aW.out, "%s: ADDED\n", vName)
+			}
+		case vListed:
+			fmt.Fprintf(aW.out, "%s: FAILED\n", vName)
+		default:
+			fmt.Fprintf(aW.out, "%s: NEW\n", vName)
+		}
+	}
+
+	var vRemoved []string
+	for n := range aW.files {
+		if !vSeen[n] {
+			vRemoved = append(vRemoved, n)
+		}
+	}
+	if !aW.polled {
+		// Files listed but missing from the start are removed too.
+		for n := range aW.entries {
+			if _, vKnown := aW.files[n]; !vKnown && !vSeen[n] {
+				vRemoved = append(vRemoved, n)
+			}
+		}
+		aW.polled = true
+	}
+	slices.Sort(vRemoved)
+	for _, vName := range vRemoved {
+		delete(aW.files, vName)
+		if _, vListed := aW.entries[vName]; vListed && aW.update {
+			delete(aW.entries, vName)
+			vChanged = true
+		}
+		fmt.Fprintf(aW.out, "%s: REMOVED\n", vName)
+	}
+
+	if vChanged {
+		return writeManifest(aW.manifest, aW.entries)
+	}
+	return nil
+}
+
+//--------------------------------------
+
+// Runs the watch subcommand keeping a manifest of a directory tree in sync.
+func runWatch(aArgs []string, aIn io.Reader, aOut, aErr io.Writer) int {
+	vFlags := flag.NewFlagSet("crc16 watch", flag.ContinueOnError)
+	vFlags.SetOutput(aErr)
+	var vAlgo string
+	vFlags.StringVar(&vAlgo, "a", cDefaultAlgo, "checksum algorithm (shorthand)")
+	vFlags.StringVar(&vAlgo, "algo", cDefaultAlgo, "checksum algorithm")
+	vSpec := vFlags.String("spec", "", "custom algorithm parameters")
+	vManifest := vFlags.String("manifest", "", "the manifest of the directory")
+	vUpdate := vFlags.Bool("update", false, "update the manifest instead of verifying against it")
+	vInterval := vFlags.Duration("interval", time.Second, "interval between polls of the directory")
+	vCount := vFlags.Int("count", 0, "stop after the number of polls, 0 for no limit")
+	if err := vFlags.Parse(aArgs); err != nil {
+		return 2
+	}
+
+	vTable, err := selectTable(vAlgo, *vSpec)
+	if err == nil && (*vManifest == "" || vFlags.NArg() != 1) {
+		err = fmt.Errorf("watch: a manifest and a single directory must be specified")
+	}
+	if err != nil {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 2
+	}
+
+	vW := &tWatcher{
+		dir:      vFlags.Arg(0),
+		manifest: *vManifest,
+		update:   *vUpdate,
+		opts:     &tSumOptions{table: vTable, in: cInRaw},
+		files:    map[string]tFileState{},
+		entries:  map[string]uint16{},
+		out:      aOut,
+		err:      aErr,
+	}
+	if vFile, err := os.Open(*vManifest); err == nil {
+		vEntries, err := readManifest(vFile)
+		vFile.Close()
+		if err != nil {
+			fmt.Fprintf(aErr, "crc16: %s: %v\n", *vManifest, err)
+			return 1
+		}
+		for _, e := range vEntries {
+			vW.entries[e.Name] = e.Sum
+		}
+	} else if !os.IsNotExist(err) || !*vUpdate {
+		fmt.Fprintln(aErr, "crc16:", err)
+		return 1
+	}
+
+	for i := 0; *vCount == 0 || i < *vCount; i++ {
+		if i > 0 {
+			time.Sleep(*vInterval)
+		}
+		if err := vW.poll(); err != nil {
+			fmt.Fprintln(aErr, "crc16:", err)
+			return 1
+		}
+	}
+	return 0
+}
+
+//-----------------------------------------------------------------------------
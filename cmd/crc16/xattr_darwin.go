@@ -0,0 +1,60 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+//-----------------------------------------------------------------------------
+
+// Returns the value of the extended attribute of the named file, or nil if it is not set.
+func getXattr(aPath, aAttr string) ([]byte, error) {
+	vPath, err := syscall.BytePtrFromString(aPath)
+	if err != nil {
+		return nil, err
+	}
+	vAttr, err := syscall.BytePtrFromString(aAttr)
+	if err != nil {
+		return nil, err
+	}
+	vBuf := make([]byte, 256)
+	n, _, vErrno := syscall.Syscall6(syscall.SYS_GETXATTR, uintptr(unsafe.Pointer(vPath)), uintptr(unsafe.Pointer(vAttr)),
+		uintptr(unsafe.Pointer(&vBuf[0])), uintptr(len(vBuf)), 0, 0)
+	if vErrno == syscall.ENOATTR {
+		return nil, nil
+	}
+	if vErrno != 0 {
+		return nil, vErrno
+	}
+	return vBuf[:n], nil
+}
+
+//--------------------------------------
+
+// Sets the extended attribute of the named file.
+func setXattr(aPath, aAttr string, aValue []byte) error {
+	vPath, err := syscall.BytePtrFromString(aPath)
+	if err != nil {
+		return err
+	}
+	vAttr, err := syscall.BytePtrFromString(aAttr)
+	if err != nil {
+		return err
+	}
+	var vValue unsafe.Pointer
+	if len(aValue) > 0 {
+		vValue = unsafe.Pointer(&aValue[0])
+	}
+	_, _, vErrno := syscall.Syscall6(syscall.SYS_SETXATTR, uintptr(unsafe.Pointer(vPath)), uintptr(unsafe.Pointer(vAttr)),
+		uintptr(vValue), uintptr(len(aValue)), 0, 0)
+	if vErrno != 0 {
+		return vErrno
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,31 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import "syscall"
+
+//-----------------------------------------------------------------------------
+
+// Returns the value of the extended attribute of the named file, or nil if it is not set.
+func getXattr(aPath, aAttr string) ([]byte, error) {
+	vBuf := make([]byte, 256)
+	n, err := syscall.Getxattr(aPath, aAttr, vBuf)
+	if err == syscall.ENODATA {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return vBuf[:n], nil
+}
+
+//--------------------------------------
+
+// Sets the extended attribute of the named file.
+func setXattr(aPath, aAttr string, aValue []byte) error {
+	return syscall.Setxattr(aPath, aAttr, aValue, 0)
+}
+
+//-----------------------------------------------------------------------------
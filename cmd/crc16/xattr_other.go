@@ -0,0 +1,23 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && !linux && !darwin
+
+package main
+
+import "errors"
+
+//-----------------------------------------------------------------------------
+
+// Returns the value of the extended attribute of the named file, or nil if it is not set.
+func getXattr(aPath, aAttr string) ([]byte, error) {
+	return nil, errors.ErrUnsupported
+}
+
+//--------------------------------------
+
+// Sets the extended attribute of the named file.
+func setXattr(aPath, aAttr string, aValue []byte) error {
+	return errors.ErrUnsupported
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,150 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+//-----------------------------------------------------------------------------
+
+// Returns true if any of the algorithms needs bit reversal at runtime.
+func needsReverse(aGens []*tAlgoGen) bool {
+	for _, g := range aGens {
+		if !g.reflected && (g.algo.RefIn || g.algo.RefOut) {
+			return true
+		}
+	}
+	return false
+}
+
+//--------------------------------------
+
+// Returns the include guard macro of the named header file.
+func includeGuard(aHeader string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, aHeader)
+}
+
+//--------------------------------------
+
+// Writes the C header declaring the tables and functions of the algorithms.
+func writeCHeader(w io.Writer, aHeader string, aGens []*tAlgoGen) error {
+	vW := bufio.NewWriter(w)
+	vGuard := includeGuard(aHeader)
+	fmt.Fprintf(vW, "/* Code generated by crc16gen; DO NOT EDIT. */\n\n#ifndef %s\n#define %s\n\n", vGuard, vGuard)
+	fmt.Fprintf(vW, "#include <stddef.h>\n#include <stdint.h>\n\n#ifdef __cplusplus\nextern \"C\" {\n#endif\n")
+	for _, g := range aGens {
+		vUpper := strings.ToUpper(g.snake)
+		fmt.Fprintf(vW, "\n/* %s (%s).\n", g.algo.Name, g.params())
+		fmt.Fprintf(vW, " * Start the register with %s_INIT, update it with %s_update and\n", vUpper, g.snake)
+		fmt.Fprintf(vW, " * finish it with %s_final, or use %s for a single buffer. */\n", g.snake, g.snake)
+		fmt.Fprintf(vW, "#define %s_INIT 0x%04xu\n", vUpper, g.init)
+		switch {
+		case g.nibbles != nil:
+			fmt.Fprintf(vW, "extern const uint16_t %s_table[16];\n", g.snake)
+		case g.slices == nil:
+			fmt.Fprintf(vW, "extern const uint16_t %s_table[256];\n", g.snake)
+		default:
+			fmt.Fprintf(vW, "extern const uint16_t %s_table[%d][256];\n", g.snake, len(g.slices))
+		}
+		fmt.Fprintf(vW, "uint16_t %s_update(uint16_t crc, const void *data, size_t len);\n", g.snake)
+		fmt.Fprintf(vW, "uint16_t %s_final(uint16_t crc);\n", g.snake)
+		fmt.Fprintf(vW, "uint16_t %s(const void *data, size_t len);\n", g.snake)
+	}
+	fmt.Fprintf(vW, "\n#ifdef __cplusplus\n}\n#endif\n\n#endif /* %s */\n", vGuard)
+	return vW.Flush()
+}
+
+//--------------------------------------
+
+// Writes the C source defining the tables and functions declared by the header.
+func writeCSource(w io.Writer, aHeader string, aGens []*tAlgoGen) error {
+	vW := bufio.NewWriter(w)
+	fmt.Fprintf(vW, "/* Code generated by crc16gen; DO NOT EDIT. */\n\n#include \"%s\"\n", aHeader)
+	if needsReverse(aGens) {
+		fmt.Fprintf(vW, "\nstatic uint16_t reverse16(uint16_t v)\n{\n    uint16_t r = 0;\n    int i;\n")
+		fmt.Fprintf(vW, "    for (i = 0; i < 16; i++, v >>= 1)\n        r = (uint16_t)(r << 1 | (v & 1));\n    return r;\n}\n")
+	}
+	for _, g := range aGens {
+		vByte := "%s"
+		if !g.reflected && g.algo.RefIn {
+			vByte = "reverse16(%s) >> 8"
+		}
+		vTable := g.snake + "_table"
+		switch {
+		case g.nibbles != nil:
+			fmt.Fprintf(vW, "\nconst uint16_t %s[16] = {\n%s};\n", vTable, formatEntries(g.nibbles, "    ", "0x", false))
+		case g.slices == nil:
+			fmt.Fprintf(vW, "\nconst uint16_t %s[256] = {\n%s};\n", vTable, formatEntries(g.table[:], "    ", "0x", false))
+		default:
+			fmt.Fprintf(vW, "\nconst uint16_t %s[%d][256] = {\n", vTable, len(g.slices))
+			for k := range g.slices {
+				fmt.Fprintf(vW, "    {\n%s    }", formatEntries(g.slices[k][:], "        ", "0x", false))
+				if k < len(g.slices)-1 {
+					fmt.Fprintf(vW, ",")
+				}
+				fmt.Fprintf(vW, "\n")
+			}
+			fmt.Fprintf(vW, "};\n")
+			vTable += "[0]"
+		}
+
+		fmt.Fprintf(vW, "\nuint16_t %s_update(uint16_t crc, const void *data, size_t len)\n{\n", g.snake)
+		fmt.Fprintf(vW, "    const uint8_t *p = (const uint8_t *)data;\n")
+		if n := len(g.slices); n > 0 {
+			var vTerms []string
+			for i := range n {
+				vTerm := fmt.Sprintf(vByte, fmt.Sprintf("p[%d]", i))
+				switch g.sliceShift(i) {
+				case 0:
+					vTerm = "(crc ^ " + vTerm + ") & 0xff"
+				case 8:
+					vTerm = "(crc >> 8 ^ " + vTerm + ") & 0xff"
+				}
+				vTerms = append(vTerms, fmt.Sprintf("%s_table[%d][%s]", g.snake, n-1-i, vTerm))
+			}
+			fmt.Fprintf(vW, "    for (; len >= %d; p += %d, len -= %d)\n        crc = (uint16_t)(%s);\n", n, n, n, strings.Join(vTerms, " ^\n            "))
+		}
+		fmt.Fprintf(vW, "    while (len--) {\n")
+		switch {
+		case g.nibbles != nil:
+			fmt.Fprintf(vW, "        uint8_t b = (uint8_t)(%s);\n", fmt.Sprintf(vByte, "*p++"))
+			if g.reflected {
+				fmt.Fprintf(vW, "        crc = (uint16_t)(crc >> 4 ^ %s[(crc ^ b) & 0x0f]);\n", vTable)
+				fmt.Fprintf(vW, "        crc = (uint16_t)(crc >> 4 ^ %s[(crc ^ b >> 4) & 0x0f]);\n", vTable)
+			} else {
+				fmt.Fprintf(vW, "        crc = (uint16_t)(crc << 4 ^ %s[(crc >> 12 ^ b >> 4) & 0x0f]);\n", vTable)
+				fmt.Fprintf(vW, "        crc = (uint16_t)(crc << 4 ^ %s[(crc >> 12 ^ b) & 0x0f]);\n", vTable)
+			}
+		case g.reflected:
+			fmt.Fprintf(vW, "        crc = (uint16_t)(crc >> 8 ^ %s[(crc ^ %s) & 0xff]);\n", vTable, fmt.Sprintf(vByte, "*p++"))
+		default:
+			fmt.Fprintf(vW, "        crc = (uint16_t)(crc << 8 ^ %s[(crc >> 8 ^ %s) & 0xff]);\n", vTable, fmt.Sprintf(vByte, "*p++"))
+		}
+		fmt.Fprintf(vW, "    }\n    return crc;\n}\n")
+
+		fmt.Fprintf(vW, "\nuint16_t %s_final(uint16_t crc)\n{\n", g.snake)
+		if !g.reflected && g.algo.RefOut {
+			fmt.Fprintf(vW, "    return (uint16_t)(reverse16(crc) ^ 0x%04xu);\n}\n", g.algo.XorOut)
+		} else {
+			fmt.Fprintf(vW, "    return (uint16_t)(crc ^ 0x%04xu);\n}\n", g.algo.XorOut)
+		}
+
+		fmt.Fprintf(vW, "\nuint16_t %s(const void *data, size_t len)\n{\n", g.snake)
+		fmt.Fprintf(vW, "    return %s_final(%s_update(%s_INIT, data, len));\n}\n", g.snake, g.snake, strings.ToUpper(g.snake))
+	}
+	return vW.Flush()
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,54 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+//-----------------------------------------------------------------------------
+
+// Writes a C# static class Crc16 in the namespace with a lookup table and a checksum method
+// for every algorithm, e.g. Crc16.Modbus.
+func writeCSharp(w io.Writer, aNamespace string, aGens []*tAlgoGen) error {
+	vW := bufio.NewWriter(w)
+	fmt.Fprintf(vW, "// Code generated by crc16gen; DO NOT EDIT.\n\nnamespace %s\n{\n    public static class Crc16\n    {\n", aNamespace)
+	if needsReverse(aGens) {
+		fmt.Fprintf(vW, "        private static ushort Reverse(ushort v, int n)\n        {\n            ushort r = 0;\n")
+		fmt.Fprintf(vW, "            for (int i = 0; i < n; i++, v >>= 1)\n            {\n                r = (ushort)(r << 1 | (v & 1));\n            }\n")
+		fmt.Fprintf(vW, "            return r;\n        }\n\n")
+	}
+	for i, g := range aGens {
+		if i > 0 {
+			fmt.Fprintf(vW, "\n")
+		}
+		fmt.Fprintf(vW, "        // Lookup table of %s\n        // (%s).\n", g.algo.Name, g.params())
+		fmt.Fprintf(vW, "        private static readonly ushort[] %sTable =\n        {\n%s        };\n", g.ident, formatEntries(g.table[:], "            ", "0x", true))
+
+		fmt.Fprintf(vW, "\n        /// <summary>Returns the %s checksum of data.</summary>\n", g.algo.Name)
+		fmt.Fprintf(vW, "        public static ushort %s(byte[] data)\n        {\n            ushort crc = 0x%04x;\n", g.ident, g.init)
+		fmt.Fprintf(vW, "            foreach (byte b in data)\n            {\n")
+		switch {
+		case g.reflected:
+			fmt.Fprintf(vW, "                crc = (ushort)(crc >> 8 ^ %sTable[(crc ^ b) & 0xff]);\n", g.ident)
+		case g.algo.RefIn:
+			fmt.Fprintf(vW, "                crc = (ushort)(crc << 8 ^ %sTable[(crc >> 8 ^ Reverse(b, 8)) & 0xff]);\n", g.ident)
+		default:
+			fmt.Fprintf(vW, "                crc = (ushort)(crc << 8 ^ %sTable[(crc >> 8 ^ b) & 0xff]);\n", g.ident)
+		}
+		fmt.Fprintf(vW, "            }\n")
+		if !g.reflected && g.algo.RefOut {
+			fmt.Fprintf(vW, "            return (ushort)(Reverse(crc, 16) ^ 0x%04x);\n        }\n", g.algo.XorOut)
+		} else {
+			fmt.Fprintf(vW, "            return (ushort)(crc ^ 0x%04x);\n        }\n", g.algo.XorOut)
+		}
+	}
+	fmt.Fprintf(vW, "    }\n}\n")
+	return vW.Flush()
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,96 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"strings"
+)
+
+//-----------------------------------------------------------------------------
+
+// Writes Go source with a lookup table and a Checksum function for every algorithm.
+func writeGo(w io.Writer, aPkg string, aGens []*tAlgoGen) error {
+	var vB bytes.Buffer
+	fmt.Fprintf(&vB, "// Code generated by crc16gen; DO NOT EDIT.\n\npackage %s\n\n", aPkg)
+	for _, g := range aGens {
+		if !g.reflected && (g.algo.RefIn || g.algo.RefOut) {
+			fmt.Fprintf(&vB, "import \"math/bits\"\n\n")
+			break
+		}
+	}
+
+	for _, g := range aGens {
+		vByte := "%s"
+		if !g.reflected && g.algo.RefIn {
+			vByte = "bits.Reverse8(%s)"
+		}
+		vTable := "table" + g.ident
+		fmt.Fprintf(&vB, "// %s is the lookup table of %s\n// (%s).\n", vTable, g.algo.Name, g.params())
+		switch {
+		case g.nibbles != nil:
+			fmt.Fprintf(&vB, "var %s = [16]uint16{\n%s}\n\n", vTable, formatEntries(g.nibbles, "\t", "0x", true))
+		case g.slices == nil:
+			fmt.Fprintf(&vB, "var %s = [256]uint16{\n%s}\n\n", vTable, formatEntries(g.table[:], "\t", "0x", true))
+		default:
+			fmt.Fprintf(&vB, "// %s[k][b] is the contribution of the byte b followed by k more in a step.\n", vTable)
+			fmt.Fprintf(&vB, "var %s = [%d][256]uint16{\n", vTable, len(g.slices))
+			for k := range g.slices {
+				fmt.Fprintf(&vB, "\t{\n%s\t},\n", formatEntries(g.slices[k][:], "\t\t", "0x", true))
+			}
+			fmt.Fprintf(&vB, "}\n\n")
+			vTable += "[0]"
+		}
+
+		fmt.Fprintf(&vB, "// Checksum%s returns the %s checksum of data.\n", g.ident, g.algo.Name)
+		fmt.Fprintf(&vB, "func Checksum%s(data []byte) uint16 {\n\tcrc := uint16(0x%04x)\n", g.ident, g.init)
+		if n := len(g.slices); n > 0 {
+			var vTerms []string
+			for i := range n {
+				vTerm := fmt.Sprintf(vByte, fmt.Sprintf("data[%d]", i))
+				switch g.sliceShift(i) {
+				case 0:
+					vTerm = "byte(crc)^" + vTerm
+				case 8:
+					vTerm = "byte(crc>>8)^" + vTerm
+				}
+				vTerms = append(vTerms, fmt.Sprintf("table%s[%d][%s]", g.ident, n-1-i, vTerm))
+			}
+			fmt.Fprintf(&vB, "\tfor len(data) >= %d {\n\t\tcrc = %s\n\t\tdata = data[%d:]\n\t}\n", n, strings.Join(vTerms, " ^\n\t\t\t"), n)
+		}
+		fmt.Fprintf(&vB, "\tfor _, b := range data {\n")
+		switch {
+		case g.nibbles != nil && g.reflected:
+			fmt.Fprintf(&vB, "\t\tcrc = crc>>4 ^ %s[(byte(crc)^b)&0x0f]\n", vTable)
+			fmt.Fprintf(&vB, "\t\tcrc = crc>>4 ^ %s[(byte(crc)^b>>4)&0x0f]\n\t}\n", vTable)
+		case g.nibbles != nil:
+			if g.algo.RefIn {
+				fmt.Fprintf(&vB, "\t\tb = bits.Reverse8(b)\n")
+			}
+			fmt.Fprintf(&vB, "\t\tcrc = crc<<4 ^ %s[byte(crc>>12)^b>>4]\n", vTable)
+			fmt.Fprintf(&vB, "\t\tcrc = crc<<4 ^ %s[byte(crc>>12)^b&0x0f]\n\t}\n", vTable)
+		case g.reflected:
+			fmt.Fprintf(&vB, "\t\tcrc = crc>>8 ^ %s[byte(crc)^%s]\n\t}\n", vTable, fmt.Sprintf(vByte, "b"))
+		default:
+			fmt.Fprintf(&vB, "\t\tcrc = crc<<8 ^ %s[byte(crc>>8)^%s]\n\t}\n", vTable, fmt.Sprintf(vByte, "b"))
+		}
+		if !g.reflected && g.algo.RefOut {
+			fmt.Fprintf(&vB, "\tcrc = bits.Reverse16(crc)\n")
+		}
+		fmt.Fprintf(&vB, "\treturn crc ^ 0x%04x\n}\n\n", g.algo.XorOut)
+	}
+
+	vSrc, err := format.Source(vB.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(vSrc)
+	return err
+}
+
+//-----------------------------------------------------------------------------
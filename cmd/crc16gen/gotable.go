@@ -0,0 +1,99 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"math/bits"
+
+	"github.com/mbsulliv/crc16"
+	"github.com/mbsulliv/crc16/crc"
+)
+
+//-----------------------------------------------------------------------------
+
+// Writes Go source declaring a *crc16.TTable built from precomputed entries for every
+// algorithm, in the layout of package crc16 built with or without the crc16_nibble tag.
+func writeGoTables(w io.Writer, aPkg string, aNibble bool, aGens []*tAlgoGen) error {
+	var vB bytes.Buffer
+	vTag := "!crc16_nibble"
+	if aNibble {
+		vTag = "crc16_nibble"
+	}
+	fmt.Fprintf(&vB, "// Code generated by crc16gen; DO NOT EDIT.\n\n//go:build %s\n\npackage %s\n\n", vTag, aPkg)
+	fmt.Fprintf(&vB, "import \"github.com/mbsulliv/crc16\"\n\n")
+
+	for _, g := range aGens {
+		vTable := "table" + g.ident
+		vReflected := "nil"
+		if aNibble {
+			fmt.Fprintf(&vB, "// %s holds the entries of %sTable.\n", vTable, g.ident)
+			fmt.Fprintf(&vB, "var %s = [16]uint16{\n%s}\n\n", vTable, formatEntries(msbNibbles(g.algo.Poly), "\t", "0x", true))
+		} else {
+			vEntries := crc.MakeEntries(g.algo.Poly)
+			fmt.Fprintf(&vB, "// %s holds the entries of %sTable.\n", vTable, g.ident)
+			fmt.Fprintf(&vB, "var %s = [256]uint16{\n%s}\n\n", vTable, formatEntries(vEntries[:], "\t", "0x", true))
+			if g.algo.RefIn {
+				vEntries = crc.MakeEntriesReflected(bits.Reverse16(g.algo.Poly))
+				vReflected = "&" + vTable + "Reflected"
+				fmt.Fprintf(&vB, "// %sReflected holds the entries of %sTable for reflected input.\n", vTable, g.ident)
+				fmt.Fprintf(&vB, "var %sReflected = [256]uint16{\n%s}\n\n", vTable, formatEntries(vEntries[:], "\t", "0x", true))
+			}
+		}
+
+		fmt.Fprintf(&vB, "// %sTable is the table of %s\n// (%s).\n", g.ident, g.algo.Name, g.params())
+		fmt.Fprintf(&vB, "var %sTable = crc16.MakeTablePrecomputed(%s, %s[:], %s)\n\n", g.ident, algoLiteral(&g.algo), vTable, vReflected)
+	}
+
+	vSrc, err := format.Source(vB.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(vSrc)
+	return err
+}
+
+//--------------------------------------
+
+// Returns the 16 entries shifting the register four bits at a time most significant bit first,
+// as held by tables of package crc16 built with the crc16_nibble tag.
+func msbNibbles(aPoly uint16) []uint16 {
+	vRet := make([]uint16, 16)
+	for n := range vRet {
+		crc := uint16(n) << 12
+		for i := 0; i < 4; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ aPoly
+			} else {
+				crc <<= 1
+			}
+		}
+		vRet[n] = crc
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Returns the Go composite literal of the algorithm.
+func algoLiteral(aAlgo *crc16.TAlgo) string {
+	vRet := fmt.Sprintf("crc16.TAlgo{Poly: 0x%04x, Init: 0x%04x, RefIn: %t, RefOut: %t, XorOut: 0x%04x, Check: 0x%04x, Name: %q",
+		aAlgo.Poly, aAlgo.Init, aAlgo.RefIn, aAlgo.RefOut, aAlgo.XorOut, aAlgo.Check, aAlgo.Name)
+	switch aAlgo.Trailer {
+	case crc16.TrailerLittleEndian:
+		vRet += ", Trailer: crc16.TrailerLittleEndian"
+	case crc16.TrailerBigEndian:
+		vRet += ", Trailer: crc16.TrailerBigEndian"
+	}
+	if aAlgo.Width != 0 {
+		vRet += fmt.Sprintf(", Width: %d", aAlgo.Width)
+	}
+	return vRet + "}"
+}
+
+//-----------------------------------------------------------------------------
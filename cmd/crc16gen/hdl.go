@@ -0,0 +1,185 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/bits"
+	"strings"
+)
+
+//-----------------------------------------------------------------------------
+
+// This file contains the generation of the parallel update equations of the register
+// for hardware description languages.
+//
+// The equations update the register most significant bit first, without reflection,
+// so that it starts with Init as listed in the catalogue. The data word holds width/8
+// bytes of the message, the first in its most significant bits.
+
+// tEquations are the parallel update equations of the register for a data word:
+// bit o of the next register is the XOR of the register bits set in c[o] and the data
+// bits set in d[o].
+type tEquations struct {
+	width int
+	c     [16]uint16
+	d     [16]uint64
+}
+
+//-----------------------------------------------------------------------------
+
+// Returns the register after the data word of aWidth bits is shifted into it bit by bit.
+func (aG *tAlgoGen) shiftWord(c uint16, d uint64, aWidth int) uint16 {
+	for k := 0; k < aWidth/8; k++ {
+		b := byte(d >> (aWidth - 8 - 8*k))
+		if aG.algo.RefIn {
+			b = bits.Reverse8(b)
+		}
+		c ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if c&0x8000 != 0 {
+				c = c<<1 ^ aG.algo.Poly
+			} else {
+				c <<= 1
+			}
+		}
+	}
+	return c
+}
+
+//--------------------------------------
+
+// Returns the equations of the algorithm for data words of aWidth bits.
+func (aG *tAlgoGen) equations(aWidth int) *tEquations {
+	vE := &tEquations{width: aWidth}
+	for i := 0; i < 16; i++ {
+		vNext := aG.shiftWord(1<<i, 0, aWidth)
+		for o := 0; o < 16; o++ {
+			vE.c[o] |= (vNext >> o & 1) << i
+		}
+	}
+	for j := 0; j < aWidth; j++ {
+		vNext := aG.shiftWord(0, 1<<j, aWidth)
+		for o := 0; o < 16; o++ {
+			vE.d[o] |= uint64(vNext>>o&1) << j
+		}
+	}
+	return vE
+}
+
+//--------------------------------------
+
+// Returns the register after the data word is shifted into c according to the equations.
+func (aE *tEquations) next(c uint16, d uint64) uint16 {
+	var vRet uint16
+	for o := 0; o < 16; o++ {
+		vParity := bits.OnesCount16(aE.c[o]&c) + bits.OnesCount64(aE.d[o]&d)
+		vRet |= uint16(vParity&1) << o
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Returns the terms of the equation of bit o, each formatted with aTerm from the operand
+// name and bit index, joined by aXor. An equation without terms yields aZero.
+func (aE *tEquations) terms(o int, aTerm func(aName string, i int) string, aXor, aZero string) string {
+	var vTerms []string
+	for i := 0; i < 16; i++ {
+		if aE.c[o]>>i&1 != 0 {
+			vTerms = append(vTerms, aTerm("c", i))
+		}
+	}
+	for j := 0; j < aE.width; j++ {
+		if aE.d[o]>>j&1 != 0 {
+			vTerms = append(vTerms, aTerm("d", j))
+		}
+	}
+	if len(vTerms) == 0 {
+		return aZero
+	}
+	return strings.Join(vTerms, aXor)
+}
+
+//-----------------------------------------------------------------------------
+
+// Writes Verilog functions computing the next register and the final checksum of every
+// algorithm, to be included in a module.
+func writeVerilog(w io.Writer, aWidth int, aGens []*tAlgoGen) error {
+	vW := bufio.NewWriter(w)
+	fmt.Fprintf(vW, "// Code generated by crc16gen; DO NOT EDIT.\n")
+	for _, g := range aGens {
+		vE := g.equations(aWidth)
+		fmt.Fprintf(vW, "\n// %s (%s).\n", g.algo.Name, g.params())
+		fmt.Fprintf(vW, "// Start the register with %s_INIT, update it with %s_next for every %d-bit\n", strings.ToUpper(g.snake), g.snake, aWidth)
+		fmt.Fprintf(vW, "// data word, the first byte in its most significant bits, and finish it with %s_final.\n", g.snake)
+		fmt.Fprintf(vW, "localparam [15:0] %s_INIT = 16'h%04x;\n", strings.ToUpper(g.snake), g.algo.Init)
+
+		fmt.Fprintf(vW, "\nfunction [15:0] %s_next;\n    input [15:0] c;\n    input [%d:0] d;\n    begin\n", g.snake, aWidth-1)
+		for o := 0; o < 16; o++ {
+			vTerms := vE.terms(o, func(aName string, i int) string { return fmt.Sprintf("%s[%d]", aName, i) }, " ^ ", "1'b0")
+			fmt.Fprintf(vW, "        %s_next[%d] = %s;\n", g.snake, o, vTerms)
+		}
+		fmt.Fprintf(vW, "    end\nendfunction\n")
+
+		fmt.Fprintf(vW, "\nfunction [15:0] %s_final;\n    input [15:0] c;\n    begin\n", g.snake)
+		if g.algo.RefOut {
+			var vBits []string
+			for i := 0; i < 16; i++ {
+				vBits = append(vBits, fmt.Sprintf("c[%d]", i))
+			}
+			fmt.Fprintf(vW, "        %s_final = {%s} ^ 16'h%04x;\n", g.snake, strings.Join(vBits, ", "), g.algo.XorOut)
+		} else {
+			fmt.Fprintf(vW, "        %s_final = c ^ 16'h%04x;\n", g.snake, g.algo.XorOut)
+		}
+		fmt.Fprintf(vW, "    end\nendfunction\n")
+	}
+	return vW.Flush()
+}
+
+//--------------------------------------
+
+// Writes a VHDL package with functions computing the next register and the final checksum
+// of every algorithm.
+func writeVHDL(w io.Writer, aPkg string, aWidth int, aGens []*tAlgoGen) error {
+	vW := bufio.NewWriter(w)
+	vNext := "function %s_next(c : std_logic_vector(15 downto 0); d : std_logic_vector(%d downto 0)) return std_logic_vector"
+	vFinal := "function %s_final(c : std_logic_vector(15 downto 0)) return std_logic_vector"
+	fmt.Fprintf(vW, "-- Code generated by crc16gen; DO NOT EDIT.\n\nlibrary ieee;\nuse ieee.std_logic_1164.all;\n\npackage %s is\n", aPkg)
+	for _, g := range aGens {
+		fmt.Fprintf(vW, "\n    -- %s (%s).\n", g.algo.Name, g.params())
+		fmt.Fprintf(vW, "    -- Start the register with %s_INIT, update it with %s_next for every %d-bit\n", strings.ToUpper(g.snake), g.snake, aWidth)
+		fmt.Fprintf(vW, "    -- data word, the first byte in its most significant bits, and finish it with %s_final.\n", g.snake)
+		fmt.Fprintf(vW, "    constant %s_INIT : std_logic_vector(15 downto 0) := x\"%04X\";\n", strings.ToUpper(g.snake), g.algo.Init)
+		fmt.Fprintf(vW, "    "+vNext+";\n", g.snake, aWidth-1)
+		fmt.Fprintf(vW, "    "+vFinal+";\n", g.snake)
+	}
+	fmt.Fprintf(vW, "end package %s;\n\npackage body %s is\n", aPkg, aPkg)
+	for _, g := range aGens {
+		vE := g.equations(aWidth)
+		fmt.Fprintf(vW, "\n    "+vNext+" is\n", g.snake, aWidth-1)
+		fmt.Fprintf(vW, "        variable n : std_logic_vector(15 downto 0);\n    begin\n")
+		for o := 0; o < 16; o++ {
+			vTerms := vE.terms(o, func(aName string, i int) string { return fmt.Sprintf("%s(%d)", aName, i) }, " xor ", "'0'")
+			fmt.Fprintf(vW, "        n(%d) := %s;\n", o, vTerms)
+		}
+		fmt.Fprintf(vW, "        return n;\n    end function;\n")
+
+		fmt.Fprintf(vW, "\n    "+vFinal+" is\n", g.snake)
+		fmt.Fprintf(vW, "        variable n : std_logic_vector(15 downto 0);\n    begin\n")
+		if g.algo.RefOut {
+			fmt.Fprintf(vW, "        for i in 0 to 15 loop\n            n(i) := c(15 - i);\n        end loop;\n")
+		} else {
+			fmt.Fprintf(vW, "        n := c;\n")
+		}
+		fmt.Fprintf(vW, "        return n xor x\"%04X\";\n    end function;\n", g.algo.XorOut)
+	}
+	fmt.Fprintf(vW, "end package body %s;\n", aPkg)
+	return vW.Flush()
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,34 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"bytes"
+	"path"
+	"runtime"
+)
+
+//-----------------------------------------------------------------------------
+
+// Returns the function name of the calling function.
+func funcName() string {
+	vRet := "?"
+	vPc, _, _, vOk := runtime.Caller(1)
+	if vOk {
+		vRet = path.Base(runtime.FuncForPC(vPc).Name())
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Runs the command with the specified arguments and returns the exit code and output streams.
+func runGen(aArgs ...string) (int, string, string) {
+	var vOut, vErr bytes.Buffer
+	vCode := run(aArgs, &vOut, &vErr)
+	return vCode, vOut.String(), vErr.String()
+}
+
+//-----------------------------------------------------------------------------
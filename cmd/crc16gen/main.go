@@ -0,0 +1,268 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+// Command crc16gen generates source code computing CRC-16 checksums of the algorithms
+// of package crc16, with precomputed lookup tables and no dependency on the package.
+//
+// Usage:
+//
+//	crc16gen -a algo[,algo ...] [-lang go|c|rust|python|csharp] [-slicing 1|4|8 | -nibble] [-pkg name] [-o file]
+//	crc16gen -a algo[,algo ...] -table [-nibble] [-pkg name] [-o file]
+//	crc16gen -a algo[,algo ...] -lang verilog|vhdl [-width 8|16|32|64] [-pkg name] [-o file]
+//	crc16gen -sync file
+//
+// For every algorithm, the generated Go source declares a lookup table and a function
+// Checksum<Name> named after the algorithm, e.g. ChecksumModbus for CRC-16/MODBUS.
+// Algorithm names are matched case-insensitively, with or without the "CRC-16/" prefix.
+//
+// With -lang c, -o names the C source file and a header with the same base name is written
+// next to it. For every algorithm they define a uint16_t lookup table and the functions
+// crc16_<name>_update, crc16_<name>_final and crc16_<name>, e.g. crc16_modbus.
+//
+// The Rust and Python modules define a table constant and a function crc16_<name> for
+// every algorithm. The C# source defines a static class Crc16 in the namespace set by -pkg,
+// Crc16 by default, with a method per algorithm, e.g. Crc16.Modbus.
+//
+// With -slicing 4 or 8, the Go and C sources process that many bytes per step using as many
+// precomputed tables, 2 or 4 KiB per algorithm, for throughput without building tables at startup.
+// With -nibble, the Go, C and Rust sources use 32-byte tables processing four bits per step instead,
+// like the package built with the crc16_nibble tag, for microcontrollers short of memory.
+//
+// With -table, the Go source instead declares a variable <Name>Table of type *crc16.TTable
+// for every algorithm, e.g. ModbusTable, built by crc16.MakeTablePrecomputed from entries
+// in the layout of package crc16, so that programs using the package build no tables at
+// startup. The file is constrained to builds without the crc16_nibble tag, or with it if
+// -nibble is also given; generating both files serves either build.
+//
+// With -lang verilog or vhdl, the parallel XOR equations updating the register with -width bits
+// of data per clock are generated, as functions to include in a module or as a VHDL package named
+// by -pkg, crc16_pkg by default. The register is kept most significant bit first and starts
+// with the Init of the catalogue; the first byte of the data word is in its most significant bits.
+//
+// With -sync, the width=16 entries of the named catalogue file in the notation of the CRC RevEng
+// catalogue, e.g. saved from its web page, are compared with the predefined algorithms of
+// the package, and the definitions of those missing or different are written for the catalogue
+// files of the package. The exit code is 1 if there are any.
+//
+// It is meant to be run by go generate, e.g.
+//
+//	//go:generate go run github.com/mbsulliv/crc16/cmd/crc16gen -a modbus,xmodem -o crc_gen.go
+//
+// The package name defaults to $GOPACKAGE, which go generate sets.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mbsulliv/crc16"
+)
+
+// tFile is a generated file.
+type tFile struct {
+	name    string
+	content []byte
+}
+
+//-----------------------------------------------------------------------------
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+//--------------------------------------
+
+// Runs the command with the specified arguments and streams and returns the exit code.
+func run(aArgs []string, aOut, aErr io.Writer) int {
+	vFlags := flag.NewFlagSet("crc16gen", flag.ContinueOnError)
+	vFlags.SetOutput(aErr)
+	var vAlgos string
+	vFlags.StringVar(&vAlgos, "a", "", "comma-separated algorithms (shorthand)")
+	vFlags.StringVar(&vAlgos, "algo", "", "comma-separated algorithms, e.g. CRC-16/MODBUS,xmodem")
+	vLang := vFlags.String("lang", "go", "language of the generated source: go, c, rust, python, csharp, verilog or vhdl")
+	vPkg := vFlags.String("pkg", os.Getenv("GOPACKAGE"), "package of the generated Go source (default $GOPACKAGE or main), namespace of the C# source or VHDL package")
+	vOut := vFlags.String("o", "", "write the generated source to the file instead of standard output")
+	vSlicing := vFlags.Int("slicing", 1, "bytes processed per step with precomputed slicing tables: 1, 4 or 8 (go and c only)")
+	vNibble := vFlags.Bool("nibble", false, "use 16-entry tables processing four bits per step (go, c and rust only)")
+	vTable := vFlags.Bool("table", false, "declare a *crc16.TTable per algorithm from precomputed entries instead of standalone functions (go only)")
+	vWidth := vFlags.Int("width", 8, "bits of data processed per clock by the verilog and vhdl equations: 8, 16, 32 or 64")
+	vSync := vFlags.String("sync", "", "compare the predefined algorithms with the RevEng catalogue `file` and write the definitions to update")
+	if err := vFlags.Parse(aArgs); err != nil {
+		return 2
+	}
+	if *vSync != "" {
+		return runSync(*vSync, aOut, aErr)
+	}
+	if *vPkg == "" {
+		*vPkg = "main"
+	}
+
+	vGens, err := selectAlgos(vAlgos)
+	if err == nil {
+		err = checkTables(*vLang, *vSlicing, *vNibble, *vTable)
+	}
+	if err == nil && *vWidth != 8 && *vWidth != 16 && *vWidth != 32 && *vWidth != 64 {
+		err = fmt.Errorf("invalid -width %d: must be 8, 16, 32 or 64", *vWidth)
+	}
+	if err != nil {
+		fmt.Fprintln(aErr, "crc16gen:", err)
+		return 2
+	}
+	for _, g := range vGens {
+		switch {
+		case *vNibble:
+			g.makeNibbles()
+		case *vSlicing > 1:
+			g.makeSlices(*vSlicing)
+		}
+	}
+
+	var vFiles []tFile
+	if *vTable {
+		var vB bytes.Buffer
+		err = writeGoTables(&vB, *vPkg, *vNibble, vGens)
+		vFiles = []tFile{{*vOut, vB.Bytes()}}
+	} else {
+		vFiles, err = generate(*vLang, *vPkg, *vOut, *vWidth, vGens)
+	}
+	if err != nil {
+		fmt.Fprintln(aErr, "crc16gen:", err)
+		return 2
+	}
+	for _, f := range vFiles {
+		if f.name == "" {
+			_, err = aOut.Write(f.content)
+		} else {
+			err = os.WriteFile(f.name, f.content, 0o644)
+		}
+		if err != nil {
+			fmt.Fprintln(aErr, "crc16gen:", err)
+			return 1
+		}
+	}
+	return 0
+}
+
+//--------------------------------------
+
+// Runs the command in -sync mode and returns the exit code.
+func runSync(aFile string, aOut, aErr io.Writer) int {
+	vFile, err := os.Open(aFile)
+	if err != nil {
+		fmt.Fprintln(aErr, "crc16gen:", err)
+		return 2
+	}
+	defer vFile.Close()
+	vInSync, err := syncCatalogue(vFile, aOut)
+	switch {
+	case err != nil:
+		fmt.Fprintf(aErr, "crc16gen: %s: %v\n", aFile, err)
+		return 2
+	case !vInSync:
+		return 1
+	}
+	return 0
+}
+
+//-----------------------------------------------------------------------------
+
+// Returns the files generated in the language, named after aOut; an empty name stands for standard output.
+// The HDL equations process aWidth bits of data at a time.
+func generate(aLang, aPkg, aOut string, aWidth int, aGens []*tAlgoGen) ([]tFile, error) {
+	var vB bytes.Buffer
+	var err error
+	switch aLang {
+	case "go":
+		err = writeGo(&vB, aPkg, aGens)
+	case "c":
+		if aOut == "" {
+			return nil, fmt.Errorf("-o must name the C source file")
+		}
+		vHeader := strings.TrimSuffix(aOut, filepath.Ext(aOut)) + ".h"
+		if err := writeCHeader(&vB, filepath.Base(vHeader), aGens); err != nil {
+			return nil, err
+		}
+		var vSrc bytes.Buffer
+		if err := writeCSource(&vSrc, filepath.Base(vHeader), aGens); err != nil {
+			return nil, err
+		}
+		return []tFile{{vHeader, vB.Bytes()}, {aOut, vSrc.Bytes()}}, nil
+	case "rust":
+		err = writeRust(&vB, aGens)
+	case "python":
+		err = writePython(&vB, aGens)
+	case "csharp":
+		if aPkg == "main" {
+			aPkg = "Crc16"
+		}
+		err = writeCSharp(&vB, aPkg, aGens)
+	case "verilog":
+		err = writeVerilog(&vB, aWidth, aGens)
+	case "vhdl":
+		if aPkg == "main" {
+			aPkg = "crc16_pkg"
+		}
+		err = writeVHDL(&vB, aPkg, aWidth, aGens)
+	default:
+		return nil, fmt.Errorf("unknown language %q", aLang)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []tFile{{aOut, vB.Bytes()}}, nil
+}
+
+//--------------------------------------
+
+// Returns an error if the table options are invalid or not supported for the language.
+func checkTables(aLang string, aSlicing int, aNibble, aTable bool) error {
+	switch {
+	case aTable && aLang != "go":
+		return fmt.Errorf("-table is not supported for %s", aLang)
+	case aTable && aSlicing > 1:
+		return fmt.Errorf("-table and -slicing are mutually exclusive")
+	case aSlicing != 1 && aSlicing != 4 && aSlicing != 8:
+		return fmt.Errorf("invalid -slicing %d: must be 1, 4 or 8", aSlicing)
+	case aSlicing > 1 && aNibble:
+		return fmt.Errorf("-slicing and -nibble are mutually exclusive")
+	case aSlicing > 1 && aLang != "go" && aLang != "c":
+		return fmt.Errorf("-slicing is not supported for %s", aLang)
+	case aNibble && aLang != "go" && aLang != "c" && aLang != "rust":
+		return fmt.Errorf("-nibble is not supported for %s", aLang)
+	}
+	return nil
+}
+
+//--------------------------------------
+
+// Returns the predefined algorithms listed in aNames, prepared for code generation.
+func selectAlgos(aNames string) ([]*tAlgoGen, error) {
+	if strings.TrimSpace(aNames) == "" {
+		return nil, fmt.Errorf("no algorithm specified")
+	}
+	var vRet []*tAlgoGen
+	vSeen := map[string]bool{}
+next:
+	for _, vName := range strings.Split(aNames, ",") {
+		vName = strings.TrimSpace(vName)
+		for _, a := range crc16.Algorithms() {
+			if strings.EqualFold(a.Name, vName) || strings.EqualFold(strings.TrimPrefix(a.Name, "CRC-16/"), vName) {
+				if !vSeen[a.Name] {
+					vSeen[a.Name] = true
+					vRet = append(vRet, newAlgoGen(a))
+				}
+				continue next
+			}
+		}
+		return nil, fmt.Errorf("unknown algorithm %q", vName)
+	}
+	return vRet, nil
+}
+
+//-----------------------------------------------------------------------------
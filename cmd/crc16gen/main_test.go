@@ -0,0 +1,440 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || (crc16_ccitt && crc16_ibm))
+
+package main
+
+import (
+	"fmt"
+	"math/bits"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mbsulliv/crc16"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+// Builds and runs the files as a Go program and returns its output.
+// A go.mod is added unless given. The test is skipped when the go tool is not available.
+func goRun(aT *testing.T, aFiles map[string]string) string {
+	vGo, err := exec.LookPath("go")
+	if err != nil || testing.Short() {
+		aT.Skip("go tool not available")
+	}
+	vDir := aT.TempDir()
+	if _, vFound := aFiles["go.mod"]; !vFound {
+		aFiles["go.mod"] = "module gentest\n\ngo 1.21\n"
+	}
+	for n, c := range aFiles {
+		if err := os.WriteFile(filepath.Join(vDir, n), []byte(c), 0o644); err != nil {
+			panic(err)
+		}
+	}
+	vCmd := exec.Command(vGo, "run", ".")
+	vCmd.Dir = vDir
+	vCmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	vOut, err := vCmd.CombinedOutput()
+	So(err, ShouldBeNil)
+	return string(vOut)
+}
+
+//--------------------------------------
+
+// Returns the names of all predefined algorithms separated by commas.
+func allAlgos() string {
+	var vNames []string
+	for _, a := range crc16.Algorithms() {
+		vNames = append(vNames, a.Name)
+	}
+	return strings.Join(vNames, ",")
+}
+
+//--------------------------------------
+
+// Returns the check values of all predefined algorithms in hexadecimal, one per line.
+func allChecks() string {
+	var vB strings.Builder
+	for _, a := range crc16.Algorithms() {
+		fmt.Fprintf(&vB, "%04x\n", a.Check)
+	}
+	return vB.String()
+}
+
+//--------------------------------------
+
+// Compiles the C sources in dir with the system compiler, runs the program and returns its output.
+// The test is skipped when no compiler is available.
+func ccRun(aT *testing.T, aDir string, aSources ...string) string {
+	vCC, err := exec.LookPath("cc")
+	if err != nil || testing.Short() {
+		aT.Skip("C compiler not available")
+	}
+	vExe := filepath.Join(aDir, "prog")
+	vCmd := exec.Command(vCC, append([]string{"-std=c99", "-Wall", "-Werror", "-o", vExe}, aSources...)...)
+	vCmd.Dir = aDir
+	vOut, err := vCmd.CombinedOutput()
+	So(string(vOut), ShouldBeEmpty)
+	So(err, ShouldBeNil)
+	vOut, err = exec.Command(vExe).Output()
+	So(err, ShouldBeNil)
+	return string(vOut)
+}
+
+//-----------------------------------------------------------------------------
+
+func TestGo(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vCode, vSrc, _ := runGen("-a", "modbus,xmodem,CRC-16/KERMIT,spi-fujitsu", "-pkg", "main")
+		So(vCode, ShouldEqual, 0)
+		So(vSrc, ShouldStartWith, "// Code generated by crc16gen; DO NOT EDIT.\n\npackage main\n")
+		So(vSrc, ShouldContainSubstring, "func ChecksumModbus(data []byte) uint16 {")
+		So(vSrc, ShouldNotContainSubstring, "math/bits")
+
+		vCode, _, _ = runGen("-a", "nope")
+		So(vCode, ShouldEqual, 2)
+		vCode, _, _ = runGen()
+		So(vCode, ShouldEqual, 2)
+
+		var vCalls []string
+		for _, a := range crc16.Algorithms() {
+			vCalls = append(vCalls, fmt.Sprintf("\tfmt.Printf(\"%%04x\\n\", Checksum%s(data))\n", algoIdent(a.Name)))
+		}
+		vCode, vSrc, _ = runGen("-a", allAlgos(), "-pkg", "main")
+		So(vCode, ShouldEqual, 0)
+		vOut := goRun(aT, map[string]string{
+			"crc_gen.go": vSrc,
+			"main.go":    "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tdata := []byte(\"123456789\")\n" + strings.Join(vCalls, "") + "}\n",
+		})
+		So(vOut, ShouldEqual, allChecks())
+	})
+}
+
+//--------------------------------------
+
+func TestC(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vDir := aT.TempDir()
+		vCode, _, _ := runGen("-a", allAlgos(), "-lang", "c", "-o", filepath.Join(vDir, "crc16_gen.c"))
+		So(vCode, ShouldEqual, 0)
+		vHeader, err := os.ReadFile(filepath.Join(vDir, "crc16_gen.h"))
+		So(err, ShouldBeNil)
+		So(string(vHeader), ShouldContainSubstring, "#ifndef CRC16_GEN_H")
+		So(string(vHeader), ShouldContainSubstring, "uint16_t crc16_modbus(const void *data, size_t len);")
+
+		vCode, _, _ = runGen("-a", "modbus", "-lang", "c")
+		So(vCode, ShouldEqual, 2)
+		vCode, _, _ = runGen("-a", "modbus", "-lang", "cobol")
+		So(vCode, ShouldEqual, 2)
+
+		// The checks, computed in one go and split across updates.
+		var vMain strings.Builder
+		vMain.WriteString("#include <stdio.h>\n#include \"crc16_gen.h\"\n\nint main(void)\n{\n    const char *data = \"123456789\";\n")
+		for _, a := range crc16.Algorithms() {
+			s := algoSnake(a.Name)
+			fmt.Fprintf(&vMain, "    if (%s(data, 9) != %s_final(%s_update(%s_update(%s_INIT, data, 4), data + 4, 5)))\n        return 1;\n",
+				s, s, s, s, strings.ToUpper(s))
+			fmt.Fprintf(&vMain, "    printf(\"%%04x\\n\", %s(data, 9));\n", s)
+		}
+		vMain.WriteString("    return 0;\n}\n")
+		So(os.WriteFile(filepath.Join(vDir, "main.c"), []byte(vMain.String()), 0o644), ShouldBeNil)
+		So(ccRun(aT, vDir, "main.c", "crc16_gen.c"), ShouldEqual, allChecks())
+	})
+}
+
+//--------------------------------------
+
+func TestRust(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vCode, vSrc, _ := runGen("-a", allAlgos(), "-lang", "rust")
+		So(vCode, ShouldEqual, 0)
+		So(vSrc, ShouldContainSubstring, "pub fn crc16_modbus(data: &[u8]) -> u16 {")
+
+		vRustc, err := exec.LookPath("rustc")
+		if err != nil || testing.Short() {
+			aT.Skip("rustc not available")
+		}
+		var vMain strings.Builder
+		vMain.WriteString(vSrc + "\nfn main() {\n    let data = b\"123456789\";\n")
+		for _, a := range crc16.Algorithms() {
+			fmt.Fprintf(&vMain, "    println!(\"{:04x}\", %s(data));\n", algoSnake(a.Name))
+		}
+		vMain.WriteString("}\n")
+		vDir := aT.TempDir()
+		So(os.WriteFile(filepath.Join(vDir, "main.rs"), []byte(vMain.String()), 0o644), ShouldBeNil)
+		vOut, err := exec.Command(vRustc, "-o", filepath.Join(vDir, "prog"), filepath.Join(vDir, "main.rs")).CombinedOutput()
+		So(string(vOut), ShouldBeEmpty)
+		So(err, ShouldBeNil)
+		vOut, err = exec.Command(filepath.Join(vDir, "prog")).Output()
+		So(err, ShouldBeNil)
+		So(string(vOut), ShouldEqual, allChecks())
+	})
+}
+
+//--------------------------------------
+
+func TestPython(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vCode, vSrc, _ := runGen("-a", allAlgos(), "-lang", "python")
+		So(vCode, ShouldEqual, 0)
+		So(vSrc, ShouldContainSubstring, "def crc16_modbus(data: bytes) -> int:")
+
+		vPython, err := exec.LookPath("python3")
+		if err != nil || testing.Short() {
+			aT.Skip("python3 not available")
+		}
+		var vMain strings.Builder
+		vMain.WriteString(vSrc + "\n\ndata = b\"123456789\"\n")
+		for _, a := range crc16.Algorithms() {
+			fmt.Fprintf(&vMain, "print(\"%%04x\" %% %s(data))\n", algoSnake(a.Name))
+		}
+		vOut, err := exec.Command(vPython, "-c", vMain.String()).Output()
+		So(err, ShouldBeNil)
+		So(string(vOut), ShouldEqual, allChecks())
+	})
+}
+
+//--------------------------------------
+
+func TestCSharp(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vCode, vSrc, _ := runGen("-a", "modbus,xmodem", "-lang", "csharp")
+		So(vCode, ShouldEqual, 0)
+		So(vSrc, ShouldStartWith, "// Code generated by crc16gen; DO NOT EDIT.\n\nnamespace Crc16\n{\n    public static class Crc16\n")
+		So(vSrc, ShouldContainSubstring, "public static ushort Modbus(byte[] data)")
+		So(vSrc, ShouldContainSubstring, "crc = (ushort)(crc >> 8 ^ ModbusTable[(crc ^ b) & 0xff]);")
+		So(vSrc, ShouldContainSubstring, "crc = (ushort)(crc << 8 ^ XmodemTable[(crc >> 8 ^ b) & 0xff]);")
+		So(strings.Count(vSrc, "{"), ShouldEqual, strings.Count(vSrc, "}"))
+
+		vCode, vSrc, _ = runGen("-a", "modbus", "-lang", "csharp", "-pkg", "Acme.Devices")
+		So(vCode, ShouldEqual, 0)
+		So(vSrc, ShouldContainSubstring, "namespace Acme.Devices\n")
+	})
+}
+
+//--------------------------------------
+
+func TestSlicing(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vCode, vSrc, _ := runGen("-a", "modbus,xmodem", "-slicing", "8", "-pkg", "main")
+		So(vCode, ShouldEqual, 0)
+		So(vSrc, ShouldContainSubstring, "var tableModbus = [8][256]uint16{")
+		So(vSrc, ShouldContainSubstring, "for len(data) >= 8 {")
+
+		vCode, _, _ = runGen("-a", "modbus", "-slicing", "3")
+		So(vCode, ShouldEqual, 2)
+		vCode, _, _ = runGen("-a", "modbus", "-slicing", "4", "-lang", "rust")
+		So(vCode, ShouldEqual, 2)
+
+		for _, n := range []string{"4", "8"} {
+			var vCalls []string
+			for _, a := range crc16.Algorithms() {
+				vCalls = append(vCalls, fmt.Sprintf("\tfmt.Printf(\"%%04x\\n\", Checksum%s(data))\n", algoIdent(a.Name)))
+			}
+			vCode, vSrc, _ = runGen("-a", allAlgos(), "-slicing", n, "-pkg", "main")
+			So(vCode, ShouldEqual, 0)
+			vOut := goRun(aT, map[string]string{
+				"crc_gen.go": vSrc,
+				"main.go":    "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tdata := []byte(\"123456789\")\n" + strings.Join(vCalls, "") + "}\n",
+			})
+			So(vOut, ShouldEqual, allChecks())
+
+			vDir := aT.TempDir()
+			vCode, _, _ = runGen("-a", allAlgos(), "-slicing", n, "-lang", "c", "-o", filepath.Join(vDir, "crc16_gen.c"))
+			So(vCode, ShouldEqual, 0)
+			var vMain strings.Builder
+			vMain.WriteString("#include <stdio.h>\n#include \"crc16_gen.h\"\n\nint main(void)\n{\n    const char *data = \"123456789\";\n")
+			for _, a := range crc16.Algorithms() {
+				s := algoSnake(a.Name)
+				fmt.Fprintf(&vMain, "    if (%s(data, 9) != %s_final(%s_update(%s_update(%s_INIT, data, 3), data + 3, 6)))\n        return 1;\n",
+					s, s, s, s, strings.ToUpper(s))
+				fmt.Fprintf(&vMain, "    printf(\"%%04x\\n\", %s(data, 9));\n", s)
+			}
+			vMain.WriteString("    return 0;\n}\n")
+			So(os.WriteFile(filepath.Join(vDir, "main.c"), []byte(vMain.String()), 0o644), ShouldBeNil)
+			So(ccRun(aT, vDir, "main.c", "crc16_gen.c"), ShouldEqual, allChecks())
+		}
+	})
+}
+
+//--------------------------------------
+
+func TestNibble(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vCode, vSrc, _ := runGen("-a", "modbus", "-nibble", "-pkg", "main")
+		So(vCode, ShouldEqual, 0)
+		So(vSrc, ShouldContainSubstring, "var tableModbus = [16]uint16{\n\t0x0000, 0xcc01, 0xd801, 0x1400,")
+
+		vCode, _, _ = runGen("-a", "modbus", "-nibble", "-slicing", "4")
+		So(vCode, ShouldEqual, 2)
+		vCode, _, _ = runGen("-a", "modbus", "-nibble", "-lang", "python")
+		So(vCode, ShouldEqual, 2)
+
+		var vCalls []string
+		for _, a := range crc16.Algorithms() {
+			vCalls = append(vCalls, fmt.Sprintf("\tfmt.Printf(\"%%04x\\n\", Checksum%s(data))\n", algoIdent(a.Name)))
+		}
+		vCode, vSrc, _ = runGen("-a", allAlgos(), "-nibble", "-pkg", "main")
+		So(vCode, ShouldEqual, 0)
+		vOut := goRun(aT, map[string]string{
+			"crc_gen.go": vSrc,
+			"main.go":    "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tdata := []byte(\"123456789\")\n" + strings.Join(vCalls, "") + "}\n",
+		})
+		So(vOut, ShouldEqual, allChecks())
+
+		vDir := aT.TempDir()
+		vCode, _, _ = runGen("-a", allAlgos(), "-nibble", "-lang", "c", "-o", filepath.Join(vDir, "crc16_gen.c"))
+		So(vCode, ShouldEqual, 0)
+		var vMain strings.Builder
+		vMain.WriteString("#include <stdio.h>\n#include \"crc16_gen.h\"\n\nint main(void)\n{\n")
+		for _, a := range crc16.Algorithms() {
+			fmt.Fprintf(&vMain, "    printf(\"%%04x\\n\", %s(\"123456789\", 9));\n", algoSnake(a.Name))
+		}
+		vMain.WriteString("    return 0;\n}\n")
+		So(os.WriteFile(filepath.Join(vDir, "main.c"), []byte(vMain.String()), 0o644), ShouldBeNil)
+		So(ccRun(aT, vDir, "main.c", "crc16_gen.c"), ShouldEqual, allChecks())
+
+		vCode, vSrc, _ = runGen("-a", allAlgos(), "-nibble", "-lang", "rust")
+		So(vCode, ShouldEqual, 0)
+		vRustc, err := exec.LookPath("rustc")
+		if err != nil || testing.Short() {
+			aT.Skip("rustc not available")
+		}
+		var vRust strings.Builder
+		vRust.WriteString(vSrc + "\nfn main() {\n    let data = b\"123456789\";\n")
+		for _, a := range crc16.Algorithms() {
+			fmt.Fprintf(&vRust, "    println!(\"{:04x}\", %s(data));\n", algoSnake(a.Name))
+		}
+		vRust.WriteString("}\n")
+		So(os.WriteFile(filepath.Join(vDir, "main.rs"), []byte(vRust.String()), 0o644), ShouldBeNil)
+		vBytes, err := exec.Command(vRustc, "-o", filepath.Join(vDir, "prog"), filepath.Join(vDir, "main.rs")).CombinedOutput()
+		So(string(vBytes), ShouldBeEmpty)
+		So(err, ShouldBeNil)
+		vBytes, err = exec.Command(filepath.Join(vDir, "prog")).Output()
+		So(err, ShouldBeNil)
+		So(string(vBytes), ShouldEqual, allChecks())
+	})
+}
+
+//--------------------------------------
+
+func TestTable(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vCode, vSrc, _ := runGen("-a", "modbus,xmodem", "-table", "-pkg", "main")
+		So(vCode, ShouldEqual, 0)
+		So(vSrc, ShouldStartWith, "// Code generated by crc16gen; DO NOT EDIT.\n\n//go:build !crc16_nibble\n\npackage main\n")
+		So(vSrc, ShouldContainSubstring, "var ModbusTable = crc16.MakeTablePrecomputed(")
+		So(vSrc, ShouldContainSubstring, "tableModbus[:], &tableModbusReflected)")
+		So(vSrc, ShouldContainSubstring, "tableXmodem[:], nil)")
+		So(vSrc, ShouldNotContainSubstring, "func Checksum")
+
+		vCode, vSrc, _ = runGen("-a", "xmodem", "-table", "-nibble", "-pkg", "main")
+		So(vCode, ShouldEqual, 0)
+		So(vSrc, ShouldContainSubstring, "//go:build crc16_nibble\n")
+		So(vSrc, ShouldContainSubstring, "var tableXmodem = [16]uint16{\n\t0x0000, 0x1021, 0x2042,")
+
+		vCode, _, _ = runGen("-a", "modbus", "-table", "-slicing", "8")
+		So(vCode, ShouldEqual, 2)
+		vCode, _, _ = runGen("-a", "modbus", "-table", "-lang", "c", "-o", "crc.c")
+		So(vCode, ShouldEqual, 2)
+
+		var vCalls []string
+		for _, a := range crc16.Algorithms() {
+			vCalls = append(vCalls, fmt.Sprintf("\tfmt.Printf(\"%%04x %%v\\n\", crc16.Checksum(data, %sTable), %sTable.Validate())\n", algoIdent(a.Name), algoIdent(a.Name)))
+		}
+		vCode, vSrc, _ = runGen("-a", allAlgos(), "-table", "-pkg", "main")
+		So(vCode, ShouldEqual, 0)
+		vModule, err := filepath.Abs("../..")
+		So(err, ShouldBeNil)
+		vOut := goRun(aT, map[string]string{
+			"go.mod":     "module gentest\n\ngo 1.23\n\nrequire github.com/mbsulliv/crc16 v0.0.0\n\nreplace github.com/mbsulliv/crc16 => " + vModule + "\n",
+			"crc_gen.go": vSrc,
+			"main.go":    "package main\n\nimport (\n\t\"fmt\"\n\n\t\"github.com/mbsulliv/crc16\"\n)\n\nfunc main() {\n\tdata := []byte(\"123456789\")\n" + strings.Join(vCalls, "") + "}\n",
+		})
+		So(vOut, ShouldEqual, strings.ReplaceAll(allChecks(), "\n", " <nil>\n"))
+	})
+}
+
+//--------------------------------------
+
+func TestHDL(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vData := []byte("12345678")
+		for _, a := range crc16.Algorithms() {
+			g := newAlgoGen(a)
+			for _, vWidth := range []int{8, 16, 32, 64} {
+				vE := g.equations(vWidth)
+				c := a.Init
+				for i := 0; i < len(vData); i += vWidth / 8 {
+					var d uint64
+					for _, b := range vData[i : i+vWidth/8] {
+						d = d<<8 | uint64(b)
+					}
+					c = vE.next(c, d)
+				}
+				if a.RefOut {
+					c = bits.Reverse16(c)
+				}
+				So(c^a.XorOut, ShouldEqual, crc16.Checksum(vData, crc16.MakeTable(a)))
+			}
+		}
+
+		vCode, vSrc, _ := runGen("-a", "modbus", "-lang", "verilog", "-width", "32")
+		So(vCode, ShouldEqual, 0)
+		So(vSrc, ShouldContainSubstring, "localparam [15:0] CRC16_MODBUS_INIT = 16'hffff;")
+		So(vSrc, ShouldContainSubstring, "function [15:0] crc16_modbus_next;\n    input [15:0] c;\n    input [31:0] d;\n")
+		So(vSrc, ShouldContainSubstring, "crc16_modbus_final = {c[0], c[1], c[2],")
+		So(strings.Count(vSrc, "crc16_modbus_next["), ShouldEqual, 16)
+
+		vCode, vSrc, _ = runGen("-a", "xmodem,modbus", "-lang", "vhdl")
+		So(vCode, ShouldEqual, 0)
+		So(vSrc, ShouldContainSubstring, "package crc16_pkg is\n")
+		So(vSrc, ShouldContainSubstring, "constant CRC16_XMODEM_INIT : std_logic_vector(15 downto 0) := x\"0000\";")
+		So(vSrc, ShouldContainSubstring, "n(9) := c(1) xor c(15) xor d(0);")
+		So(vSrc, ShouldEndWith, "end package body crc16_pkg;\n")
+
+		vCode, _, _ = runGen("-a", "modbus", "-lang", "vhdl", "-width", "12")
+		So(vCode, ShouldEqual, 2)
+	})
+}
+
+//--------------------------------------
+
+func TestSync(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vNew := crc16.TAlgo{Poly: 0x1021, Init: 0x1D0F, Name: "CRC-16/ACME"}
+		vNew.Check = crc16.ChecksumBitwise([]byte("123456789"), vNew)
+		vChanged := crc16.CRC16_UMTS
+		vChanged.Init = 0xFFFF
+		vChanged.Check = crc16.ChecksumBitwise([]byte("123456789"), vChanged)
+		vCatalogue := "CRC-16 catalogue\n\n" + crc16.CRC16_ARC.String() + "\nAlias: CRC-16/LHA\n" +
+			crc16.CRC16_IBM_3740.String() + "\nwidth=32 poly=0x04c11db7 name=\"CRC-32\"\n"
+		vFile := filepath.Join(aT.TempDir(), "16.txt")
+		os.WriteFile(vFile, []byte(vCatalogue), 0o644)
+		vCode, vOut, _ := runGen("-sync", vFile)
+		So(vCode, ShouldEqual, 0)
+		So(vOut, ShouldBeEmpty)
+
+		os.WriteFile(vFile, []byte(vCatalogue+vNew.String()+"\n"+vChanged.String()+"\n"), 0o644)
+		vCode, vOut, _ = runGen("-sync", vFile)
+		So(vCode, ShouldEqual, 1)
+		So(vOut, ShouldEqual, "// Missing from catalogue_ccitt.go:\n"+
+			"\tCRC16_ACME = TAlgo{0x1021, 0x1D0F, false, false, 0x0000, 0xE5CC, \"CRC-16/ACME\", TrailerBigEndian, 16}\n"+
+			"// Different in catalogue_ibm.go:\n"+
+			"\tCRC16_UMTS = TAlgo{0x8005, 0xFFFF, false, false, 0x0000, 0xAEE7, \"CRC-16/UMTS\", TrailerBigEndian, 16}\n")
+
+		os.WriteFile(vFile, []byte("width=16 poly=0x1021 check=0x1234 name=\"CRC-16/BAD\"\n"), 0o644)
+		vCode, _, vErr := runGen("-sync", vFile)
+		So(vCode, ShouldEqual, 2)
+		So(vErr, ShouldContainSubstring, "line 1")
+		vCode, _, _ = runGen("-sync", filepath.Join(aT.TempDir(), "missing"))
+		So(vCode, ShouldEqual, 2)
+	})
+}
+
+//-----------------------------------------------------------------------------
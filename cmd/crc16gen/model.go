@@ -0,0 +1,201 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+	"unicode"
+
+	"github.com/mbsulliv/crc16"
+)
+
+//-----------------------------------------------------------------------------
+
+// tAlgoGen is an algorithm prepared for code generation.
+//
+// Algorithms reflecting both input and output are generated with a reflected table
+// shifting the register right, so no bit reversal is needed at runtime; all others
+// with a table shifting the register left. With slicing, slices holds a table per byte
+// processed in a step, the first being table. In nibble mode, nibbles holds the 16-entry
+// table processing four bits per step instead.
+type tAlgoGen struct {
+	algo      crc16.TAlgo
+	ident     string
+	snake     string
+	reflected bool
+	init      uint16
+	table     [256]uint16
+	slices    [][256]uint16
+	nibbles   []uint16
+}
+
+//-----------------------------------------------------------------------------
+
+// Returns the words of the algorithm name without the CRC-16 prefix.
+func nameWords(aName string) []string {
+	return strings.FieldsFunc(strings.TrimPrefix(aName, "CRC-16/"), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+//--------------------------------------
+
+// Returns the identifier derived from the algorithm name, e.g. Modbus for CRC-16/MODBUS
+// or Ibm3740 for CRC-16/IBM-3740.
+func algoIdent(aName string) string {
+	vWords := nameWords(aName)
+	for i, w := range vWords {
+		vWords[i] = strings.ToUpper(w[:1]) + strings.ToLower(w[1:])
+	}
+	vRet := strings.Join(vWords, "")
+	if vRet == "" || unicode.IsDigit(rune(vRet[0])) {
+		vRet = "Crc" + vRet
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Returns the snake case identifier derived from the algorithm name, e.g. crc16_modbus
+// for CRC-16/MODBUS or crc16_ibm_3740 for CRC-16/IBM-3740.
+func algoSnake(aName string) string {
+	return strings.ToLower(strings.Join(append([]string{"crc16"}, nameWords(aName)...), "_"))
+}
+
+//--------------------------------------
+
+// Returns the algorithm prepared for code generation.
+func newAlgoGen(aAlgo crc16.TAlgo) *tAlgoGen {
+	vG := &tAlgoGen{algo: aAlgo, ident: algoIdent(aAlgo.Name), snake: algoSnake(aAlgo.Name), reflected: aAlgo.RefIn && aAlgo.RefOut, init: aAlgo.Init}
+	if vG.reflected {
+		vG.init = bits.Reverse16(aAlgo.Init)
+		vPoly := bits.Reverse16(aAlgo.Poly)
+		for n := range vG.table {
+			crc := uint16(n)
+			for i := 0; i < 8; i++ {
+				if crc&1 != 0 {
+					crc = crc>>1 ^ vPoly
+				} else {
+					crc >>= 1
+				}
+			}
+			vG.table[n] = crc
+		}
+		return vG
+	}
+	for n := range vG.table {
+		crc := uint16(n) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ aAlgo.Poly
+			} else {
+				crc <<= 1
+			}
+		}
+		vG.table[n] = crc
+	}
+	return vG
+}
+
+//--------------------------------------
+
+// Computes the tables for slicing-by-n: slices[k][b] is the register after the byte b
+// followed by k zero bytes is shifted into a zero register.
+func (aG *tAlgoGen) makeSlices(n int) {
+	aG.slices = make([][256]uint16, n)
+	aG.slices[0] = aG.table
+	for k := 1; k < n; k++ {
+		for b, v := range aG.slices[k-1] {
+			if aG.reflected {
+				aG.slices[k][b] = v>>8 ^ aG.table[byte(v)]
+			} else {
+				aG.slices[k][b] = v<<8 ^ aG.table[v>>8]
+			}
+		}
+	}
+}
+
+//--------------------------------------
+
+// Computes the table processing four bits per step.
+func (aG *tAlgoGen) makeNibbles() {
+	aG.nibbles = make([]uint16, 16)
+	vPoly := aG.algo.Poly
+	if aG.reflected {
+		vPoly = bits.Reverse16(vPoly)
+	}
+	for n := range aG.nibbles {
+		crc := uint16(n)
+		if !aG.reflected {
+			crc <<= 12
+		}
+		for i := 0; i < 4; i++ {
+			switch {
+			case aG.reflected && crc&1 != 0:
+				crc = crc>>1 ^ vPoly
+			case aG.reflected:
+				crc >>= 1
+			case crc&0x8000 != 0:
+				crc = crc<<1 ^ vPoly
+			default:
+				crc <<= 1
+			}
+		}
+		aG.nibbles[n] = crc
+	}
+}
+
+//--------------------------------------
+
+// Returns the shift of the register byte combined with the byte at offset i of a slicing step,
+// or -1 beyond the two register bytes.
+func (aG *tAlgoGen) sliceShift(i int) int {
+	switch {
+	case i >= 2:
+		return -1
+	case aG.reflected:
+		return 8 * i
+	default:
+		return 8 - 8*i
+	}
+}
+
+//--------------------------------------
+
+// Returns the parameters of the algorithm in the notation of the CRC RevEng catalogue.
+func (aG *tAlgoGen) params() string {
+	a := &aG.algo
+	return fmt.Sprintf("poly=0x%04x init=0x%04x refin=%t refout=%t xorout=0x%04x check=0x%04x",
+		a.Poly, a.Init, a.RefIn, a.RefOut, a.XorOut, a.Check)
+}
+
+//--------------------------------------
+
+// Returns the table entries formatted as aPrefix and 4 hexadecimal digits, followed by a comma,
+// 8 per line, each line starting with aIndent. The comma is omitted after the last entry
+// if aLastComma is false.
+func formatEntries(aEntries []uint16, aIndent, aPrefix string, aLastComma bool) string {
+	var vB strings.Builder
+	for i, v := range aEntries {
+		switch {
+		case i%8 == 0:
+			vB.WriteString(aIndent)
+		default:
+			vB.WriteString(" ")
+		}
+		fmt.Fprintf(&vB, "%s%04x", aPrefix, v)
+		if i < len(aEntries)-1 || aLastComma {
+			vB.WriteString(",")
+		}
+		if i%8 == 7 || i == len(aEntries)-1 {
+			vB.WriteString("\n")
+		}
+	}
+	return vB.String()
+}
+
+//-----------------------------------------------------------------------------
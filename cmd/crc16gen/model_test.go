@@ -0,0 +1,25 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestAlgoIdent(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		So(algoIdent("CRC-16/MODBUS"), ShouldEqual, "Modbus")
+		So(algoIdent("CRC-16/IBM-3740"), ShouldEqual, "Ibm3740")
+		So(algoIdent("CRC-16/X-25"), ShouldEqual, "X25")
+		So(algoIdent("CRC-16/CCITT-FALSE"), ShouldEqual, "CcittFalse")
+		So(algoSnake("CRC-16/IBM-3740"), ShouldEqual, "crc16_ibm_3740")
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,48 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+//-----------------------------------------------------------------------------
+
+// Writes a Python module with a lookup table tuple and a checksum function for every algorithm.
+func writePython(w io.Writer, aGens []*tAlgoGen) error {
+	vW := bufio.NewWriter(w)
+	fmt.Fprintf(vW, "# Code generated by crc16gen; DO NOT EDIT.\n")
+	if needsReverse(aGens) {
+		fmt.Fprintf(vW, "\n\ndef _reverse(v, n):\n    return int(format(v, \"0%%db\" %% n)[::-1], 2)\n")
+	}
+	for _, g := range aGens {
+		vTable := strings.ToUpper(g.snake) + "_TABLE"
+		fmt.Fprintf(vW, "\n\n# Lookup table of %s\n# (%s).\n", g.algo.Name, g.params())
+		fmt.Fprintf(vW, "%s = (\n%s)\n", vTable, formatEntries(g.table[:], "    ", "0x", true))
+
+		fmt.Fprintf(vW, "\n\ndef %s(data: bytes) -> int:\n", g.snake)
+		fmt.Fprintf(vW, "    \"\"\"Returns the %s checksum of data.\"\"\"\n", g.algo.Name)
+		fmt.Fprintf(vW, "    crc = 0x%04x\n    for b in data:\n", g.init)
+		switch {
+		case g.reflected:
+			fmt.Fprintf(vW, "        crc = (crc >> 8) ^ %s[(crc ^ b) & 0xff]\n", vTable)
+		case g.algo.RefIn:
+			fmt.Fprintf(vW, "        crc = ((crc << 8) & 0xffff) ^ %s[((crc >> 8) ^ _reverse(b, 8)) & 0xff]\n", vTable)
+		default:
+			fmt.Fprintf(vW, "        crc = ((crc << 8) & 0xffff) ^ %s[((crc >> 8) ^ b) & 0xff]\n", vTable)
+		}
+		if !g.reflected && g.algo.RefOut {
+			fmt.Fprintf(vW, "    return _reverse(crc, 16) ^ 0x%04x\n", g.algo.XorOut)
+		} else {
+			fmt.Fprintf(vW, "    return crc ^ 0x%04x\n", g.algo.XorOut)
+		}
+	}
+	return vW.Flush()
+}
+
+//-----------------------------------------------------------------------------
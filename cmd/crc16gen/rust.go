@@ -0,0 +1,58 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+//-----------------------------------------------------------------------------
+
+// Writes a Rust module with a lookup table constant and a checksum function for every algorithm.
+func writeRust(w io.Writer, aGens []*tAlgoGen) error {
+	vW := bufio.NewWriter(w)
+	fmt.Fprintf(vW, "// Code generated by crc16gen; DO NOT EDIT.\n")
+	for _, g := range aGens {
+		vTable := strings.ToUpper(g.snake) + "_TABLE"
+		fmt.Fprintf(vW, "\n/// Lookup table of %s\n/// (%s).\n", g.algo.Name, g.params())
+		if g.nibbles != nil {
+			fmt.Fprintf(vW, "pub const %s: [u16; 16] = [\n%s];\n", vTable, formatEntries(g.nibbles, "    ", "0x", true))
+		} else {
+			fmt.Fprintf(vW, "pub const %s: [u16; 256] = [\n%s];\n", vTable, formatEntries(g.table[:], "    ", "0x", true))
+		}
+
+		fmt.Fprintf(vW, "\n/// Returns the %s checksum of data.\n", g.algo.Name)
+		fmt.Fprintf(vW, "pub fn %s(data: &[u8]) -> u16 {\n    let mut crc: u16 = 0x%04x;\n    for &b in data {\n", g.snake, g.init)
+		switch {
+		case g.nibbles != nil && g.reflected:
+			fmt.Fprintf(vW, "        crc = (crc >> 4) ^ %s[((crc ^ b as u16) & 0x0f) as usize];\n", vTable)
+			fmt.Fprintf(vW, "        crc = (crc >> 4) ^ %s[((crc ^ (b >> 4) as u16) & 0x0f) as usize];\n", vTable)
+		case g.nibbles != nil:
+			if g.algo.RefIn {
+				fmt.Fprintf(vW, "        let b = b.reverse_bits();\n")
+			}
+			fmt.Fprintf(vW, "        crc = (crc << 4) ^ %s[(((crc >> 12) ^ (b >> 4) as u16) & 0x0f) as usize];\n", vTable)
+			fmt.Fprintf(vW, "        crc = (crc << 4) ^ %s[(((crc >> 12) ^ (b & 0x0f) as u16) & 0x0f) as usize];\n", vTable)
+		case g.reflected:
+			fmt.Fprintf(vW, "        crc = (crc >> 8) ^ %s[((crc ^ b as u16) & 0xff) as usize];\n", vTable)
+		case g.algo.RefIn:
+			fmt.Fprintf(vW, "        crc = (crc << 8) ^ %s[(((crc >> 8) ^ b.reverse_bits() as u16) & 0xff) as usize];\n", vTable)
+		default:
+			fmt.Fprintf(vW, "        crc = (crc << 8) ^ %s[(((crc >> 8) ^ b as u16) & 0xff) as usize];\n", vTable)
+		}
+		fmt.Fprintf(vW, "    }\n")
+		if !g.reflected && g.algo.RefOut {
+			fmt.Fprintf(vW, "    crc.reverse_bits() ^ 0x%04x\n}\n", g.algo.XorOut)
+		} else {
+			fmt.Fprintf(vW, "    crc ^ 0x%04x\n}\n", g.algo.XorOut)
+		}
+	}
+	return vW.Flush()
+}
+
+//-----------------------------------------------------------------------------
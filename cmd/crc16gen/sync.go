@@ -0,0 +1,82 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mbsulliv/crc16"
+)
+
+//-----------------------------------------------------------------------------
+
+// Returns the file of package crc16 defining the family of the polynomial.
+func familyFile(aPoly uint16) string {
+	switch aPoly {
+	case 0x1021:
+		return "catalogue_ccitt.go"
+	case 0x8005:
+		return "catalogue_ibm.go"
+	default:
+		return "catalogue_misc.go"
+	}
+}
+
+//--------------------------------------
+
+// Returns the definition of the algorithm as in the catalogue files of package crc16.
+func algoDefinition(a *crc16.TAlgo) string {
+	vTrailer := "TrailerBigEndian"
+	if a.RefOut {
+		vTrailer = "TrailerLittleEndian"
+	}
+	return fmt.Sprintf("CRC16_%s = TAlgo{0x%04X, 0x%04X, %t, %t, 0x%04X, 0x%04X, %q, %s, 16}",
+		strings.ToUpper(strings.Join(nameWords(a.Name), "_")),
+		a.Poly, a.Init, a.RefIn, a.RefOut, a.XorOut, a.Check, a.Name, vTrailer)
+}
+
+//--------------------------------------
+
+// Compares the width=16 entries of a catalogue in the notation of the CRC RevEng catalogue
+// read from r with the predefined algorithms, writes the definitions of those missing or
+// different to w, and reports whether there were none. Entries named by an alias of
+// a predefined algorithm are compared with it.
+func syncCatalogue(r io.Reader, w io.Writer) (bool, error) {
+	vInSync := true
+	vScanner := bufio.NewScanner(r)
+	for vLine := 1; vScanner.Scan(); vLine++ {
+		vText := strings.TrimSpace(vScanner.Text())
+		if !strings.HasPrefix(vText, "width=16 ") {
+			continue
+		}
+		a, err := crc16.ParseAlgo(vText)
+		if err == nil && a.Name == "" {
+			err = fmt.Errorf("algorithm without a name")
+		}
+		if err == nil {
+			err = a.Validate()
+		}
+		if err != nil {
+			return false, fmt.Errorf("line %d: %w", vLine, err)
+		}
+		vFound, _ := crc16.AlgoByName(a.Name)
+		switch {
+		case vFound == nil:
+			fmt.Fprintf(w, "// Missing from %s:\n\t%s\n", familyFile(a.Poly), algoDefinition(&a))
+		case vFound.Poly != a.Poly || vFound.Init != a.Init || vFound.RefIn != a.RefIn ||
+			vFound.RefOut != a.RefOut || vFound.XorOut != a.XorOut || vFound.Check != a.Check:
+			fmt.Fprintf(w, "// Different in %s:\n\t%s\n", familyFile(vFound.Poly), algoDefinition(&a))
+		default:
+			continue
+		}
+		vInSync = false
+	}
+	return vInSync, vScanner.Err()
+}
+
+//-----------------------------------------------------------------------------
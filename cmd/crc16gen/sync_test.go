@@ -0,0 +1,31 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || (crc16_ccitt && crc16_ibm && crc16_misc))
+
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestSyncReveng(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		// The vendored snapshot of the CRC RevEng catalogue is in sync with the catalogue.
+		vFile, err := os.Open("../../catalogue/reveng.txt")
+		So(err, ShouldBeNil)
+		defer vFile.Close()
+		var vOut strings.Builder
+		vInSync, err := syncCatalogue(vFile, &vOut)
+		So(err, ShouldBeNil)
+		So(vOut.String(), ShouldBeEmpty)
+		So(vInSync, ShouldBeTrue)
+	})
+}
+
+//-----------------------------------------------------------------------------
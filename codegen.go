@@ -0,0 +1,83 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package crc16
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+//-----------------------------------------------------------------------------
+
+// This file contains the export of lookup tables as source code for embedding
+// into firmware and programs written in other languages.
+
+//-----------------------------------------------------------------------------
+
+// Returns the parameters of the table algorithm in the notation of the CRC RevEng catalogue.
+func (aTable *TTable) paramsComment() string {
+	a := &aTable.algo
+	return fmt.Sprintf("poly=0x%04x init=0x%04x refin=%t refout=%t xorout=0x%04x check=0x%04x",
+		a.Poly, a.Init, a.RefIn, a.RefOut, a.XorOut, a.Check)
+}
+
+//--------------------------------------
+
+// Returns the description of how the table is used to update the register with a byte b.
+func (aTable *TTable) updateComment() string {
+	if aTable.algo.RefIn {
+		return "crc = crc<<8 ^ table[crc>>8 ^ reverse8(b)]"
+	}
+	return "crc = crc<<8 ^ table[crc>>8 ^ b]"
+}
+
+//--------------------------------------
+
+// Writes the table entries, 8 per line, each line starting with aIndent.
+func (aTable *TTable) writeEntries(w *bufio.Writer, aIndent string) {
+	for i, v := range aTable.Entries() {
+		switch {
+		case i%8 == 0:
+			fmt.Fprintf(w, "%s0x%04x,", aIndent, v)
+		case i%8 == 7:
+			fmt.Fprintf(w, " 0x%04x,\n", v)
+		default:
+			fmt.Fprintf(w, " 0x%04x,", v)
+		}
+	}
+}
+
+//-----------------------------------------------------------------------------
+
+// WriteGoSource writes Go source declaring the lookup table as a [256]uint16 variable
+// named varName in package pkg, documented with the algorithm parameters.
+func (aTable *TTable) WriteGoSource(w io.Writer, pkg, varName string) error {
+	vW := bufio.NewWriter(w)
+	fmt.Fprintf(vW, "// Code generated by crc16; DO NOT EDIT.\n\npackage %s\n\n", pkg)
+	fmt.Fprintf(vW, "// %s is the lookup table of %s\n// (%s).\n", varName, aTable.algo.Name, aTable.paramsComment())
+	fmt.Fprintf(vW, "// Every byte b updates the register as %s.\n", aTable.updateComment())
+	fmt.Fprintf(vW, "var %s = [256]uint16{\n", varName)
+	aTable.writeEntries(vW, "\t")
+	fmt.Fprintf(vW, "}\n")
+	return vW.Flush()
+}
+
+//--------------------------------------
+
+// WriteCSource writes C source defining the lookup table as a static const uint16_t array
+// with the specified name, documented with the algorithm parameters.
+func (aTable *TTable) WriteCSource(w io.Writer, name string) error {
+	vW := bufio.NewWriter(w)
+	fmt.Fprintf(vW, "/* Code generated by crc16; DO NOT EDIT. */\n\n#include <stdint.h>\n\n")
+	fmt.Fprintf(vW, "/* Lookup table of %s\n * (%s).\n", aTable.algo.Name, aTable.paramsComment())
+	fmt.Fprintf(vW, " * Every byte b updates the register as %s.\n */\n", aTable.updateComment())
+	fmt.Fprintf(vW, "static const uint16_t %s[256] = {\n", name)
+	aTable.writeEntries(vW, "    ")
+	fmt.Fprintf(vW, "};\n")
+	return vW.Flush()
+}
+
+//-----------------------------------------------------------------------------
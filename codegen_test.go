@@ -0,0 +1,46 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || crc16_ccitt)
+
+package crc16
+
+import (
+	"bytes"
+	"go/format"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestWriteSource(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_XMODEM)
+
+		var vBuf bytes.Buffer
+		So(vTable.WriteGoSource(&vBuf, "tables", "xmodemTable"), ShouldBeNil)
+		vSrc, err := format.Source(vBuf.Bytes())
+		So(err, ShouldBeNil)
+		So(string(vSrc), ShouldEqual, vBuf.String())
+		So(vBuf.String(), ShouldContainSubstring, "package tables\n")
+		So(vBuf.String(), ShouldContainSubstring, "var xmodemTable = [256]uint16{\n\t0x0000, 0x1021,")
+		So(strings.Count(vBuf.String(), "0x"), ShouldEqual, 256+4)
+
+		vBuf.Reset()
+		So(vTable.WriteCSource(&vBuf, "xmodem_table"), ShouldBeNil)
+		So(vBuf.String(), ShouldContainSubstring, "static const uint16_t xmodem_table[256] = {\n    0x0000, 0x1021,")
+		So(vBuf.String(), ShouldEndWith, "0x1ef0,\n};\n")
+		So(vBuf.String(), ShouldContainSubstring, "CRC-16/XMODEM")
+
+		// Reflected algorithms document the reversal of the input bytes.
+		vBuf.Reset()
+		So(MakeTable(CRC16_KERMIT).WriteCSource(&vBuf, "kermit_table"), ShouldBeNil)
+		So(vBuf.String(), ShouldContainSubstring, "refin=true refout=true")
+		So(vBuf.String(), ShouldContainSubstring, "table[crc>>8 ^ reverse8(b)]")
+		So(vBuf.String(), ShouldContainSubstring, "kermit_table[256] = {\n    0x0000, 0x1021,")
+	})
+}
+
+//-----------------------------------------------------------------------------
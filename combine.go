@@ -0,0 +1,174 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import "math/bits"
+
+//-----------------------------------------------------------------------------
+
+// This file contains the shift operator algebra over the CRC register.
+//
+// Feeding zero bytes into the register is a linear operation over GF(2), so it is
+// represented by a 16x16 bit matrix and long runs of zeros can be applied in
+// logarithmic time by repeated squaring, as in zlib's crc32_combine.
+
+// tMatrix is a linear operator on the CRC register; column i is the image of bit i.
+type tMatrix [16]uint16
+
+// tAffine is the operator r -> m*r ^ c; feeding any fixed data into the register is affine.
+type tAffine struct {
+	m tMatrix
+	c uint16
+}
+
+//-----------------------------------------------------------------------------
+
+// Returns the identity operator.
+func identityMatrix() tMatrix {
+	var vM tMatrix
+	for i := range vM {
+		vM[i] = 1 << i
+	}
+	return vM
+}
+
+//--------------------------------------
+
+// Returns the image of the register value v.
+func (aM *tMatrix) apply(v uint16) uint16 {
+	var vRet uint16
+	for i := 0; v != 0; i, v = i+1, v>>1 {
+		if v&1 != 0 {
+			vRet ^= aM[i]
+		}
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Returns a register value v with image y, or false if there is none.
+func (aM *tMatrix) solve(y uint16) (uint16, bool) {
+	// vBasis[b] is a combination of columns with highest bit b, vMask[b] selects its columns.
+	var vBasis, vMask [16]uint16
+	for i, c := range aM {
+		vM := uint16(1) << i
+		for c != 0 {
+			b := bits.Len16(c) - 1
+			if vBasis[b] == 0 {
+				vBasis[b], vMask[b] = c, vM
+				break
+			}
+			c, vM = c^vBasis[b], vM^vMask[b]
+		}
+	}
+	var vRet uint16
+	for y != 0 {
+		b := bits.Len16(y) - 1
+		if vBasis[b] == 0 {
+			return 0, false
+		}
+		y, vRet = y^vBasis[b], vRet^vMask[b]
+	}
+	return vRet, true
+}
+
+//--------------------------------------
+
+// Returns the operator applying b first and then aM.
+func (aM *tMatrix) mul(b *tMatrix) tMatrix {
+	var vRet tMatrix
+	for i := range vRet {
+		vRet[i] = aM.apply(b[i])
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Returns the operator applying b first and then aA.
+func (aA *tAffine) mul(b *tAffine) tAffine {
+	return tAffine{m: aA.m.mul(&b.m), c: aA.m.apply(b.c) ^ aA.c}
+}
+
+//--------------------------------------
+
+// Returns the operator applying aA n times.
+func (aA tAffine) pow(n int64) tAffine {
+	vRet := tAffine{m: identityMatrix()}
+	for ; n > 0; n >>= 1 {
+		if n&1 != 0 {
+			vRet = aA.mul(&vRet)
+		}
+		aA = aA.mul(&aA)
+	}
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
+
+// Returns the operator feeding a single zero byte into the register.
+func zeroByteMatrix(aTable *TTable) tMatrix {
+	var vM tMatrix
+	vZero := []byte{0}
+	for i := range vM {
+		vM[i] = Update(1<<i, vZero, aTable)
+	}
+	return vM
+}
+
+//--------------------------------------
+
+// Returns the operator feeding n zero bytes into the register.
+func zerosMatrix(n int64, aTable *TTable) tMatrix {
+	return tAffine{m: zeroByteMatrix(aTable)}.pow(n).m
+}
+
+//--------------------------------------
+
+// Returns the register the checksum was completed from; it undoes Complete.
+func register(aSum uint16, aTable *TTable) uint16 {
+	aSum ^= aTable.algo.XorOut
+	if aTable.algo.RefOut {
+		return bits.Reverse16(aSum)
+	}
+	return aSum
+}
+
+//-----------------------------------------------------------------------------
+
+// Combine returns CRC checksum of the concatenation of two messages, given the checksum
+// aSum1 of the first one and the checksum aSum2 of the second one of aLen2 bytes,
+// so that a message assembled from separately checksummed chunks needs no rereading.
+// It runs in O(log(aLen2)) time.
+func Combine(aSum1, aSum2 uint16, aLen2 int64, aTable *TTable) uint16 {
+	vZeros := zerosMatrix(aLen2, aTable)
+	vReg := vZeros.apply(register(aSum1, aTable)^Init(aTable)) ^ register(aSum2, aTable)
+	return Complete(vReg, aTable)
+}
+
+//--------------------------------------
+
+// ExtendZeros returns CRC checksum of a message followed by n zero bytes, given
+// the checksum aSum of the message, e.g. for sparse files or padding never materialized.
+// It runs in O(log(n)) time.
+func ExtendZeros(aSum uint16, n int64, aTable *TTable) uint16 {
+	vZeros := zerosMatrix(n, aTable)
+	return Complete(vZeros.apply(register(aSum, aTable)), aTable)
+}
+
+//--------------------------------------
+
+// ChecksumRepeat returns CRC checksum of pattern repeated count times
+// using the specified algorithm represented by the TTable.
+// It runs in O(len(pattern) + log(count)) time and allocates nothing per repetition.
+func ChecksumRepeat(pattern []byte, count int64, aTable *TTable) uint16 {
+	vOp := tAffine{
+		m: zerosMatrix(int64(len(pattern)), aTable),
+		c: Update(0, pattern, aTable),
+	}
+	vOp = vOp.pow(count)
+	return Complete(vOp.m.apply(Init(aTable))^vOp.c, aTable)
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,60 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nocatalogue || (crc16_ccitt && crc16_ibm && crc16_misc)
+
+package crc16
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestChecksumRepeat(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		for _, vAlgo := range []TAlgo{CRC16_XMODEM, CRC16_MODBUS, CRC16_GENIBUS, CRC16_DDS_110} {
+			vTable := MakeTable(vAlgo)
+			for _, vPattern := range [][]byte{nil, {0}, []byte("abc"), []byte("123456789")} {
+				for _, vCount := range []int64{0, 1, 2, 7, 100, 1023} {
+					vExpected := Checksum(bytes.Repeat(vPattern, int(vCount)), vTable)
+					So(ChecksumRepeat(vPattern, vCount, vTable), ShouldEqual, vExpected)
+				}
+			}
+		}
+	})
+}
+
+//--------------------------------------
+
+func TestCombine(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vData := []byte("The quick brown fox jumps over the lazy dog, twice over.")
+		for _, vAlgo := range []TAlgo{CRC16_XMODEM, CRC16_MODBUS, CRC16_GENIBUS, CRC16_NRSC_5, {Poly: 0x8005, RefIn: true, XorOut: 0x1234}} {
+			vTable := MakeTable(vAlgo)
+			for _, n := range []int{0, 1, 9, 30, len(vData)} {
+				vSum1, vSum2 := Checksum(vData[:n], vTable), Checksum(vData[n:], vTable)
+				So(Combine(vSum1, vSum2, int64(len(vData)-n), vTable), ShouldEqual, Checksum(vData, vTable))
+			}
+		}
+	})
+}
+
+//--------------------------------------
+
+func TestExtendZeros(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vData := []byte("123456789")
+		for _, vAlgo := range []TAlgo{CRC16_XMODEM, CRC16_MODBUS, CRC16_GENIBUS, {Poly: 0x8005, RefIn: true, XorOut: 0x1234}} {
+			vTable := MakeTable(vAlgo)
+			for _, n := range []int64{0, 1, 2, 17, 4096} {
+				vExtended := append(append([]byte{}, vData...), make([]byte, n)...)
+				So(ExtendZeros(Checksum(vData, vTable), n, vTable), ShouldEqual, Checksum(vExtended, vTable))
+			}
+		}
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,138 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package crc16
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+)
+
+//-----------------------------------------------------------------------------
+
+// This file contains the message framing of stream connections, e.g. TCP or serial-over-TCP links.
+//
+// A frame is the two sync bytes, the length of the message in a 2-byte word, the message
+// and the checksum of the length and the message in a 2-byte trailer.
+
+// TFrameConfig describes the frames exchanged over a connection.
+// A zero Sync means 0xA55A, a zero MaxLen means 4096 bytes and a nil Order means
+// big-endian lengths and trailers. The sync bytes are always sent most significant first.
+type TFrameConfig struct {
+	Sync   uint16
+	MaxLen int
+	Order  binary.ByteOrder
+}
+
+// MessageConn is a connection exchanging checksummed messages.
+//
+// ReadMessage returns the next message whose frame verifies, skipping garbage
+// and corrupt frames to resynchronize. It must not be called concurrently.
+type MessageConn interface {
+	net.Conn
+	WriteMessage(p []byte) error
+	ReadMessage() ([]byte, error)
+}
+
+type tMessageConn struct {
+	net.Conn
+	table  *TTable
+	cfg    TFrameConfig
+	reader *bufio.Reader
+}
+
+const (
+	cFrameSync   = 0xA55A
+	cFrameMaxLen = 4096
+	cFrameHeader = 4
+)
+
+//-----------------------------------------------------------------------------
+
+// WrapConn returns the connection exchanging messages over c in the configured frames,
+// checksummed with the specified table. Messages can not exceed 65535 bytes.
+func WrapConn(c net.Conn, t *TTable, aCfg TFrameConfig) MessageConn {
+	if aCfg.Sync == 0 {
+		aCfg.Sync = cFrameSync
+	}
+	if aCfg.MaxLen <= 0 {
+		aCfg.MaxLen = cFrameMaxLen
+	}
+	aCfg.MaxLen = min(aCfg.MaxLen, 0xFFFF)
+	if aCfg.Order == nil {
+		aCfg.Order = binary.BigEndian
+	}
+	return &tMessageConn{Conn: c, table: t, cfg: aCfg,
+		reader: bufio.NewReaderSize(c, cFrameHeader+aCfg.MaxLen+2)}
+}
+
+//--------------------------------------
+
+// WriteMessage writes p in a single frame.
+func (aC *tMessageConn) WriteMessage(p []byte) error {
+	if len(p) > aC.cfg.MaxLen {
+		return errors.New("crc16: message too long")
+	}
+	vFrame := make([]byte, cFrameHeader+len(p)+2)
+	binary.BigEndian.PutUint16(vFrame, aC.cfg.Sync)
+	aC.cfg.Order.PutUint16(vFrame[2:], uint16(len(p)))
+	copy(vFrame[cFrameHeader:], p)
+	vEnd := cFrameHeader + len(p)
+	aC.cfg.Order.PutUint16(vFrame[vEnd:], Checksum(vFrame[2:vEnd], aC.table))
+	_, err := aC.Conn.Write(vFrame)
+	return err
+}
+
+//--------------------------------------
+
+// ReadMessage returns the next valid message. It returns io.EOF if the connection ends
+// between frames and io.ErrUnexpectedEOF if it ends inside one.
+func (aC *tMessageConn) ReadMessage() ([]byte, error) {
+	for {
+		vHeader, err := aC.reader.Peek(cFrameHeader)
+		if err != nil {
+			return nil, aC.eof(err)
+		}
+		if binary.BigEndian.Uint16(vHeader) != aC.cfg.Sync {
+			aC.reader.Discard(1)
+			continue
+		}
+		vLen := int(aC.cfg.Order.Uint16(vHeader[2:]))
+		if vLen > aC.cfg.MaxLen {
+			aC.reader.Discard(1)
+			continue
+		}
+		vFrame, err := aC.reader.Peek(cFrameHeader + vLen + 2)
+		if err == io.EOF {
+			// The header may be garbage: rescan the rest.
+			aC.reader.Discard(1)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !VerifyTrailer(vFrame[2:], aC.table, aC.cfg.Order) {
+			aC.reader.Discard(1)
+			continue
+		}
+		vRet := append([]byte{}, vFrame[cFrameHeader:cFrameHeader+vLen]...)
+		aC.reader.Discard(len(vFrame))
+		return vRet, nil
+	}
+}
+
+//--------------------------------------
+
+// Returns the error to report for err, io.EOF inside a frame being unexpected.
+func (aC *tMessageConn) eof(err error) error {
+	if err == io.EOF && aC.reader.Buffered() > 0 {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+//-----------------------------------------------------------------------------
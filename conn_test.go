@@ -0,0 +1,73 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || crc16_ibm)
+
+package crc16
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestWrapConn(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_MODBUS)
+		vCfg := TFrameConfig{MaxLen: 64, Order: binary.LittleEndian}
+
+		vLocal, vRemote := net.Pipe()
+		vA, vB := WrapConn(vLocal, vTable, vCfg), WrapConn(vRemote, vTable, vCfg)
+		go func() {
+			vA.WriteMessage([]byte("hello"))
+			vA.WriteMessage(nil)
+			vA.Close()
+		}()
+		vMsg, err := vB.ReadMessage()
+		So(err, ShouldBeNil)
+		So(string(vMsg), ShouldEqual, "hello")
+		vMsg, err = vB.ReadMessage()
+		So(err, ShouldBeNil)
+		So(vMsg, ShouldBeEmpty)
+		_, err = vB.ReadMessage()
+		So(err, ShouldEqual, io.EOF)
+		So(vA.WriteMessage(make([]byte, 65)), ShouldNotBeNil)
+
+		// Resynchronization past garbage, a corrupt frame and a bogus header.
+		vFrame := func(p string) []byte {
+			vRet := AppendChecksum(append([]byte{byte(len(p)), 0}, p...), vTable, binary.LittleEndian)
+			return append([]byte{0xA5, 0x5A}, vRet...)
+		}
+		vCorrupt := vFrame("corrupt")
+		vCorrupt[4] ^= 1
+		var vStream []byte
+		vStream = append(vStream, 0x00, 0xA5)
+		vStream = append(vStream, 0xA5, 0x5A)
+		vStream = append(vStream, vFrame("first")...)
+		vStream = append(vStream, 0xA5, 0x5A, 0x3F, 0x00)
+		vStream = append(vStream, vCorrupt...)
+		vStream = append(vStream, vFrame("second")...)
+		vStream = append(vStream, 0xA5, 0x5A, 0x10)
+
+		vLocal, vRemote = net.Pipe()
+		vB = WrapConn(vRemote, vTable, vCfg)
+		go func() {
+			vLocal.Write(vStream)
+			vLocal.Close()
+		}()
+		vMsg, err = vB.ReadMessage()
+		So(err, ShouldBeNil)
+		So(string(vMsg), ShouldEqual, "first")
+		vMsg, err = vB.ReadMessage()
+		So(err, ShouldBeNil)
+		So(string(vMsg), ShouldEqual, "second")
+		_, err = vB.ReadMessage()
+		So(err, ShouldEqual, io.ErrUnexpectedEOF)
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,56 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+//-----------------------------------------------------------------------------
+
+// This file contains the constant-time engine for security-sensitive contexts, e.g. when
+// checksums are part of token formats: table lookups indexed by secret data leak it through
+// cache timing, so the engine shifts the message in one bit at a time with masks instead
+// of branches or lookups. Only the data is protected, not the parameters of the algorithm.
+
+//-----------------------------------------------------------------------------
+
+// UpdateConstantTime returns the result of adding the bytes in data to the crc, like Update,
+// in time depending only on the length of data. It is several times slower than Update.
+func UpdateConstantTime(crc uint16, data []byte, aTable *TTable) uint16 {
+	vPoly := aTable.algo.Poly << aTable.algo.pad()
+	for _, d := range data {
+		vByte := uint16(d)
+		if aTable.algo.RefIn {
+			vByte = reverseConstantTime(vByte) >> 8
+		}
+		crc ^= vByte << 8
+		for range 8 {
+			crc = crc<<1 ^ vPoly&-(crc>>15)
+		}
+	}
+	return crc
+}
+
+//--------------------------------------
+
+// ChecksumConstantTime returns CRC checksum of data using the specified algorithm,
+// like Checksum, in time depending only on the length of data.
+func ChecksumConstantTime(data []byte, aTable *TTable) uint16 {
+	crc := UpdateConstantTime(Init(aTable), data, aTable)
+	if aTable.algo.RefOut {
+		crc = reverseConstantTime(crc)
+	} else {
+		crc >>= aTable.algo.pad()
+	}
+	return crc ^ aTable.algo.XorOut
+}
+
+//--------------------------------------
+
+// Returns v with the bit order reversed, by swapping bit groups rather than by a lookup
+// table as bits.Reverse16 does on some platforms.
+func reverseConstantTime(v uint16) uint16 {
+	v = v>>1&0x5555 | v&0x5555<<1
+	v = v>>2&0x3333 | v&0x3333<<2
+	v = v>>4&0x0F0F | v&0x0F0F<<4
+	return v>>8 | v<<8
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,38 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import (
+	"math/bits"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestConstantTime(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		for v := 0; v < 0x10000; v += 0x1F3 {
+			So(reverseConstantTime(uint16(v)), ShouldEqual, bits.Reverse16(uint16(v)))
+		}
+		vData := make([]byte, 1000)
+		for i := range vData {
+			vData[i] = byte(i * 131)
+		}
+		for _, a := range []TAlgo{
+			{Poly: 0x1021, Init: 0xFFFF},
+			{Poly: 0x1021, Init: 0xFFFF, RefIn: true, RefOut: true, XorOut: 0xFFFF},
+			{Poly: 0x8005, RefIn: true, RefOut: false},
+			{Poly: 0x8BB7},
+		} {
+			vTable := MakeTable(a)
+			So(ChecksumConstantTime([]byte("123456789"), vTable), ShouldEqual, Checksum([]byte("123456789"), vTable))
+			So(ChecksumConstantTime(vData, vTable), ShouldEqual, Checksum(vData, vTable))
+			vCrc := UpdateConstantTime(Init(vTable), vData[:333], vTable)
+			So(UpdateConstantTime(vCrc, vData[333:], vTable), ShouldEqual, Update(Init(vTable), vData, vTable))
+		}
+	})
+}
+
+//-----------------------------------------------------------------------------
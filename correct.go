@@ -0,0 +1,253 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import "math/bits"
+
+//-----------------------------------------------------------------------------
+
+// This file contains the single-bit and burst error correction facilities.
+//
+// Bit positions are numbered from the start of the frame: position p refers
+// to bit p%8 (least significant bit is 0) of byte p/8. Positions at or beyond
+// 8*len(data) refer to the bits of the received checksum itself, so position
+// 8*len(data)+k means bit k of the received uint16 was flipped.
+
+// TSyndromeTable maps CRC syndromes to error locations for frames of a fixed length,
+// so that per-frame correction is a single lookup.
+type TSyndromeTable struct {
+	table  *TTable
+	length int
+	index  map[uint16]int
+}
+
+// Marks a syndrome shared by several positions, which can not be corrected.
+const cAmbiguous = -1
+
+//-----------------------------------------------------------------------------
+
+// Returns the syndromes caused by flipping every single bit of a frame
+// of aLen data bytes followed by its checksum.
+func bitSyndromes(aTable *TTable, aLen int) []uint16 {
+	vSyn := make([]uint16, 8*aLen+16)
+	vZero := []byte{0}
+	vBase := Complete(0, aTable)
+
+	var vRegs [8]uint16
+	for b := range vRegs {
+		vRegs[b] = Update(0, []byte{1 << b}, aTable)
+	}
+	for i := aLen - 1; i >= 0; i-- {
+		for b := range vRegs {
+			vSyn[8*i+b] = Complete(vRegs[b], aTable) ^ vBase
+			vRegs[b] = Update(vRegs[b], vZero, aTable)
+		}
+	}
+	for k := 0; k < 16; k++ {
+		vSyn[8*aLen+k] = 1 << k
+	}
+	return vSyn
+}
+
+//--------------------------------------
+
+// Returns the index of the specified syndromes, mapping syndromes shared by
+// several positions to cAmbiguous.
+func syndromeIndex(aSyn []uint16) map[uint16]int {
+	vIndex := make(map[uint16]int, len(aSyn))
+	for p, s := range aSyn {
+		if _, vFound := vIndex[s]; vFound {
+			vIndex[s] = cAmbiguous
+		} else {
+			vIndex[s] = p
+		}
+	}
+	return vIndex
+}
+
+//--------------------------------------
+
+// Flips the bit at position aPos of the frame formed by data and its checksum.
+func flipBit(data []byte, received *uint16, aPos int) {
+	if aPos < 8*len(data) {
+		data[aPos/8] ^= 1 << (aPos % 8)
+	} else {
+		*received ^= 1 << (aPos - 8*len(data))
+	}
+}
+
+//-----------------------------------------------------------------------------
+
+// Correct attempts to repair a single-bit error in data, given the checksum received with it.
+// On success the bit is flipped back in place and its position is returned; a position
+// at or beyond 8*len(data) means the error was in the received checksum.
+// It returns -1 and true if data already matches the checksum, and false if the error
+// can not be attributed to a single bit unambiguously.
+func Correct(data []byte, received uint16, aTable *TTable) (int, bool) {
+	vSyndrome := Checksum(data, aTable) ^ received
+	countVerified(len(data), vSyndrome == 0)
+	if vSyndrome == 0 {
+		return -1, true
+	}
+
+	vPos := cAmbiguous
+	for p, s := range bitSyndromes(aTable, len(data)) {
+		if s != vSyndrome {
+			continue
+		}
+		if vPos != cAmbiguous {
+			return cAmbiguous, false
+		}
+		vPos = p
+	}
+	if vPos == cAmbiguous {
+		return cAmbiguous, false
+	}
+	flipBit(data, &received, vPos)
+	countCorrection()
+	return vPos, true
+}
+
+//--------------------------------------
+
+// CorrectBurst attempts to repair a burst error of up to aMaxLen consecutive bit positions
+// in data, given the checksum received with it. The shortest burst explaining the checksum
+// mismatch is flipped back in place and its flipped positions are returned in ascending order.
+// It returns an empty slice and true if data already matches the checksum, and false
+// if no burst matches or several bursts of the shortest matching length do.
+//
+// Consecutive positions are adjacent on the wire for links transmitting the least significant
+// bit first. Bursts longer than 16 bits are not reliably detected, so aMaxLen is capped at 16.
+func CorrectBurst(data []byte, received uint16, aMaxLen int, aTable *TTable) ([]int, bool) {
+	vSyndrome := Checksum(data, aTable) ^ received
+	countVerified(len(data), vSyndrome == 0)
+	if vSyndrome == 0 {
+		return []int{}, true
+	}
+
+	vSyn := bitSyndromes(aTable, len(data))
+	for l := 1; l <= min(aMaxLen, 16); l++ {
+		vPos, vPattern, vFound := -1, uint32(0), false
+		for p := 0; p+l <= len(vSyn); p++ {
+			// Walk the patterns with both end bits set in Gray code order of the inner bits.
+			s := vSyn[p]
+			vBits := uint32(1)
+			if l > 1 {
+				s ^= vSyn[p+l-1]
+				vBits |= 1 << (l - 1)
+			}
+			for i := uint32(1); ; i++ {
+				if s == vSyndrome {
+					if vFound {
+						return nil, false
+					}
+					vPos, vPattern, vFound = p, vBits, true
+				}
+				if l < 3 || i == 1<<(l-2) {
+					break
+				}
+				j := 1 + bits.TrailingZeros32(i)
+				s ^= vSyn[p+j]
+				vBits ^= 1 << j
+			}
+		}
+		if vFound {
+			var vRet []int
+			for ; vPattern != 0; vPattern &= vPattern - 1 {
+				vRet = append(vRet, vPos+bits.TrailingZeros32(vPattern))
+			}
+			for _, p := range vRet {
+				flipBit(data, &received, p)
+			}
+			countCorrection()
+			return vRet, true
+		}
+	}
+	return nil, false
+}
+
+//--------------------------------------
+
+// CorrectDouble attempts to repair an error of one or two bits anywhere in data, given
+// the checksum received with it. A single-bit error is preferred to a double-bit one;
+// the flipped positions are returned in ascending order. It returns an empty slice and
+// true if data already matches the checksum, and false if the error can not be
+// attributed to one or two bits unambiguously.
+//
+// Double-bit errors are only unambiguous for short frames with a polynomial of Hamming
+// distance 5 or more at that length; longer frames are reported as uncorrectable.
+func CorrectDouble(data []byte, received uint16, aTable *TTable) ([]int, bool) {
+	vSyndrome := Checksum(data, aTable) ^ received
+	countVerified(len(data), vSyndrome == 0)
+	if vSyndrome == 0 {
+		return []int{}, true
+	}
+
+	vSyn := bitSyndromes(aTable, len(data))
+	vIndex := syndromeIndex(vSyn)
+	var vRet []int
+	if p, vFound := vIndex[vSyndrome]; vFound {
+		if p == cAmbiguous {
+			return nil, false
+		}
+		vRet = []int{p}
+	} else {
+		for p, s := range vSyn {
+			q, vFound := vIndex[vSyndrome^s]
+			switch {
+			case !vFound || (q != cAmbiguous && q <= p):
+				continue
+			case q == cAmbiguous || vRet != nil:
+				return nil, false
+			}
+			vRet = []int{p, q}
+		}
+		if vRet == nil {
+			return nil, false
+		}
+	}
+	for _, p := range vRet {
+		flipBit(data, &received, p)
+	}
+	countCorrection()
+	return vRet, true
+}
+
+//-----------------------------------------------------------------------------
+
+// MakeSyndromeTable precomputes the syndrome to error location index
+// for frames of aLength data bytes checksummed with the specified table.
+func MakeSyndromeTable(aTable *TTable, aLength int) *TSyndromeTable {
+	return &TSyndromeTable{table: aTable, length: aLength, index: syndromeIndex(bitSyndromes(aTable, aLength))}
+}
+
+//--------------------------------------
+
+// Len returns the frame length in bytes the syndrome table was built for.
+func (aS *TSyndromeTable) Len() int {
+	return aS.length
+}
+
+//--------------------------------------
+
+// Correct behaves like the package-level Correct but uses the precomputed index.
+// The length of data must match the length the table was built for.
+func (aS *TSyndromeTable) Correct(data []byte, received uint16) (int, bool) {
+	if len(data) != aS.length {
+		return cAmbiguous, false
+	}
+	vSyndrome := Checksum(data, aS.table) ^ received
+	countVerified(len(data), vSyndrome == 0)
+	if vSyndrome == 0 {
+		return -1, true
+	}
+	vPos, vFound := aS.index[vSyndrome]
+	if !vFound || vPos == cAmbiguous {
+		return cAmbiguous, false
+	}
+	flipBit(data, &received, vPos)
+	countCorrection()
+	return vPos, true
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,151 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nocatalogue || (crc16_ccitt && crc16_ibm && crc16_misc)
+
+package crc16
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestCorrect(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_MODBUS)
+		vOrig := []byte("correct me if you can")
+		vCrc := Checksum(vOrig, vTable)
+
+		vData := append([]byte(nil), vOrig...)
+		vPos, vOk := Correct(vData, vCrc, vTable)
+		So(vOk, ShouldBeTrue)
+		So(vPos, ShouldEqual, -1)
+
+		for _, p := range []int{0, 7, 13, 8*len(vOrig) - 1} {
+			vData[p/8] ^= 1 << (p % 8)
+			vPos, vOk = Correct(vData, vCrc, vTable)
+			So(vOk, ShouldBeTrue)
+			So(vPos, ShouldEqual, p)
+			So(bytes.Equal(vData, vOrig), ShouldBeTrue)
+		}
+
+		vPos, vOk = Correct(vData, vCrc^0x0100, vTable)
+		So(vOk, ShouldBeTrue)
+		So(vPos, ShouldEqual, 8*len(vOrig)+8)
+		So(bytes.Equal(vData, vOrig), ShouldBeTrue)
+	})
+}
+
+//--------------------------------------
+
+func TestSyndromeTable(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		for _, vAlgo := range []TAlgo{CRC16_XMODEM, CRC16_X_25, CRC16_DNP} {
+			vTable := MakeTable(vAlgo)
+			vOrig := []byte("0123456789abcdef")
+			vCrc := Checksum(vOrig, vTable)
+			vSyn := MakeSyndromeTable(vTable, len(vOrig))
+			So(vSyn.Len(), ShouldEqual, len(vOrig))
+
+			vData := append([]byte(nil), vOrig...)
+			for p := 0; p < 8*len(vOrig); p++ {
+				vData[p/8] ^= 1 << (p % 8)
+				vPos, vOk := vSyn.Correct(vData, vCrc)
+				So(vOk, ShouldBeTrue)
+				So(vPos, ShouldEqual, p)
+			}
+			So(bytes.Equal(vData, vOrig), ShouldBeTrue)
+
+			vData[0] ^= 0x03
+			vData[5] ^= 0x80
+			_, vOk := vSyn.Correct(vData, vCrc)
+			So(vOk, ShouldBeFalse)
+
+			_, vOk = vSyn.Correct(vData[1:], vCrc)
+			So(vOk, ShouldBeFalse)
+		}
+	})
+}
+
+//--------------------------------------
+
+func TestCorrectBurst(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_KERMIT)
+		vOrig := []byte("bursts of noise on the line")
+		vCrc := Checksum(vOrig, vTable)
+
+		vData := append([]byte(nil), vOrig...)
+		vPos, vOk := CorrectBurst(vData, vCrc, 8, vTable)
+		So(vOk, ShouldBeTrue)
+		So(vPos, ShouldBeEmpty)
+
+		for _, vErr := range [][]int{{21}, {40, 41}, {64, 66, 67}, {100, 103, 105}} {
+			for _, p := range vErr {
+				vData[p/8] ^= 1 << (p % 8)
+			}
+			vPos, vOk = CorrectBurst(vData, vCrc, 6, vTable)
+			So(vOk, ShouldBeTrue)
+			So(vPos, ShouldResemble, vErr)
+			So(bytes.Equal(vData, vOrig), ShouldBeTrue)
+		}
+
+		vData[0] ^= 0x81
+		vPos, vOk = CorrectBurst(vData, vCrc, 4, vTable)
+		So(vOk, ShouldBeFalse)
+		So(vPos, ShouldBeNil)
+		vData[0] ^= 0x81
+
+		vPos, vOk = CorrectBurst(vData, vCrc^0x0003, 4, vTable)
+		So(vOk, ShouldBeTrue)
+		So(vPos, ShouldResemble, []int{8 * len(vOrig), 8*len(vOrig) + 1})
+	})
+}
+
+//--------------------------------------
+
+func TestCorrectDouble(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_DECT_R)
+		vOrig := []byte("radio-fr")
+		vCrc := Checksum(vOrig, vTable)
+
+		vData := append([]byte(nil), vOrig...)
+		vPos, vOk := CorrectDouble(vData, vCrc, vTable)
+		So(vOk, ShouldBeTrue)
+		So(vPos, ShouldBeEmpty)
+
+		vPos, vOk = CorrectDouble(vData, vCrc^0x0400, vTable)
+		So(vOk, ShouldBeTrue)
+		So(vPos, ShouldResemble, []int{8*len(vOrig) + 10})
+
+		// CRC-16/DECT-R corrects all one and two bit errors in frames of 8 bytes.
+		vFailed := 0
+		for p := 0; p < 8*len(vOrig)+16; p++ {
+			for q := p + 1; q < 8*len(vOrig)+16; q++ {
+				vRecv := vCrc
+				flipBit(vData, &vRecv, p)
+				flipBit(vData, &vRecv, q)
+				vPos, vOk = CorrectDouble(vData, vRecv, vTable)
+				if !vOk || len(vPos) != 2 || vPos[0] != p || vPos[1] != q || !bytes.Equal(vData, vOrig) {
+					vFailed++
+					copy(vData, vOrig)
+				}
+			}
+		}
+		So(vFailed, ShouldEqual, 0)
+
+		vTable = MakeTable(CRC16_KERMIT)
+		vCrc = Checksum(vOrig, vTable)
+		vData[0] ^= 0x81
+		vPos, vOk = CorrectDouble(vData, vCrc, vTable)
+		So(vOk, ShouldBeFalse)
+		So(vPos, ShouldBeNil)
+		So(vData[0], ShouldEqual, vOrig[0]^0x81)
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,41 @@
+//-----------------------------------------------------------------------------
+
+package crc
+
+//-----------------------------------------------------------------------------
+
+// Predefined algorithms of widths other than 8, 16, 32 and 64 bits,
+// held in the narrowest register type fitting them.
+//
+// Related links:
+// - https://reveng.sourceforge.io/crc-catalogue/1-15.htm
+// - https://reveng.sourceforge.io/crc-catalogue/17plus.htm
+var (
+	CRC5_EPC_C1G2    = TAlgo[uint8]{0x09, 0x09, false, false, 0x00, 0x00, "CRC-5/EPC-C1G2", 5}
+	CRC5_G_704       = TAlgo[uint8]{0x15, 0x00, true, true, 0x00, 0x07, "CRC-5/G-704", 5}
+	CRC5_USB         = TAlgo[uint8]{0x05, 0x1F, true, true, 0x1F, 0x19, "CRC-5/USB", 5}
+	CRC7_MMC         = TAlgo[uint8]{0x09, 0x00, false, false, 0x00, 0x75, "CRC-7/MMC", 7}
+	CRC10_ATM        = TAlgo[uint16]{0x233, 0x000, false, false, 0x000, 0x199, "CRC-10/ATM", 10}
+	CRC10_CDMA2000   = TAlgo[uint16]{0x3D9, 0x3FF, false, false, 0x000, 0x233, "CRC-10/CDMA2000", 10}
+	CRC10_GSM        = TAlgo[uint16]{0x175, 0x000, false, false, 0x3FF, 0x12A, "CRC-10/GSM", 10}
+	CRC11_FLEXRAY    = TAlgo[uint16]{0x385, 0x01A, false, false, 0x000, 0x5A3, "CRC-11/FLEXRAY", 11}
+	CRC11_UMTS       = TAlgo[uint16]{0x307, 0x000, false, false, 0x000, 0x061, "CRC-11/UMTS", 11}
+	CRC12_CDMA2000   = TAlgo[uint16]{0xF13, 0xFFF, false, false, 0x000, 0xD4D, "CRC-12/CDMA2000", 12}
+	CRC12_DECT       = TAlgo[uint16]{0x80F, 0x000, false, false, 0x000, 0xF5B, "CRC-12/DECT", 12}
+	CRC12_GSM        = TAlgo[uint16]{0xD31, 0x000, false, false, 0xFFF, 0xB34, "CRC-12/GSM", 12}
+	CRC12_UMTS       = TAlgo[uint16]{0x80F, 0x000, false, true, 0x000, 0xDAF, "CRC-12/UMTS", 12}
+	CRC13_BBC        = TAlgo[uint16]{0x1CF5, 0x0000, false, false, 0x0000, 0x04FA, "CRC-13/BBC", 13}
+	CRC14_DARC       = TAlgo[uint16]{0x0805, 0x0000, true, true, 0x0000, 0x082D, "CRC-14/DARC", 14}
+	CRC14_GSM        = TAlgo[uint16]{0x202D, 0x0000, false, false, 0x3FFF, 0x30AE, "CRC-14/GSM", 14}
+	CRC15_CAN        = TAlgo[uint16]{0x4599, 0x0000, false, false, 0x0000, 0x059E, "CRC-15/CAN", 15}
+	CRC15_MPT1327    = TAlgo[uint16]{0x6815, 0x0000, false, false, 0x0001, 0x2566, "CRC-15/MPT1327", 15}
+	CRC17_CAN_FD     = TAlgo[uint32]{0x1685B, 0x00000, false, false, 0x00000, 0x04F03, "CRC-17/CAN-FD", 17}
+	CRC21_CAN_FD     = TAlgo[uint32]{0x102899, 0x000000, false, false, 0x000000, 0x0ED841, "CRC-21/CAN-FD", 21}
+	CRC24_BLE        = TAlgo[uint32]{0x00065B, 0x555555, true, true, 0x000000, 0xC25A56, "CRC-24/BLE", 24}
+	CRC24_FLEXRAY_A  = TAlgo[uint32]{0x5D6DCB, 0xFEDCBA, false, false, 0x000000, 0x7979BD, "CRC-24/FLEXRAY-A", 24}
+	CRC24_INTERLAKEN = TAlgo[uint32]{0x328B63, 0xFFFFFF, false, false, 0xFFFFFF, 0xB4F3E6, "CRC-24/INTERLAKEN", 24}
+	CRC24_LTE_A      = TAlgo[uint32]{0x864CFB, 0x000000, false, false, 0x000000, 0xCDE703, "CRC-24/LTE-A", 24}
+	CRC24_OPENPGP    = TAlgo[uint32]{0x864CFB, 0xB704CE, false, false, 0x000000, 0x21CF02, "CRC-24/OPENPGP", 24}
+)
+
+//-----------------------------------------------------------------------------
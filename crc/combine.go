@@ -0,0 +1,141 @@
+//-----------------------------------------------------------------------------
+
+package crc
+
+//-----------------------------------------------------------------------------
+
+// This file contains the shift operator algebra over the CRC register.
+//
+// Feeding zero bytes into the register is a linear operation over GF(2), so it is
+// represented by a bit matrix as wide as the register type and long runs of zeros
+// can be applied in logarithmic time by repeated squaring, as in zlib's crc32_combine.
+
+// tMatrix is a linear operator on the register; column i is the image of bit i.
+// Only the first Width[T]() columns are used.
+type tMatrix[T TWord] [64]T
+
+// tAffine is the operator r -> m*r ^ c; feeding any fixed data into the register is affine.
+type tAffine[T TWord] struct {
+	m tMatrix[T]
+	c T
+}
+
+//-----------------------------------------------------------------------------
+
+// Returns the identity operator.
+func identityMatrix[T TWord]() tMatrix[T] {
+	var vM tMatrix[T]
+	for i := 0; i < Width[T](); i++ {
+		vM[i] = 1 << i
+	}
+	return vM
+}
+
+//--------------------------------------
+
+// Returns the image of the register value v.
+func (aM *tMatrix[T]) apply(v T) T {
+	var vRet T
+	for i := 0; v != 0; i, v = i+1, v>>1 {
+		if v&1 != 0 {
+			vRet ^= aM[i]
+		}
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Returns the operator applying b first and then aM.
+func (aM *tMatrix[T]) mul(b *tMatrix[T]) tMatrix[T] {
+	var vRet tMatrix[T]
+	for i := 0; i < Width[T](); i++ {
+		vRet[i] = aM.apply(b[i])
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Returns the operator applying b first and then aA.
+func (aA *tAffine[T]) mul(b *tAffine[T]) tAffine[T] {
+	return tAffine[T]{m: aA.m.mul(&b.m), c: aA.m.apply(b.c) ^ aA.c}
+}
+
+//--------------------------------------
+
+// Returns the operator applying aA n times.
+func (aA tAffine[T]) pow(n int64) tAffine[T] {
+	vRet := tAffine[T]{m: identityMatrix[T]()}
+	for ; n > 0; n >>= 1 {
+		if n&1 != 0 {
+			vRet = aA.mul(&vRet)
+		}
+		aA = aA.mul(&aA)
+	}
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
+
+// Returns the operator feeding n zero bytes into the register.
+func zerosMatrix[T TWord](n int64, aTable *TTable[T]) tMatrix[T] {
+	var vM tMatrix[T]
+	vZero := []byte{0}
+	for i := 0; i < Width[T](); i++ {
+		vM[i] = Update(T(1)<<i, vZero, aTable)
+	}
+	return tAffine[T]{m: vM}.pow(n).m
+}
+
+//--------------------------------------
+
+// Returns the register the checksum was completed from; it undoes Complete.
+func register[T TWord](aSum T, aTable *TTable[T]) T {
+	aSum ^= aTable.algo.XorOut
+	switch {
+	case aTable.Reflected() && aTable.algo.RefOut:
+		return aSum
+	case aTable.algo.RefOut:
+		return reverse(aSum)
+	default:
+		return aTable.toRegister(aSum)
+	}
+}
+
+//-----------------------------------------------------------------------------
+
+// Combine returns CRC checksum of the concatenation of two messages, given the checksum
+// aSum1 of the first one and the checksum aSum2 of the second one of aLen2 bytes,
+// so that the parts of a message may be checksummed in parallel.
+// It runs in O(log(aLen2)) time.
+func Combine[T TWord](aSum1, aSum2 T, aLen2 int64, aTable *TTable[T]) T {
+	vZeros := zerosMatrix(aLen2, aTable)
+	vReg := vZeros.apply(register(aSum1, aTable)^Init(aTable)) ^ register(aSum2, aTable)
+	return Complete(vReg, aTable)
+}
+
+//--------------------------------------
+
+// ExtendZeros returns CRC checksum of a message followed by n zero bytes,
+// given the checksum aSum of the message. It runs in O(log(n)) time.
+func ExtendZeros[T TWord](aSum T, n int64, aTable *TTable[T]) T {
+	vZeros := zerosMatrix(n, aTable)
+	return Complete(vZeros.apply(register(aSum, aTable)), aTable)
+}
+
+//--------------------------------------
+
+// ChecksumRepeat returns CRC checksum of pattern repeated count times
+// using the specified algorithm represented by the TTable.
+// It runs in O(len(pattern) + log(count)) time and allocates nothing per repetition.
+func ChecksumRepeat[T TWord](pattern []byte, count int64, aTable *TTable[T]) T {
+	vOp := tAffine[T]{
+		m: zerosMatrix(int64(len(pattern)), aTable),
+		c: Update(0, pattern, aTable),
+	}
+	vOp = vOp.pow(count)
+	return Complete(vOp.m.apply(Init(aTable))^vOp.c, aTable)
+}
+
+//-----------------------------------------------------------------------------
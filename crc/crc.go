@@ -0,0 +1,253 @@
+//-----------------------------------------------------------------------------
+
+// Package crc implements cyclic redundancy checks of any width from 1 to 64 bits
+// with a generic engine, parameterized by the unsigned integer type holding the register.
+//
+// Algorithms narrower than their register type, like CRC-5/USB in a uint8 or CRC-24/OPENPGP
+// in a uint32, set Width; the register is then kept in the most significant bits of the word
+// and the checksums are masked to Width bits.
+//
+// Package crc16 is the 16-bit instantiation of the engine with the CRC-16 catalogue.
+package crc
+
+import "math/bits"
+
+//-----------------------------------------------------------------------------
+
+// TWord is the type holding CRC registers of up to its number of bits.
+type TWord interface {
+	~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// TAlgo represents parameters of CRC algorithms with the register type T.
+// Width is the number of bits of the CRC, at most the bits of T, zero standing for all of them.
+type TAlgo[T TWord] struct {
+	Poly   T
+	Init   T
+	RefIn  bool
+	RefOut bool
+	XorOut T
+	Check  T
+	Name   string
+	Width  int
+}
+
+// TTable is a 256-word table representing polinomial and algorithm settings for efficient processing.
+//
+// The table of algorithms reflecting their input keeps the register reflected, in its least
+// significant bits, and shifts it right; the others keep it in its most significant bits
+// and shift it left, so that no byte has to be reversed.
+type TTable[T TWord] struct {
+	algo TAlgo[T]
+	pad  int
+	data [256]T
+}
+
+//-----------------------------------------------------------------------------
+
+// Width returns the number of bits of the register type T.
+func Width[T TWord]() int {
+	return bits.Len64(uint64(^T(0)))
+}
+
+//--------------------------------------
+
+// Returns the bits of v in reverse order.
+func reverse[T TWord](v T) T {
+	return T(bits.Reverse64(uint64(v)) >> (64 - Width[T]()))
+}
+
+//--------------------------------------
+
+// MakeEntries returns the lookup table entries of the polynomial for shifting
+// the register most significant bit first.
+func MakeEntries[T TWord](aPoly T) [256]T {
+	// The entries are linear in the index, so only those of the powers of two are shifted out
+	// and the others are combined from them, each power being the previous one shifted once.
+	var vRet [256]T
+	vWidth := Width[T]()
+	vTop := T(1) << (vWidth - 1)
+	crc := T(1) << (vWidth - 8)
+	for i := 0; i < 8; i++ {
+		crc = shiftMSB(crc, vTop, aPoly)
+	}
+	for i := 1; i < 256; i <<= 1 {
+		for j := range i {
+			vRet[i+j] = crc ^ vRet[j]
+		}
+		crc = shiftMSB(crc, vTop, aPoly)
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Returns crc shifted one bit towards its most significant bit vTop.
+func shiftMSB[T TWord](crc, vTop, aPoly T) T {
+	if crc&vTop != 0 {
+		return crc<<1 ^ aPoly
+	}
+	return crc << 1
+}
+
+//--------------------------------------
+
+// MakeEntriesReflected returns the lookup table entries of the polynomial for shifting
+// the reflected register least significant bit first; aPoly is the reflected polynomial.
+func MakeEntriesReflected[T TWord](aPoly T) [256]T {
+	// As in MakeEntries, from the powers of two, the highest first.
+	var vRet [256]T
+	crc := T(0x80)
+	for i := 0; i < 8; i++ {
+		crc = shiftLSB(crc, aPoly)
+	}
+	for i := 0x80; i > 0; i >>= 1 {
+		for j := i; j < 256; j += 2 * i {
+			vRet[j] = crc ^ vRet[j-i]
+		}
+		crc = shiftLSB(crc, aPoly)
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Returns crc shifted one bit towards its least significant bit.
+func shiftLSB[T TWord](crc, aPoly T) T {
+	if crc&1 != 0 {
+		return crc>>1 ^ aPoly
+	}
+	return crc >> 1
+}
+
+//--------------------------------------
+
+// Shift returns the register after the byte d is shifted into it most significant bit first,
+// using the entries made by MakeEntries.
+func Shift[T TWord](crc T, d byte, aEntries *[256]T) T {
+	// Shifting through uint64 lets 8-bit registers shift out entirely.
+	return T(uint64(crc)<<8) ^ aEntries[byte(crc>>(Width[T]()-8))^d]
+}
+
+//--------------------------------------
+
+// ShiftReflected returns the reflected register after the byte d is shifted into it least
+// significant bit first, using the entries made by MakeEntriesReflected.
+func ShiftReflected[T TWord](crc T, d byte, aEntries *[256]T) T {
+	return T(uint64(crc)>>8) ^ aEntries[byte(crc)^d]
+}
+
+//-----------------------------------------------------------------------------
+
+// MakeTable returns the TTable constructed from the specified algorithm.
+// It panics if the Width of the algorithm exceeds the bits of T.
+func MakeTable[T TWord](aAlgo TAlgo[T]) *TTable[T] {
+	vPad := 0
+	if aAlgo.Width != 0 {
+		vPad = Width[T]() - aAlgo.Width
+	}
+	if vPad < 0 || vPad >= Width[T]() {
+		panic("crc: invalid width")
+	}
+	vTable := &TTable[T]{algo: aAlgo, pad: vPad}
+	if aAlgo.RefIn {
+		vTable.data = MakeEntriesReflected(reverse(aAlgo.Poly << vPad))
+	} else {
+		vTable.data = MakeEntries(aAlgo.Poly << vPad)
+	}
+	return vTable
+}
+
+//--------------------------------------
+
+// Algo returns the algorithm the table was constructed from.
+func (aTable *TTable[T]) Algo() TAlgo[T] {
+	return aTable.algo
+}
+
+//--------------------------------------
+
+// Reflected reports whether the table shifts the register right, least significant bit first,
+// rather than left. It does for the algorithms reflecting their input.
+func (aTable *TTable[T]) Reflected() bool {
+	return aTable.algo.RefIn
+}
+
+//--------------------------------------
+
+// Returns the register holding the value v, given with its most significant bit first.
+func (aTable *TTable[T]) toRegister(v T) T {
+	if aTable.Reflected() {
+		return reverse(v << aTable.pad)
+	}
+	return v << aTable.pad
+}
+
+//--------------------------------------
+
+// Returns the value held by the register, with its most significant bit first.
+func (aTable *TTable[T]) fromRegister(crc T) T {
+	if aTable.Reflected() {
+		return reverse(crc) >> aTable.pad
+	}
+	return crc >> aTable.pad
+}
+
+//--------------------------------------
+
+// Entries returns a copy of the 256 lookup table entries, made by MakeEntriesReflected
+// for reflected tables and by MakeEntries otherwise.
+// The entries of algorithms narrower than T are aligned to the end the register shifts out of.
+func (aTable *TTable[T]) Entries() [256]T {
+	return aTable.data
+}
+
+//--------------------------------------
+
+// Init returns the initial value for CRC register corresponding to the specified algorithm.
+func Init[T TWord](aTable *TTable[T]) T {
+	return aTable.toRegister(aTable.algo.Init)
+}
+
+//--------------------------------------
+
+// Update returns the result of adding the bytes in data to the crc.
+func Update[T TWord](crc T, data []byte, aTable *TTable[T]) T {
+	if aTable.Reflected() {
+		for _, d := range data {
+			crc = ShiftReflected(crc, d, &aTable.data)
+		}
+		return crc
+	}
+	for _, d := range data {
+		crc = Shift(crc, d, &aTable.data)
+	}
+	return crc
+}
+
+//--------------------------------------
+
+// Complete returns the result of CRC calculation and post-calculation processing of the crc.
+func Complete[T TWord](crc T, aTable *TTable[T]) T {
+	switch {
+	case aTable.Reflected() && aTable.algo.RefOut:
+		// The reflected register is the output.
+		return crc ^ aTable.algo.XorOut
+	case aTable.algo.RefOut:
+		// The pad bits are zero, so the reversed register is aligned to the least significant bit.
+		return reverse(crc) ^ aTable.algo.XorOut
+	default:
+		return aTable.fromRegister(crc) ^ aTable.algo.XorOut
+	}
+}
+
+//--------------------------------------
+
+// Checksum returns CRC checksum of data using specified algorithm represented by the TTable.
+func Checksum[T TWord](data []byte, aTable *TTable[T]) T {
+	crc := Init(aTable)
+	crc = Update(crc, data, aTable)
+	return Complete(crc, aTable)
+}
+
+//-----------------------------------------------------------------------------
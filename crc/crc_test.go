@@ -0,0 +1,227 @@
+//-----------------------------------------------------------------------------
+
+package crc
+
+import (
+	"bytes"
+	"hash/crc32"
+	"hash/crc64"
+	"math/bits"
+	"math/rand"
+	"path"
+	"runtime"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+// Returns the function name of the calling function.
+func funcName() string {
+	vRet := "?"
+	vPc, _, _, vOk := runtime.Caller(1)
+	if vOk {
+		vRet = path.Base(runtime.FuncForPC(vPc).Name())
+	}
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
+
+func TestWidths(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		So(Width[uint8](), ShouldEqual, 8)
+		So(Width[uint16](), ShouldEqual, 16)
+		So(Width[uint32](), ShouldEqual, 32)
+		So(Width[uint64](), ShouldEqual, 64)
+
+		vCheck := []byte("123456789")
+		So(Checksum(vCheck, MakeTable(TAlgo[uint8]{Poly: 0x07})), ShouldEqual, 0xF4)
+		So(Checksum(vCheck, MakeTable(TAlgo[uint8]{Poly: 0x31, RefIn: true, RefOut: true})), ShouldEqual, 0xA1)
+		So(Checksum(vCheck, MakeTable(TAlgo[uint16]{Poly: 0x8005, RefIn: true, RefOut: true})), ShouldEqual, 0xBB3D)
+		So(Checksum(vCheck, MakeTable(TAlgo[uint16]{Poly: 0x1021, Init: 0xFFFF})), ShouldEqual, 0x29B1)
+
+		vCRC32 := MakeTable(TAlgo[uint32]{0x04C11DB7, 0xFFFFFFFF, true, true, 0xFFFFFFFF, 0xCBF43926, "CRC-32/ISO-HDLC", 32})
+		So(Checksum(vCheck, vCRC32), ShouldEqual, vCRC32.Algo().Check)
+		vCRC64 := MakeTable(TAlgo[uint64]{0x42F0E1EBA9EA3693, ^uint64(0), true, true, ^uint64(0), 0x995DC9BBDF1939FA, "CRC-64/XZ", 64})
+		So(Checksum(vCheck, vCRC64), ShouldEqual, vCRC64.Algo().Check)
+
+		vRand := rand.New(rand.NewSource(9))
+		vECMA := crc64.MakeTable(crc64.ECMA)
+		for n := 0; n < 100; n++ {
+			vData := make([]byte, n)
+			vRand.Read(vData)
+			So(Checksum(vData, vCRC32), ShouldEqual, crc32.ChecksumIEEE(vData))
+			So(Checksum(vData, vCRC64), ShouldEqual, crc64.Checksum(vData, vECMA))
+		}
+	})
+}
+
+//--------------------------------------
+
+func TestOddWidths(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vCheck := []byte("123456789")
+		for _, a := range []TAlgo[uint8]{CRC5_EPC_C1G2, CRC5_G_704, CRC5_USB, CRC7_MMC} {
+			So(Checksum(vCheck, MakeTable(a)), ShouldEqual, a.Check)
+		}
+		for _, a := range []TAlgo[uint16]{CRC10_ATM, CRC10_CDMA2000, CRC10_GSM, CRC11_FLEXRAY, CRC11_UMTS,
+			CRC12_CDMA2000, CRC12_DECT, CRC12_GSM, CRC12_UMTS, CRC13_BBC, CRC14_DARC, CRC14_GSM, CRC15_CAN, CRC15_MPT1327} {
+			So(Checksum(vCheck, MakeTable(a)), ShouldEqual, a.Check)
+		}
+		for _, a := range []TAlgo[uint32]{CRC17_CAN_FD, CRC21_CAN_FD, CRC24_BLE, CRC24_FLEXRAY_A, CRC24_INTERLAKEN, CRC24_LTE_A, CRC24_OPENPGP} {
+			So(Checksum(vCheck, MakeTable(a)), ShouldEqual, a.Check)
+		}
+
+		// Checksums never exceed the width, and splitting the data does not matter.
+		vTable := MakeTable(CRC24_OPENPGP)
+		vRand := rand.New(rand.NewSource(24))
+		for n := 0; n < 100; n++ {
+			vData := make([]byte, n)
+			vRand.Read(vData)
+			vSum := Checksum(vData, vTable)
+			So(vSum>>24, ShouldEqual, 0)
+			vCrc := Update(Init(vTable), vData[:n/2], vTable)
+			So(Complete(Update(vCrc, vData[n/2:], vTable), vTable), ShouldEqual, vSum)
+		}
+
+		So(MakeTable(TAlgo[uint16]{Poly: 0x8005, Width: 16}).Entries(), ShouldEqual, MakeTable(TAlgo[uint16]{Poly: 0x8005}).Entries())
+		So(func() { MakeTable(TAlgo[uint8]{Poly: 0x07, Width: 9}) }, ShouldPanic)
+	})
+}
+
+//--------------------------------------
+
+func TestHash(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vData := []byte("123456789")
+		vH8 := New8(MakeTable(CRC5_USB))
+		vH8.Write(vData)
+		So(vH8.Sum8(), ShouldEqual, CRC5_USB.Check)
+		So(vH8.Sum(nil), ShouldResemble, []byte{0x19})
+
+		vH16 := New16(MakeTable(CRC15_CAN))
+		vH16.Write(vData[:3])
+		vH16.Write(vData[3:])
+		So(vH16.Sum16(), ShouldEqual, CRC15_CAN.Check)
+		So(vH16.Size(), ShouldEqual, 2)
+
+		vH32 := New32(MakeTable(CRC24_OPENPGP))
+		vH32.Write(vData)
+		So(vH32.Sum32(), ShouldEqual, CRC24_OPENPGP.Check)
+		So(vH32.Size(), ShouldEqual, 3)
+		So(vH32.Sum([]byte{0xAA}), ShouldResemble, []byte{0xAA, 0x21, 0xCF, 0x02})
+		vH32.Reset()
+		So(vH32.Sum32(), ShouldEqual, Checksum(nil, MakeTable(CRC24_OPENPGP)))
+
+		vStd := crc32.NewIEEE()
+		vStd.Write(vData)
+		vH32 = New32(MakeTable(TAlgo[uint32]{0x04C11DB7, 0xFFFFFFFF, true, true, 0xFFFFFFFF, 0xCBF43926, "CRC-32/ISO-HDLC", 32}))
+		vH32.Write(vData)
+		So(vH32.Sum(nil), ShouldResemble, vStd.Sum(nil))
+		So(vH32.BlockSize(), ShouldEqual, 1)
+
+		vH64 := New64(MakeTable(TAlgo[uint64]{0x42F0E1EBA9EA3693, ^uint64(0), true, true, ^uint64(0), 0x995DC9BBDF1939FA, "CRC-64/XZ", 64}))
+		vH64.Write(vData)
+		So(vH64.Sum64(), ShouldEqual, uint64(0x995DC9BBDF1939FA))
+		So(vH64.Size(), ShouldEqual, 8)
+	})
+}
+
+//--------------------------------------
+
+// Reports whether Combine, ExtendZeros and ChecksumRepeat agree with Checksum for the algorithm.
+func combines[T TWord](aAlgo TAlgo[T]) bool {
+	vTable := MakeTable(aAlgo)
+	vData := []byte("The quick brown fox jumps over the lazy dog")
+	for _, n := range []int{0, 1, 9, len(vData)} {
+		vSum1, vSum2 := Checksum(vData[:n], vTable), Checksum(vData[n:], vTable)
+		if Combine(vSum1, vSum2, int64(len(vData)-n), vTable) != Checksum(vData, vTable) {
+			return false
+		}
+	}
+	for _, n := range []int64{0, 1, 100} {
+		vExtended := append(append([]byte(nil), vData...), make([]byte, n)...)
+		if ExtendZeros(Checksum(vData, vTable), n, vTable) != Checksum(vExtended, vTable) {
+			return false
+		}
+		if ChecksumRepeat(vData[:3], n, vTable) != Checksum(bytes.Repeat(vData[:3], int(n)), vTable) {
+			return false
+		}
+	}
+	return true
+}
+
+//--------------------------------------
+
+func TestCombine(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		So(combines(TAlgo[uint8]{Poly: 0x07}), ShouldBeTrue)
+		So(combines(CRC5_USB), ShouldBeTrue)
+		So(combines(CRC7_MMC), ShouldBeTrue)
+		So(combines(CRC12_UMTS), ShouldBeTrue)
+		So(combines(CRC15_CAN), ShouldBeTrue)
+		So(combines(TAlgo[uint16]{Poly: 0x1021, Init: 0xFFFF, RefIn: true, RefOut: true, XorOut: 0xFFFF}), ShouldBeTrue)
+		So(combines(CRC24_BLE), ShouldBeTrue)
+		So(combines(CRC24_OPENPGP), ShouldBeTrue)
+		So(combines(TAlgo[uint32]{0x04C11DB7, 0xFFFFFFFF, true, true, 0xFFFFFFFF, 0xCBF43926, "CRC-32/ISO-HDLC", 32}), ShouldBeTrue)
+		So(combines(TAlgo[uint64]{0x42F0E1EBA9EA3693, 0, false, false, 0, 0x6C40DF5F0B497347, "CRC-64/ECMA-182", 64}), ShouldBeTrue)
+
+		// Parts checksummed by hash/crc32 combine like with zlib's crc32_combine.
+		vTable := MakeTable(TAlgo[uint32]{Poly: 0x04C11DB7, Init: 0xFFFFFFFF, RefIn: true, RefOut: true, XorOut: 0xFFFFFFFF})
+		So(Combine(crc32.ChecksumIEEE([]byte("1234")), crc32.ChecksumIEEE([]byte("56789")), 5, vTable), ShouldEqual, uint32(0xCBF43926))
+	})
+}
+
+//--------------------------------------
+
+func TestReflected(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vIEEE := MakeTable(TAlgo[uint32]{Poly: 0x04C11DB7, Init: 0xFFFFFFFF, RefIn: true, RefOut: true, XorOut: 0xFFFFFFFF})
+		So(vIEEE.Reflected(), ShouldBeTrue)
+		So(vIEEE.Entries(), ShouldEqual, [256]uint32(*crc32.IEEETable))
+		vECMA := MakeTable(TAlgo[uint64]{Poly: 0x42F0E1EBA9EA3693, RefIn: true, RefOut: true})
+		So(vECMA.Entries(), ShouldEqual, [256]uint64(*crc64.MakeTable(crc64.ECMA)))
+		So(MakeTable(TAlgo[uint16]{Poly: 0x1021}).Reflected(), ShouldBeFalse)
+
+		// Either kernel yields the same register, up to its reflection.
+		vRand := rand.New(rand.NewSource(18))
+		vData := make([]byte, 64)
+		vRand.Read(vData)
+		vLeft, vRight := MakeEntries[uint16](0x8005), MakeEntriesReflected[uint16](0xA001)
+		vL, vR := uint16(0x1234), uint16(0x2C48)
+		for _, d := range vData {
+			vL, vR = Shift(vL, d, &vLeft), ShiftReflected(vR, bits.Reverse8(d), &vRight)
+			So(vR, ShouldEqual, bits.Reverse16(vL))
+		}
+	})
+}
+
+//--------------------------------------
+
+func TestMakeEntries(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		// The entries derived from the powers of two match those shifted out one by one.
+		for _, vPoly := range []uint32{0x04C11DB7, 0x1EDC6F41, 0x814141AB} {
+			vEntries := MakeEntries(vPoly)
+			for n := range vEntries {
+				crc := uint32(n) << 24
+				for range 8 {
+					crc = shiftMSB(crc, 0x80000000, vPoly)
+				}
+				So(vEntries[n], ShouldEqual, crc)
+			}
+		}
+		vEntries := MakeEntriesReflected[uint8](0x8C)
+		for n := range vEntries {
+			crc := uint8(n)
+			for range 8 {
+				crc = shiftLSB(crc, 0x8C)
+			}
+			So(vEntries[n], ShouldEqual, crc)
+		}
+	})
+}
+
+//-----------------------------------------------------------------------------
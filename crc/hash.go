@@ -0,0 +1,142 @@
+//-----------------------------------------------------------------------------
+
+package crc
+
+import "hash"
+
+//-----------------------------------------------------------------------------
+
+// This file contains the implementations of the go standard library hash.Hash
+// interfaces for the instantiations of the engine.
+
+// Hash8 is the common interface implemented by 8-bit CRC digests.
+type Hash8 interface {
+	hash.Hash
+	Sum8() uint8
+}
+
+// Hash16 is the common interface implemented by 16-bit CRC digests.
+type Hash16 interface {
+	hash.Hash
+	Sum16() uint16
+}
+
+type digest[T TWord] struct {
+	sum T
+	t   *TTable[T]
+}
+
+type digest8 struct{ digest[uint8] }
+type digest16 struct{ digest[uint16] }
+type digest32 struct{ digest[uint32] }
+type digest64 struct{ digest[uint64] }
+
+//-----------------------------------------------------------------------------
+
+// Write adds more data to the running digest.
+// It never returns an error.
+func (aH *digest[T]) Write(data []byte) (int, error) {
+	aH.sum = Update(aH.sum, data, aH.t)
+	return len(data), nil
+}
+
+//--------------------------------------
+
+// Sum appends the current digest (leftmost byte first, big-endian)
+// to b and returns the resulting slice.
+// It does not change the underlying digest state.
+func (aH *digest[T]) Sum(b []byte) []byte {
+	s := uint64(Complete(aH.sum, aH.t))
+	for i := aH.Size() - 1; i >= 0; i-- {
+		b = append(b, byte(s>>(8*i)))
+	}
+	return b
+}
+
+//--------------------------------------
+
+// Reset resets the Hash to its initial state.
+func (aH *digest[T]) Reset() {
+	aH.sum = Init(aH.t)
+}
+
+//--------------------------------------
+
+// Size returns the number of bytes Sum will return,
+// fewer than the register type holds for narrower algorithms.
+func (aH *digest[T]) Size() int {
+	return (Width[T]() - aH.t.pad + 7) / 8
+}
+
+//--------------------------------------
+
+// BlockSize returns the undelying block size.
+func (aH *digest[T]) BlockSize() int {
+	return 1
+}
+
+//--------------------------------------
+
+// Sum8 returns the CRC checksum.
+func (aH *digest8) Sum8() uint8 {
+	return Complete(aH.sum, aH.t)
+}
+
+//--------------------------------------
+
+// Sum16 returns the CRC checksum.
+func (aH *digest16) Sum16() uint16 {
+	return Complete(aH.sum, aH.t)
+}
+
+//--------------------------------------
+
+// Sum32 returns the CRC checksum.
+func (aH *digest32) Sum32() uint32 {
+	return Complete(aH.sum, aH.t)
+}
+
+//--------------------------------------
+
+// Sum64 returns the CRC checksum.
+func (aH *digest64) Sum64() uint64 {
+	return Complete(aH.sum, aH.t)
+}
+
+//-----------------------------------------------------------------------------
+
+// New8 creates a new digest for the given table with a register of up to 8 bits.
+func New8(t *TTable[uint8]) Hash8 {
+	aH := &digest8{digest[uint8]{t: t}}
+	aH.Reset()
+	return aH
+}
+
+//--------------------------------------
+
+// New16 creates a new digest for the given table with a register of up to 16 bits.
+func New16(t *TTable[uint16]) Hash16 {
+	aH := &digest16{digest[uint16]{t: t}}
+	aH.Reset()
+	return aH
+}
+
+//--------------------------------------
+
+// New32 creates a new digest for the given table with a register of up to 32 bits.
+func New32(t *TTable[uint32]) hash.Hash32 {
+	aH := &digest32{digest[uint32]{t: t}}
+	aH.Reset()
+	return aH
+}
+
+//--------------------------------------
+
+// New64 creates a new digest for the given table with a register of up to 64 bits.
+func New64(t *TTable[uint64]) hash.Hash64 {
+	aH := &digest64{digest[uint64]{t: t}}
+	aH.Reset()
+	return aH
+}
+
+//-----------------------------------------------------------------------------
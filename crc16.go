@@ -3,108 +3,295 @@
 // Package crc16 implements the 16-bit cyclic redundancy check, or CRC-16, checksum.
 //
 // It provides parameters for the majority of well-known CRC-16 algorithms.
+//
+// For TinyGo and bare-metal targets, the crc16_nibble build tag replaces the 512-byte
+// lookup tables with 32-byte ones processing four bits at a time, and the crc16_tiny tag
+// leaves out the facilities depending on fmt and encoding/binary, and so on reflection:
+// code generation, algorithm specs, trailers, firmware images, protocol framing,
+// resynchronization, connection and datagram framing and detection. The rest
+// of the package performs no allocation at initialization, e.g.
+//
+//	tinygo build -target=cortex-m-qemu -tags crc16_tiny,crc16_nibble
+//
+// The crc16_nocatalogue tag leaves out the predefined algorithms except the families
+// selected by the crc16_ccitt (polynomial 0x1021), crc16_ibm (0x8005) and crc16_misc
+// (all others) tags. Algorithms and the facilities searching the catalogue only see
+// the families built in, and protocol framing needs all of them.
 package crc16
 
-import "math/bits"
+import (
+	"context"
+	"io"
+	"math/bits"
+	"sync"
+
+	"github.com/mbsulliv/crc16/crc"
+)
 
 //-----------------------------------------------------------------------------
 
 // TAlgo represents parameters of CRC-16 algorithms.
+// Trailer records the byte order their checksums are conventionally transmitted in.
+// Width is the number of bits of the CRC, zero standing for 16. Narrower algorithms,
+// e.g. CRC-12/UMTS or CRC-15/CAN as predefined by package crc, keep their parameters
+// and checksums in the Width least significant bits; the register is kept in the most
+// significant bits by MakeTable, Init, Update, Complete and ChecksumBitwise. The other
+// facilities of the package work on 16-bit algorithms only.
 type TAlgo struct {
-	Poly   uint16
-	Init   uint16
-	RefIn  bool
-	RefOut bool
-	XorOut uint16
-	Check  uint16
-	Name   string
+	Poly    uint16
+	Init    uint16
+	RefIn   bool
+	RefOut  bool
+	XorOut  uint16
+	Check   uint16
+	Name    string
+	Trailer TTrailerOrder
+	Width   int
 }
 
-// Predefined CRC-16 algorithms.
-//
-// Related links:
-// - http://www.zlib.net/crc_v3.txt
-// - http://reveng.sourceforge.net/crc-catalogue/16.htm
-// - https://crccalc.com/?crc=123456789&method=CRC-16&datatype=ascii&outtype=hex
-var (
-	CRC16_DECT_R       = TAlgo{0x0589, 0x0000, false, false, 0x0001, 0x007E, "CRC-16/DECT-R"}
-	CRC16_DECT_X       = TAlgo{0x0589, 0x0000, false, false, 0x0000, 0x007F, "CRC-16/DECT-X"}
-	CRC16_NRSC_5       = TAlgo{0x080B, 0xFFFF, true, true, 0x0000, 0xA066, "CRC-16/NRSC-5"}
-	CRC16_GSM          = TAlgo{0x1021, 0x0000, false, false, 0xFFFF, 0xCE3C, "CRC-16/GSM"}
-	CRC16_KERMIT       = TAlgo{0x1021, 0x0000, true, true, 0x0000, 0x2189, "CRC-16/KERMIT"}
-	CRC16_XMODEM       = TAlgo{0x1021, 0x0000, false, false, 0x0000, 0x31C3, "CRC-16/XMODEM"}
-	CRC16_SPI_FUJITSU  = TAlgo{0x1021, 0x1D0F, false, false, 0x0000, 0xE5CC, "CRC-16/SPI-FUJITSU"}
-	CRC16_TMS37157     = TAlgo{0x1021, 0x89EC, true, true, 0x0000, 0x26B1, "CRC-16/TMS37157"}
-	CRC16_RIELLO       = TAlgo{0x1021, 0xB2AA, true, true, 0x0000, 0x63D0, "CRC-16/RIELLO"}
-	CRC16_CRC_A        = TAlgo{0x1021, 0xC6C6, true, true, 0x0000, 0xBF05, "CRC-16/CRC-A"}
-	CRC16_CCITT_FALSE  = TAlgo{0x1021, 0xFFFF, false, false, 0x0000, 0x29B1, "CRC-16/CCITT-FALSE"}
-	CRC16_GENIBUS      = TAlgo{0x1021, 0xFFFF, false, false, 0xFFFF, 0xD64E, "CRC-16/GENIBUS"}
-	CRC16_IBM_3740     = TAlgo{0x1021, 0xFFFF, false, false, 0x0000, 0x29B1, "CRC-16/IBM-3740"}
-	CRC16_IBM_SDLC     = TAlgo{0x1021, 0xFFFF, true, true, 0xFFFF, 0x906E, "CRC-16/IBM-SDLC"}
-	CRC16_MCRF4XX      = TAlgo{0x1021, 0xFFFF, true, true, 0x0000, 0x6F91, "CRC-16/MCRF4XX"}
-	CRC16_X_25         = TAlgo{0x1021, 0xFFFF, true, true, 0xFFFF, 0x906E, "CRC-16/X-25"}
-	CRC16_PROFIBUS     = TAlgo{0x1DCF, 0xFFFF, false, false, 0xFFFF, 0xA819, "CRC-16/PROFIBUS"}
-	CRC16_DNP          = TAlgo{0x3D65, 0x0000, true, true, 0xFFFF, 0xEA82, "CRC-16/DNP"}
-	CRC16_EN_13757     = TAlgo{0x3D65, 0x0000, false, false, 0xFFFF, 0xC2B7, "CRC-16/EN-13757"}
-	CRC16_OPENSAFETY_A = TAlgo{0x5935, 0x0000, false, false, 0x0000, 0x5D38, "CRC-16/OPENSAFETY-A"}
-	CRC16_M17          = TAlgo{0x5935, 0xFFFF, false, false, 0x0000, 0x772B, "CRC-16/M17"}
-	CRC16_LJ1200       = TAlgo{0x6F63, 0x0000, false, false, 0x0000, 0xBDF4, "CRC-16/LJ1200"}
-	CRC16_OPENSAFETY_B = TAlgo{0x755B, 0x0000, false, false, 0x0000, 0x20FE, "CRC-16/OPENSAFETY-B"}
-	CRC16_ARC          = TAlgo{0x8005, 0x0000, true, true, 0x0000, 0xBB3D, "CRC-16/ARC"}
-	CRC16_BUYPASS      = TAlgo{0x8005, 0x0000, false, false, 0x0000, 0xFEE8, "CRC-16/BUYPASS"}
-	CRC16_MAXIM        = TAlgo{0x8005, 0x0000, true, true, 0xFFFF, 0x44C2, "CRC-16/MAXIM"}
-	CRC16_UMTS         = TAlgo{0x8005, 0x0000, false, false, 0x0000, 0xFEE8, "CRC-16/UMTS"}
-	CRC16_DDS_110      = TAlgo{0x8005, 0x800D, false, false, 0x0000, 0x9ECF, "CRC-16/DDS-110"}
-	CRC16_CMS          = TAlgo{0x8005, 0xFFFF, false, false, 0x0000, 0xAEE7, "CRC-16/CMS"}
-	CRC16_MODBUS       = TAlgo{0x8005, 0xFFFF, true, true, 0x0000, 0x4B37, "CRC-16/MODBUS"}
-	CRC16_USB          = TAlgo{0x8005, 0xFFFF, true, true, 0xFFFF, 0xB4C8, "CRC-16/USB"}
-	CRC16_T10_DIF      = TAlgo{0x8BB7, 0x0000, false, false, 0x0000, 0xD0DB, "CRC-16/T10-DIF"}
-	CRC16_TELEDISK     = TAlgo{0xA097, 0x0000, false, false, 0x0000, 0x0FB3, "CRC-16/TELEDISK"}
-	CRC16_CDMA2000     = TAlgo{0xC867, 0xFFFF, false, false, 0x0000, 0x4C06, "CRC-16/CDMA2000"}
+// TTrailerOrder is the byte order of checksum trailers.
+type TTrailerOrder byte
+
+const (
+	// TrailerAuto is little-endian for algorithms with reflected output, as used by
+	// e.g. Modbus, and big-endian otherwise.
+	TrailerAuto TTrailerOrder = iota
+	TrailerLittleEndian
+	TrailerBigEndian
 )
 
 // TTable is a 256-word table representing polinomial and algorithm settings for efficient processing.
+// Built with the crc16_nibble tag, it holds 16 words instead and processes four bits at a time.
 type TTable struct {
-	algo TAlgo
-	data [256]uint16
+	algo      TAlgo
+	data      tTableData
+	reflected tReflectedData
+	seal      uint16
+	slices    tSlices
 }
 
+// The size of the buffer ChecksumReader reads streams with.
+const cReadChunk = 32 << 10
+
+// tables holds the tables returned by Table, as functions building them once, by algorithm.
+var tables sync.Map
+
 //-----------------------------------------------------------------------------
 
 // MakeTable returns the TTable constructed from the specified algorithm.
+// It panics if the Width of the algorithm is negative or exceeds 16 bits.
 func MakeTable(aAlgo TAlgo) *TTable {
 	vTable := new(TTable)
 	vTable.algo = aAlgo
-	for n := 0; n < 256; n++ {
-		crc := uint16(n) << 8
-		for i := 0; i < 8; i++ {
-			bit := (crc & 0x8000) != 0
-			crc <<= 1
-			if bit {
-				crc ^= aAlgo.Poly
-			}
-		}
-		vTable.data[n] = crc
+	vTable.data.build(aAlgo.Poly << aAlgo.pad())
+	vTable.reflected.build(&aAlgo)
+	vTable.seal = vTable.digest()
+	return vTable
+}
+
+//--------------------------------------
+
+// MakeTablePrecomputed returns the TTable of the specified algorithm with entries computed
+// beforehand, as declared by the source generated with crc16gen -table, so that no table
+// is built at startup. The entries must number 16 in builds with the crc16_nibble tag and
+// 256 in the others. For algorithms with reflected input, aReflected holds the entries
+// shifting the register least significant bit first; it is computed if nil and ignored
+// by nibble builds. The entries are not verified against the algorithm.
+func MakeTablePrecomputed(aAlgo TAlgo, aEntries []uint16, aReflected *[256]uint16) *TTable {
+	vTable := new(TTable)
+	if len(aEntries) != len(vTable.data) {
+		panic("crc16: precomputed table entries do not match the build")
 	}
+	vTable.algo = aAlgo
+	copy(vTable.data[:], aEntries)
+	vTable.reflected.set(&aAlgo, aReflected)
+	vTable.seal = vTable.digest()
 	return vTable
 }
 
 //--------------------------------------
 
-// Init returns the initial value for CRC register corresponding to the specified algorithm.
+// Table returns the table of the specified algorithm, built on first use and shared
+// by all callers afterwards, so that packages using the same algorithm do not build
+// a table each. The table must not be modified, e.g. by UnmarshalBinary.
+func Table(aAlgo TAlgo) *TTable {
+	if v, vFound := tables.Load(aAlgo); vFound {
+		return v.(func() *TTable)()
+	}
+	v, _ := tables.LoadOrStore(aAlgo, sync.OnceValue(func() *TTable { return MakeTable(aAlgo) }))
+	return v.(func() *TTable)()
+}
+
+//--------------------------------------
+
+// Reports whether two algorithms compute the same checksums regardless of their names.
+func sameParams(a, b *TAlgo) bool {
+	return a.Poly == b.Poly && a.Init == b.Init && a.RefIn == b.RefIn &&
+		a.RefOut == b.RefOut && a.XorOut == b.XorOut && a.pad() == b.pad()
+}
+
+//--------------------------------------
+
+// Returns the number of bits below the Width of the algorithm in the register, which
+// holds the CRC in its most significant bits. It panics if the Width is invalid.
+func (aAlgo *TAlgo) pad() int {
+	if aAlgo.Width < 0 || aAlgo.Width > 16 {
+		panic("crc16: invalid width")
+	}
+	if aAlgo.Width == 0 {
+		return 0
+	}
+	return 16 - aAlgo.Width
+}
+
+//--------------------------------------
+
+// Returns the checksum of the register crc, aligned to the least significant bit.
+func (aAlgo *TAlgo) complete(crc uint16) uint16 {
+	if aAlgo.RefOut {
+		// The pad bits are zero, so the reversed register is aligned to the least significant bit.
+		return bits.Reverse16(crc) ^ aAlgo.XorOut
+	}
+	return crc>>aAlgo.pad() ^ aAlgo.XorOut
+}
+
+//--------------------------------------
+
+// LittleEndian reports whether checksums of the algorithm are conventionally transmitted
+// least significant byte first.
+func (aAlgo *TAlgo) LittleEndian() bool {
+	if aAlgo.Trailer == TrailerAuto {
+		return aAlgo.RefOut
+	}
+	return aAlgo.Trailer == TrailerLittleEndian
+}
+
+//--------------------------------------
+
+// Residue returns the residue of the algorithm as listed in the CRC RevEng catalogue: the register
+// after a message followed by its checksum, transmitted in the order the register is shifted,
+// reflected for algorithms with reflected output but without the final XorOut. It is the same
+// for all messages, being XorOut times x^Width modulo the polynomial.
+func (aAlgo *TAlgo) Residue() uint16 {
+	vPad := aAlgo.pad()
+	vXorOut := aAlgo.XorOut
+	if aAlgo.RefOut {
+		vXorOut = bits.Reverse16(vXorOut) >> vPad
+	}
+	vRet := aAlgo.shiftZeros(vXorOut)
+	if aAlgo.RefOut {
+		vRet = bits.Reverse16(vRet) >> vPad
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Returns v, a value of Width bits, times x^Width modulo the polynomial.
+func (aAlgo *TAlgo) shiftZeros(v uint16) uint16 {
+	vPad := aAlgo.pad()
+	vPoly := aAlgo.Poly << vPad
+	v <<= vPad
+	for range 16 - vPad {
+		v = v<<1 ^ vPoly&-(v>>15)
+	}
+	return v >> vPad
+}
+
+//--------------------------------------
+
+// Generic returns the parameters of the algorithm for the generic engine of package crc,
+// so that CRC-16 algorithms can be handled alongside other widths with the same API.
+func (aAlgo *TAlgo) Generic() crc.TAlgo[uint16] {
+	return crc.TAlgo[uint16]{Poly: aAlgo.Poly, Init: aAlgo.Init, RefIn: aAlgo.RefIn, RefOut: aAlgo.RefOut,
+		XorOut: aAlgo.XorOut, Check: aAlgo.Check, Name: aAlgo.Name, Width: aAlgo.Width}
+}
+
+//--------------------------------------
+
+// Returns the digest of the parameters and the entries of the table, computed without it.
+func (aTable *TTable) digest() uint16 {
+	a := &aTable.algo
+	vFlags := uint16(a.Trailer) << 2
+	if a.RefIn {
+		vFlags |= 1
+	}
+	if a.RefOut {
+		vFlags |= 2
+	}
+	vReg := uint16(0xFFFF)
+	for _, v := range [...]uint16{a.Poly, a.Init, vFlags, a.XorOut, a.Check, uint16(a.Width)} {
+		vReg = shiftBitwise(vReg, v, 0x1021)
+	}
+	for _, e := range aTable.data {
+		vReg = shiftBitwise(vReg, e, 0x1021)
+	}
+	for _, e := range aTable.reflected.entries() {
+		vReg = shiftBitwise(vReg, e, 0x1021)
+	}
+	return vReg
+}
+
+//--------------------------------------
+
+// Validate returns ErrTableCorrupt if the parameters or the entries of the table changed
+// since it was constructed, e.g. by corruption of the memory holding it, or the tables of
+// the slicing and folding engines changed since they were built. Long-running
+// safety-critical systems can call it periodically, as required by e.g. IEC 61508.
+func (aTable *TTable) Validate() error {
+	if aTable.digest() != aTable.seal || !aTable.slices.valid() {
+		return ErrTableCorrupt
+	}
+	return nil
+}
+
+//--------------------------------------
+
+// Algo returns the algorithm the table was constructed from.
+func (aTable *TTable) Algo() TAlgo {
+	return aTable.algo
+}
+
+//--------------------------------------
+
+// Entries returns a copy of the 256 lookup table entries.
+// They are computed on demand when the table is built with the crc16_nibble tag.
+func (aTable *TTable) Entries() [256]uint16 {
+	var vRet [256]uint16
+	for n := range vRet {
+		vRet[n] = aTable.data.shift(0, byte(n))
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Init returns the initial value for CRC register corresponding to the specified algorithm,
+// the Init of algorithms narrower than 16 bits being shifted to the most significant bits.
 func Init(aTable *TTable) uint16 {
-	return aTable.algo.Init
+	return aTable.algo.Init << aTable.algo.pad()
 }
 
 //--------------------------------------
 
 // Update returns the result of adding the bytes in data to the crc.
+// Long inputs are processed by the slicing engine, see CalibrateEngines.
 func Update(crc uint16, data []byte, aTable *TTable) uint16 {
+	if len(data) >= slicingMin {
+		crc, data = aTable.slices.update(crc, data, aTable, foldEnabled)
+	}
+	return updateBytes(crc, data, aTable)
+}
+
+//--------------------------------------
+
+// Returns the result of adding the bytes in data to the crc a byte at a time.
+func updateBytes(crc uint16, data []byte, aTable *TTable) uint16 {
+	if aTable.algo.RefIn {
+		return aTable.reflected.update(crc, data, aTable)
+	}
 	for _, d := range data {
-		if aTable.algo.RefIn {
-			d = bits.Reverse8(d)
-		}
-		crc = crc<<8 ^ aTable.data[byte(crc>>8)^d]
+		crc = aTable.data.shift(crc, d)
 	}
 	return crc
 }
@@ -113,19 +300,78 @@ func Update(crc uint16, data []byte, aTable *TTable) uint16 {
 
 // Complete returns the result of CRC calculation and post-calculation processing of the crc.
 func Complete(crc uint16, aTable *TTable) uint16 {
-	if aTable.algo.RefOut {
-		return bits.Reverse16(crc) ^ aTable.algo.XorOut
-	}
-	return crc ^ aTable.algo.XorOut
+	return aTable.algo.complete(crc)
 }
 
 //--------------------------------------
 
 // Checksum returns CRC checksum of data using scpecified algorithm represented by the TTable.
+// It does not allocate.
 func Checksum(data []byte, aTable *TTable) uint16 {
 	crc := Init(aTable)
 	crc = Update(crc, data, aTable)
 	return Complete(crc, aTable)
 }
 
+//--------------------------------------
+
+// ChecksumReader returns the checksum of the content of r, read until EOF through a buffer
+// of its own, and the number of bytes read. On a read error it returns the error and
+// the number of bytes read before it.
+func ChecksumReader(r io.Reader, aTable *TTable) (uint16, int64, error) {
+	crc, vLen, err := updateReader(Init(aTable), r, aTable)
+	if err != nil {
+		return 0, vLen, err
+	}
+	return Complete(crc, aTable), vLen, nil
+}
+
+//--------------------------------------
+
+// ChecksumReaderContext is ChecksumReader checking ctx for cancellation before reading
+// each chunk and calling progress, unless nil, with the number of bytes read so far after
+// each chunk. On cancellation it returns the context's error and the number of bytes read
+// before it.
+func ChecksumReaderContext(ctx context.Context, r io.Reader, aTable *TTable, progress func(n int64)) (uint16, int64, error) {
+	vBuf := make([]byte, cReadChunk)
+	crc := Init(aTable)
+	var vLen int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return 0, vLen, err
+		}
+		n, err := r.Read(vBuf)
+		crc = Update(crc, vBuf[:n], aTable)
+		vLen += int64(n)
+		if n > 0 && progress != nil {
+			progress(vLen)
+		}
+		if err == io.EOF {
+			return Complete(crc, aTable), vLen, nil
+		}
+		if err != nil {
+			return 0, vLen, err
+		}
+	}
+}
+
+//--------------------------------------
+
+// Returns crc updated with the content of r, read until EOF, and the number of bytes read.
+func updateReader(crc uint16, r io.Reader, aTable *TTable) (uint16, int64, error) {
+	vBuf := make([]byte, cReadChunk)
+	var vLen int64
+	for {
+		n, err := r.Read(vBuf)
+		crc = Update(crc, vBuf[:n], aTable)
+		vLen += int64(n)
+		if err == io.EOF {
+			return crc, vLen, nil
+		}
+		if err != nil {
+			return crc, vLen, err
+		}
+	}
+}
+
 //-----------------------------------------------------------------------------
@@ -1,30 +1,27 @@
 //-----------------------------------------------------------------------------
 
+//go:build !crc16_nocatalogue || (crc16_ccitt && crc16_ibm && crc16_misc)
+
 package crc16
 
 import (
+	"bytes"
+	"context"
 	"fmt"
-	"path"
-	"runtime"
+	"hash"
+	"io"
+	"strings"
+	"sync"
 	"testing"
+	"testing/iotest"
+
+	"github.com/mbsulliv/crc16/crc"
 
 	. "github.com/smartystreets/goconvey/convey"
 )
 
 //-----------------------------------------------------------------------------
 
-// Returns the function name of the calling function.
-func funcName() string {
-	vRet := "?"
-	vPc, _, _, vOk := runtime.Caller(1)
-	if vOk {
-		vRet = path.Base(runtime.FuncForPC(vPc).Name())
-	}
-	return vRet
-}
-
-//-----------------------------------------------------------------------------
-
 func TestMain(aT *testing.T) {
 	vCases := []struct {
 		Algo *TAlgo
@@ -38,7 +35,7 @@ func TestMain(aT *testing.T) {
 		{&CRC16_SPI_FUJITSU},
 		{&CRC16_TMS37157},
 		{&CRC16_RIELLO},
-		{&CRC16_CRC_A},
+		{&CRC16_ISO_IEC_14443_3_A},
 		{&CRC16_CCITT_FALSE},
 		{&CRC16_GENIBUS},
 		{&CRC16_IBM_3740},
@@ -54,7 +51,7 @@ func TestMain(aT *testing.T) {
 		{&CRC16_OPENSAFETY_B},
 		{&CRC16_ARC},
 		{&CRC16_BUYPASS},
-		{&CRC16_MAXIM},
+		{&CRC16_MAXIM_DOW},
 		{&CRC16_UMTS},
 		{&CRC16_DDS_110},
 		{&CRC16_CMS},
@@ -102,9 +99,304 @@ func TestHash(aT *testing.T) {
 		So(len(vBuf), ShouldEqual, 2)
 		So(vBuf[0], ShouldEqual, vExpected[0])
 		So(vBuf[1], ShouldEqual, vExpected[1])
+		So(vH.(LittleEndianSummer).SumLE(nil), ShouldResemble, []byte{0x98, 0xe6})
 
 		So(vH.BlockSize(), ShouldEqual, 1)
 	})
 }
 
+//--------------------------------------
+
+func TestValidate(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(TAlgo{Poly: 0x8005, Init: 0xFFFF, RefIn: true, RefOut: true})
+		So(vTable.Validate(), ShouldBeNil)
+
+		vTable.data[len(vTable.data)-1] ^= 0x0400
+		So(vTable.Validate(), ShouldEqual, ErrTableCorrupt)
+		vTable.data[len(vTable.data)-1] ^= 0x0400
+		So(vTable.Validate(), ShouldBeNil)
+		if vEntries := vTable.reflected.entries(); vEntries != nil {
+			vEntries[7] ^= 1
+			So(vTable.Validate(), ShouldEqual, ErrTableCorrupt)
+			vEntries[7] ^= 1
+		}
+
+		vTable.algo.XorOut ^= 1
+		So(vTable.Validate(), ShouldEqual, ErrTableCorrupt)
+		vTable.algo.XorOut ^= 1
+		vTable.seal ^= 0x8000
+		So(vTable.Validate(), ShouldEqual, ErrTableCorrupt)
+	})
+}
+
+//--------------------------------------
+
+func TestMakeTablePrecomputed(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		for _, vAlgo := range []TAlgo{CRC16_MODBUS, CRC16_XMODEM, CRC16_CMS} {
+			vRef := MakeTable(vAlgo)
+			vTable := MakeTablePrecomputed(vAlgo, vRef.data[:], nil)
+			So(vTable.Validate(), ShouldBeNil)
+			So(vTable.seal, ShouldEqual, vRef.seal)
+			So(Checksum([]byte("123456789"), vTable), ShouldEqual, vAlgo.Check)
+		}
+
+		So(func() { MakeTablePrecomputed(CRC16_MODBUS, make([]uint16, 8), nil) }, ShouldPanic)
+	})
+}
+
+//--------------------------------------
+
+func TestTable(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := Table(CRC16_MODBUS)
+		So(Table(CRC16_MODBUS), ShouldPointTo, vTable)
+		So(Table(CRC16_XMODEM), ShouldNotPointTo, vTable)
+		So(Checksum([]byte("123456789"), vTable), ShouldEqual, CRC16_MODBUS.Check)
+		So(vTable.Validate(), ShouldBeNil)
+
+		vAlgo := TAlgo{Poly: 0x3D65, Init: 0x1234, Name: "shared"}
+		var vTables [8]*TTable
+		var vWg sync.WaitGroup
+		for i := range vTables {
+			vWg.Add(1)
+			go func() {
+				defer vWg.Done()
+				vTables[i] = Table(vAlgo)
+			}()
+		}
+		vWg.Wait()
+		for _, t := range vTables {
+			So(t, ShouldPointTo, vTables[0])
+		}
+	})
+}
+
+//--------------------------------------
+
+func TestGeneric(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		for a := range predefined() {
+			vTable := crc.MakeTable(a.Generic())
+			So(crc.Checksum([]byte("123456789"), vTable), ShouldEqual, a.Check)
+			So(vTable.Algo().Name, ShouldEqual, a.Name)
+		}
+	})
+}
+
+//--------------------------------------
+
+func TestResidue(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		// Residues of the CRC RevEng catalogue.
+		for _, c := range []struct {
+			algo    TAlgo
+			residue uint16
+		}{
+			{CRC16_X_25, 0xF0B8}, {CRC16_GENIBUS, 0x1D0F}, {CRC16_DNP, 0x66C5}, {CRC16_EN_13757, 0xA366},
+			{CRC16_MAXIM_DOW, 0xB001}, {CRC16_USB, 0xB001}, {CRC16_DECT_R, 0x0589}, {CRC16_PROFIBUS, 0xE394},
+			{CRC16_MODBUS, 0x0000}, {CRC16_XMODEM, 0x0000},
+		} {
+			So(c.algo.Residue(), ShouldEqual, c.residue)
+		}
+	})
+}
+
+//--------------------------------------
+
+func TestChecksumReader(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_KERMIT)
+		vData := make([]byte, 2*cReadChunk+100)
+		for i := range vData {
+			vData[i] = byte(i * 13)
+		}
+		for _, r := range []io.Reader{
+			bytes.NewReader(vData),
+			iotest.OneByteReader(bytes.NewReader(vData)),
+			iotest.DataErrReader(bytes.NewReader(vData)),
+		} {
+			vSum, vLen, err := ChecksumReader(r, vTable)
+			So(err, ShouldBeNil)
+			So(vLen, ShouldEqual, len(vData))
+			So(vSum, ShouldEqual, Checksum(vData, vTable))
+		}
+
+		vSum, vLen, err := ChecksumReader(strings.NewReader(""), vTable)
+		So(err, ShouldBeNil)
+		So(vLen, ShouldEqual, 0)
+		So(vSum, ShouldEqual, Checksum(nil, vTable))
+
+		_, vLen, err = ChecksumReader(io.MultiReader(bytes.NewReader(vData[:10]), iotest.ErrReader(iotest.ErrTimeout)), vTable)
+		So(err, ShouldEqual, iotest.ErrTimeout)
+		So(vLen, ShouldEqual, 10)
+	})
+}
+
+//--------------------------------------
+
+func TestHashReadFrom(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_XMODEM)
+		vH := New(vTable)
+		_, vOk := vH.(io.ReaderFrom)
+		So(vOk, ShouldBeTrue)
+
+		vData := make([]byte, cReadChunk+100)
+		for i := range vData {
+			vData[i] = byte(i * 31)
+		}
+		vH.Write(vData[:7])
+		n, err := io.Copy(vH, iotest.HalfReader(bytes.NewReader(vData[7:])))
+		So(err, ShouldBeNil)
+		So(n, ShouldEqual, len(vData)-7)
+		So(vH.Sum16(), ShouldEqual, Checksum(vData, vTable))
+
+		vH.Reset()
+		n, err = io.Copy(vH, io.MultiReader(bytes.NewReader(vData[:10]), iotest.ErrReader(iotest.ErrTimeout)))
+		So(err, ShouldEqual, iotest.ErrTimeout)
+		So(n, ShouldEqual, 10)
+		So(vH.Sum16(), ShouldEqual, Checksum(vData[:10], vTable))
+	})
+}
+
+//--------------------------------------
+
+func TestHashWriteByte(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_KERMIT)
+		for _, vH := range []Hash16{New(vTable), NewText(vTable)} {
+			_, vOk := vH.(io.ByteWriter)
+			So(vOk, ShouldBeTrue)
+			_, vOk = vH.(io.StringWriter)
+			So(vOk, ShouldBeTrue)
+
+			for _, b := range []byte("12345") {
+				So(vH.(io.ByteWriter).WriteByte(b), ShouldBeNil)
+			}
+			vH.(io.StringWriter).WriteString("6789")
+			So(vH.Sum16(), ShouldEqual, CRC16_KERMIT.Check)
+			So(testing.AllocsPerRun(100, func() { vH.(io.ByteWriter).WriteByte('x') }), ShouldEqual, 0)
+		}
+	})
+}
+
+//--------------------------------------
+
+func TestHashClone(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_XMODEM)
+		for _, vH := range []Hash16{New(vTable), NewText(vTable)} {
+			io.WriteString(vH, "1234\r")
+			vClone := vH.(Cloner).Clone()
+			io.WriteString(vH, "\n56789")
+			io.WriteString(vClone, "56789")
+			So(vH.Sum16(), ShouldNotEqual, vClone.Sum16())
+			So(vClone.Sum16(), ShouldEqual, ChecksumString("1234\r56789", vTable))
+		}
+		vH := NewText(vTable)
+		io.WriteString(vH, "a\r")
+		vClone := vH.(Cloner).Clone()
+		io.WriteString(vClone, "\n")
+		So(vClone.Sum16(), ShouldEqual, ChecksumString("a\n", vTable))
+		So(vH.Sum16(), ShouldEqual, ChecksumString("a\r", vTable))
+	})
+}
+
+//--------------------------------------
+
+func TestHashPool(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_XMODEM)
+		vPool := NewHashPool(vTable)
+		vH := vPool.Get()
+		io.WriteString(vH, "123456789")
+		So(vH.Sum16(), ShouldEqual, CRC16_XMODEM.Check)
+		vPool.Put(vH)
+		vH = vPool.Get()
+		So(vH.Sum16(), ShouldEqual, Checksum(nil, vTable))
+
+		So(func() { vPool.Put(New(MakeTable(CRC16_KERMIT))) }, ShouldPanic)
+		So(func() { vPool.Put(NewText(vTable)) }, ShouldPanic)
+
+		vData := []byte("123456789")
+		So(testing.AllocsPerRun(100, func() { Checksum(vData, vTable) }), ShouldEqual, 0)
+	})
+}
+
+//--------------------------------------
+
+func TestChecksumReaderContext(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_KERMIT)
+		vData := make([]byte, 2*cReadChunk+100)
+		for i := range vData {
+			vData[i] = byte(i * 13)
+		}
+		var vProgress []int64
+		vSum, vLen, err := ChecksumReaderContext(context.Background(), bytes.NewReader(vData), vTable, func(n int64) {
+			vProgress = append(vProgress, n)
+		})
+		So(err, ShouldBeNil)
+		So(vLen, ShouldEqual, len(vData))
+		So(vSum, ShouldEqual, Checksum(vData, vTable))
+		So(vProgress, ShouldResemble, []int64{cReadChunk, 2 * cReadChunk, int64(len(vData))})
+
+		vSum, vLen, err = ChecksumReaderContext(context.Background(), strings.NewReader(""), vTable, nil)
+		So(err, ShouldBeNil)
+		So(vLen, ShouldEqual, 0)
+		So(vSum, ShouldEqual, Checksum(nil, vTable))
+
+		vCtx, vCancel := context.WithCancel(context.Background())
+		_, vLen, err = ChecksumReaderContext(vCtx, bytes.NewReader(vData), vTable, func(n int64) {
+			vCancel()
+		})
+		So(err, ShouldEqual, context.Canceled)
+		So(vLen, ShouldEqual, cReadChunk)
+	})
+}
+
+//--------------------------------------
+
+func TestHashWide(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_MODBUS)
+		vData := []byte("123456789")
+
+		h32 := NewHash32(vTable)
+		h32.Write(vData[:4])
+		io.WriteString(h32, string(vData[4:]))
+		So(h32.Sum32(), ShouldEqual, uint32(CRC16_MODBUS.Check))
+		So(h32.Size(), ShouldEqual, 4)
+		So(h32.Sum([]byte{0xAA}), ShouldResemble, []byte{0xAA, 0, 0, byte(CRC16_MODBUS.Check >> 8), byte(CRC16_MODBUS.Check)})
+		h32.Reset()
+		So(h32.Sum32(), ShouldEqual, uint32(Checksum(nil, vTable)))
+
+		h64 := NewHash64(vTable)
+		h64.Write(vData)
+		So(h64.Sum64(), ShouldEqual, uint64(CRC16_MODBUS.Check))
+		So(h64.Size(), ShouldEqual, 8)
+		So(len(h64.Sum(nil)), ShouldEqual, 8)
+		vH := New(vTable)
+		vH.Write(vData)
+		So(h64.Sum(nil), ShouldResemble, vH.Sum(make([]byte, 6)))
+
+		// Clones keep the size of the original.
+		h32.Write(vData)
+		vClone32, vOk := h32.(Cloner).Clone().(hash.Hash32)
+		So(vOk, ShouldBeTrue)
+		So(vClone32.Size(), ShouldEqual, 4)
+		So(len(vClone32.Sum(nil)), ShouldEqual, 4)
+		So(vClone32.Sum32(), ShouldEqual, uint32(CRC16_MODBUS.Check))
+		So(len(h32.(LittleEndianSummer).SumLE(nil)), ShouldEqual, 4)
+		vClone64, vOk := h64.(Cloner).Clone().(hash.Hash64)
+		So(vOk, ShouldBeTrue)
+		So(vClone64.Size(), ShouldEqual, 8)
+		So(len(vClone64.Sum(nil)), ShouldEqual, 8)
+		So(vClone64.Sum64(), ShouldEqual, uint64(CRC16_MODBUS.Check))
+		So(h64.(LittleEndianSummer).SumLE(nil), ShouldResemble, []byte{byte(CRC16_MODBUS.Check), byte(CRC16_MODBUS.Check >> 8), 0, 0, 0, 0, 0, 0})
+	})
+}
+
 //-----------------------------------------------------------------------------
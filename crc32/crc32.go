@@ -0,0 +1,137 @@
+//-----------------------------------------------------------------------------
+
+// Package crc32 implements the 32-bit cyclic redundancy check, or CRC-32, checksum.
+//
+// It provides parameters for the majority of well-known CRC-32 algorithms
+// and instantiates the generic engine of package crc with 32-bit registers.
+package crc32
+
+import "github.com/mbsulliv/crc16/crc"
+
+//-----------------------------------------------------------------------------
+
+// TAlgo represents parameters of CRC-32 algorithms.
+type TAlgo struct {
+	Poly   uint32
+	Init   uint32
+	RefIn  bool
+	RefOut bool
+	XorOut uint32
+	Check  uint32
+	Name   string
+}
+
+// TTable is a 256-word table representing polinomial and algorithm settings for efficient processing.
+type TTable = crc.TTable[uint32]
+
+// Predefined CRC-32 algorithms.
+//
+// Related links:
+// - http://reveng.sourceforge.net/crc-catalogue/17plus.htm#crc.cat-bits.32
+var (
+	CRC32_XFER       = TAlgo{0x000000AF, 0x00000000, false, false, 0x00000000, 0xBD0BE338, "CRC-32/XFER"}
+	CRC32_CKSUM      = TAlgo{0x04C11DB7, 0x00000000, false, false, 0xFFFFFFFF, 0x765E7680, "CRC-32/CKSUM"}
+	CRC32_MPEG_2     = TAlgo{0x04C11DB7, 0xFFFFFFFF, false, false, 0x00000000, 0x0376E6E7, "CRC-32/MPEG-2"}
+	CRC32_BZIP2      = TAlgo{0x04C11DB7, 0xFFFFFFFF, false, false, 0xFFFFFFFF, 0xFC891918, "CRC-32/BZIP2"}
+	CRC32_JAMCRC     = TAlgo{0x04C11DB7, 0xFFFFFFFF, true, true, 0x00000000, 0x340BC6D9, "CRC-32/JAMCRC"}
+	CRC32_ISO_HDLC   = TAlgo{0x04C11DB7, 0xFFFFFFFF, true, true, 0xFFFFFFFF, 0xCBF43926, "CRC-32/ISO-HDLC"}
+	CRC32_ISCSI      = TAlgo{0x1EDC6F41, 0xFFFFFFFF, true, true, 0xFFFFFFFF, 0xE3069283, "CRC-32/ISCSI"}
+	CRC32_C          = TAlgo{0x1EDC6F41, 0xFFFFFFFF, true, true, 0xFFFFFFFF, 0xE3069283, "CRC-32C"}
+	CRC32_MEF        = TAlgo{0x741B8CD7, 0xFFFFFFFF, true, true, 0x00000000, 0xD2C22F51, "CRC-32/MEF"}
+	CRC32_CD_ROM_EDC = TAlgo{0x8001801B, 0x00000000, true, true, 0x00000000, 0x6EC2EDC4, "CRC-32/CD-ROM-EDC"}
+	CRC32_AIXM       = TAlgo{0x814141AB, 0x00000000, false, false, 0x00000000, 0x3010BF7F, "CRC-32/AIXM"}
+	CRC32_BASE91_D   = TAlgo{0xA833982B, 0xFFFFFFFF, true, true, 0xFFFFFFFF, 0x87315576, "CRC-32/BASE91-D"}
+	CRC32_AUTOSAR    = TAlgo{0xF4ACFB13, 0xFFFFFFFF, true, true, 0xFFFFFFFF, 0x1697D06A, "CRC-32/AUTOSAR"}
+)
+
+// The predefined algorithms in the catalogue order.
+var catalogue = []*TAlgo{
+	&CRC32_XFER,
+	&CRC32_CKSUM,
+	&CRC32_MPEG_2,
+	&CRC32_BZIP2,
+	&CRC32_JAMCRC,
+	&CRC32_ISO_HDLC,
+	&CRC32_ISCSI,
+	&CRC32_C,
+	&CRC32_MEF,
+	&CRC32_CD_ROM_EDC,
+	&CRC32_AIXM,
+	&CRC32_BASE91_D,
+	&CRC32_AUTOSAR,
+}
+
+//-----------------------------------------------------------------------------
+
+// MakeTable returns the TTable constructed from the specified algorithm.
+func MakeTable(aAlgo TAlgo) *TTable {
+	return crc.MakeTable(crc.TAlgo[uint32]{
+		Poly: aAlgo.Poly, Init: aAlgo.Init, RefIn: aAlgo.RefIn, RefOut: aAlgo.RefOut,
+		XorOut: aAlgo.XorOut, Check: aAlgo.Check, Name: aAlgo.Name,
+	})
+}
+
+//--------------------------------------
+
+// Algorithms returns the predefined algorithms in the catalogue order.
+func Algorithms() []TAlgo {
+	vRet := make([]TAlgo, len(catalogue))
+	for i, a := range catalogue {
+		vRet[i] = *a
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Init returns the initial value for CRC register corresponding to the specified algorithm.
+func Init(aTable *TTable) uint32 {
+	return crc.Init(aTable)
+}
+
+//--------------------------------------
+
+// Update returns the result of adding the bytes in data to the crc.
+func Update(crc32 uint32, data []byte, aTable *TTable) uint32 {
+	return crc.Update(crc32, data, aTable)
+}
+
+//--------------------------------------
+
+// Complete returns the result of CRC calculation and post-calculation processing of the crc.
+func Complete(crc32 uint32, aTable *TTable) uint32 {
+	return crc.Complete(crc32, aTable)
+}
+
+//--------------------------------------
+
+// Checksum returns CRC checksum of data using specified algorithm represented by the TTable.
+func Checksum(data []byte, aTable *TTable) uint32 {
+	return crc.Checksum(data, aTable)
+}
+
+//-----------------------------------------------------------------------------
+
+// Combine returns CRC checksum of the concatenation of two messages, given the checksum
+// aSum1 of the first one and the checksum aSum2 of the second one of aLen2 bytes.
+func Combine(aSum1, aSum2 uint32, aLen2 int64, aTable *TTable) uint32 {
+	return crc.Combine(aSum1, aSum2, aLen2, aTable)
+}
+
+//--------------------------------------
+
+// ExtendZeros returns CRC checksum of a message followed by n zero bytes,
+// given the checksum aSum of the message.
+func ExtendZeros(aSum uint32, n int64, aTable *TTable) uint32 {
+	return crc.ExtendZeros(aSum, n, aTable)
+}
+
+//--------------------------------------
+
+// ChecksumRepeat returns CRC checksum of pattern repeated count times
+// using the specified algorithm represented by the TTable.
+func ChecksumRepeat(pattern []byte, count int64, aTable *TTable) uint32 {
+	return crc.ChecksumRepeat(pattern, count, aTable)
+}
+
+//-----------------------------------------------------------------------------
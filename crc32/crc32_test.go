@@ -0,0 +1,78 @@
+//-----------------------------------------------------------------------------
+
+package crc32
+
+import (
+	"fmt"
+	"hash/crc32"
+	"math/bits"
+	"math/rand"
+	"path"
+	"runtime"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+// Returns the function name of the calling function.
+func funcName() string {
+	vRet := "?"
+	vPc, _, _, vOk := runtime.Caller(1)
+	if vOk {
+		vRet = path.Base(runtime.FuncForPC(vPc).Name())
+	}
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
+
+func TestCatalogue(aT *testing.T) {
+	vTestData := []byte("123456789")
+	for _, a := range Algorithms() {
+		Convey(fmt.Sprintf("%s: %s", funcName(), a.Name), aT, func() {
+			vTable := MakeTable(a)
+			So(fmt.Sprintf("0x%08X", Checksum(vTestData, vTable)), ShouldEqual, fmt.Sprintf("0x%08X", a.Check))
+			So(Complete(Update(Update(Init(vTable), vTestData[:4], vTable), vTestData[4:], vTable), vTable), ShouldEqual, a.Check)
+		})
+	}
+}
+
+//--------------------------------------
+
+func TestStdlib(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vIEEE, vC := MakeTable(CRC32_ISO_HDLC), MakeTable(CRC32_C)
+		vCastagnoli := crc32.MakeTable(crc32.Castagnoli)
+		vRand := rand.New(rand.NewSource(10))
+		for n := 0; n < 100; n++ {
+			vData := make([]byte, n)
+			vRand.Read(vData)
+			So(Checksum(vData, vIEEE), ShouldEqual, crc32.ChecksumIEEE(vData))
+			So(Checksum(vData, vC), ShouldEqual, crc32.Checksum(vData, vCastagnoli))
+		}
+
+		// CRC-32C with a nonstandard init, beyond what hash/crc32 offers.
+		vCustom := MakeTable(TAlgo{Poly: 0x1EDC6F41, Init: 0x12345678, RefIn: true, RefOut: true, XorOut: 0xFFFFFFFF})
+		So(Checksum(nil, vCustom), ShouldEqual, bits.Reverse32(0x12345678)^0xFFFFFFFF)
+	})
+}
+
+//--------------------------------------
+
+func TestHash(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vH := New(MakeTable(CRC32_C))
+		So(vH.Size(), ShouldEqual, 4)
+		vH.Write([]byte("1234"))
+		vH.Write([]byte("56789"))
+		So(vH.Sum32(), ShouldEqual, CRC32_C.Check)
+
+		vStd := crc32.New(crc32.MakeTable(crc32.Castagnoli))
+		vStd.Write([]byte("123456789"))
+		So(vH.Sum([]byte{0xAA}), ShouldResemble, vStd.Sum([]byte{0xAA}))
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,18 @@
+//-----------------------------------------------------------------------------
+
+package crc32
+
+import (
+	"hash"
+
+	"github.com/mbsulliv/crc16/crc"
+)
+
+//-----------------------------------------------------------------------------
+
+// New creates a new CRC32 digest for the given table.
+func New(t *TTable) hash.Hash32 {
+	return crc.New32(t)
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,126 @@
+//-----------------------------------------------------------------------------
+
+// Package crc64 implements the 64-bit cyclic redundancy check, or CRC-64, checksum.
+//
+// It provides parameters for the majority of well-known CRC-64 algorithms
+// and instantiates the generic engine of package crc with 64-bit registers.
+// It implements the golang hash.Hash64 interface.
+package crc64
+
+import "github.com/mbsulliv/crc16/crc"
+
+//-----------------------------------------------------------------------------
+
+// TAlgo represents parameters of CRC-64 algorithms.
+type TAlgo struct {
+	Poly   uint64
+	Init   uint64
+	RefIn  bool
+	RefOut bool
+	XorOut uint64
+	Check  uint64
+	Name   string
+}
+
+// TTable is a 256-word table representing polinomial and algorithm settings for efficient processing.
+type TTable = crc.TTable[uint64]
+
+// Predefined CRC-64 algorithms.
+//
+// Related links:
+// - http://reveng.sourceforge.net/crc-catalogue/17plus.htm#crc.cat-bits.64
+var (
+	CRC64_GO_ISO   = TAlgo{0x000000000000001B, 0xFFFFFFFFFFFFFFFF, true, true, 0xFFFFFFFFFFFFFFFF, 0xB90956C775A41001, "CRC-64/GO-ISO"}
+	CRC64_MS       = TAlgo{0x259C84CBA6426349, 0xFFFFFFFFFFFFFFFF, true, true, 0x0000000000000000, 0x75D4B74F024ECEEA, "CRC-64/MS"}
+	CRC64_ECMA_182 = TAlgo{0x42F0E1EBA9EA3693, 0x0000000000000000, false, false, 0x0000000000000000, 0x6C40DF5F0B497347, "CRC-64/ECMA-182"}
+	CRC64_WE       = TAlgo{0x42F0E1EBA9EA3693, 0xFFFFFFFFFFFFFFFF, false, false, 0xFFFFFFFFFFFFFFFF, 0x62EC59E3F1A4F00A, "CRC-64/WE"}
+	CRC64_XZ       = TAlgo{0x42F0E1EBA9EA3693, 0xFFFFFFFFFFFFFFFF, true, true, 0xFFFFFFFFFFFFFFFF, 0x995DC9BBDF1939FA, "CRC-64/XZ"}
+	CRC64_REDIS    = TAlgo{0xAD93D23594C935A9, 0x0000000000000000, true, true, 0x0000000000000000, 0xE9C6D914C4B8D9CA, "CRC-64/REDIS"}
+	CRC64_NVME     = TAlgo{0xAD93D23594C93659, 0xFFFFFFFFFFFFFFFF, true, true, 0xFFFFFFFFFFFFFFFF, 0xAE8B14860A799888, "CRC-64/NVME"}
+)
+
+// The predefined algorithms in the catalogue order.
+var catalogue = []*TAlgo{
+	&CRC64_GO_ISO,
+	&CRC64_MS,
+	&CRC64_ECMA_182,
+	&CRC64_WE,
+	&CRC64_XZ,
+	&CRC64_REDIS,
+	&CRC64_NVME,
+}
+
+//-----------------------------------------------------------------------------
+
+// MakeTable returns the TTable constructed from the specified algorithm.
+func MakeTable(aAlgo TAlgo) *TTable {
+	return crc.MakeTable(crc.TAlgo[uint64]{
+		Poly: aAlgo.Poly, Init: aAlgo.Init, RefIn: aAlgo.RefIn, RefOut: aAlgo.RefOut,
+		XorOut: aAlgo.XorOut, Check: aAlgo.Check, Name: aAlgo.Name,
+	})
+}
+
+//--------------------------------------
+
+// Algorithms returns the predefined algorithms in the catalogue order.
+func Algorithms() []TAlgo {
+	vRet := make([]TAlgo, len(catalogue))
+	for i, a := range catalogue {
+		vRet[i] = *a
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Init returns the initial value for CRC register corresponding to the specified algorithm.
+func Init(aTable *TTable) uint64 {
+	return crc.Init(aTable)
+}
+
+//--------------------------------------
+
+// Update returns the result of adding the bytes in data to the crc.
+func Update(crc64 uint64, data []byte, aTable *TTable) uint64 {
+	return crc.Update(crc64, data, aTable)
+}
+
+//--------------------------------------
+
+// Complete returns the result of CRC calculation and post-calculation processing of the crc.
+func Complete(crc64 uint64, aTable *TTable) uint64 {
+	return crc.Complete(crc64, aTable)
+}
+
+//--------------------------------------
+
+// Checksum returns CRC checksum of data using specified algorithm represented by the TTable.
+func Checksum(data []byte, aTable *TTable) uint64 {
+	return crc.Checksum(data, aTable)
+}
+
+//-----------------------------------------------------------------------------
+
+// Combine returns CRC checksum of the concatenation of two messages, given the checksum
+// aSum1 of the first one and the checksum aSum2 of the second one of aLen2 bytes.
+func Combine(aSum1, aSum2 uint64, aLen2 int64, aTable *TTable) uint64 {
+	return crc.Combine(aSum1, aSum2, aLen2, aTable)
+}
+
+//--------------------------------------
+
+// ExtendZeros returns CRC checksum of a message followed by n zero bytes,
+// given the checksum aSum of the message.
+func ExtendZeros(aSum uint64, n int64, aTable *TTable) uint64 {
+	return crc.ExtendZeros(aSum, n, aTable)
+}
+
+//--------------------------------------
+
+// ChecksumRepeat returns CRC checksum of pattern repeated count times
+// using the specified algorithm represented by the TTable.
+func ChecksumRepeat(pattern []byte, count int64, aTable *TTable) uint64 {
+	return crc.ChecksumRepeat(pattern, count, aTable)
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,78 @@
+//-----------------------------------------------------------------------------
+
+package crc64
+
+import (
+	"fmt"
+	"hash/crc64"
+	"math/rand"
+	"path"
+	"runtime"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+// Returns the function name of the calling function.
+func funcName() string {
+	vRet := "?"
+	vPc, _, _, vOk := runtime.Caller(1)
+	if vOk {
+		vRet = path.Base(runtime.FuncForPC(vPc).Name())
+	}
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
+
+func TestCatalogue(aT *testing.T) {
+	vTestData := []byte("123456789")
+	for _, a := range Algorithms() {
+		Convey(fmt.Sprintf("%s: %s", funcName(), a.Name), aT, func() {
+			vTable := MakeTable(a)
+			So(fmt.Sprintf("0x%016X", Checksum(vTestData, vTable)), ShouldEqual, fmt.Sprintf("0x%016X", a.Check))
+			So(Complete(Update(Update(Init(vTable), vTestData[:4], vTable), vTestData[4:], vTable), vTable), ShouldEqual, a.Check)
+		})
+	}
+}
+
+//--------------------------------------
+
+func TestStdlib(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vISO, vECMA := MakeTable(CRC64_GO_ISO), MakeTable(CRC64_XZ)
+		vStdISO, vStdECMA := crc64.MakeTable(crc64.ISO), crc64.MakeTable(crc64.ECMA)
+		vRand := rand.New(rand.NewSource(12))
+		for n := 0; n < 100; n++ {
+			vData := make([]byte, n)
+			vRand.Read(vData)
+			So(Checksum(vData, vISO), ShouldEqual, crc64.Checksum(vData, vStdISO))
+			So(Checksum(vData, vECMA), ShouldEqual, crc64.Checksum(vData, vStdECMA))
+		}
+	})
+}
+
+//--------------------------------------
+
+func TestHash(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vH := New(MakeTable(CRC64_XZ))
+		So(vH.Size(), ShouldEqual, 8)
+		So(vH.BlockSize(), ShouldEqual, 1)
+		vH.Write([]byte("1234"))
+		vH.Write([]byte("56789"))
+		So(vH.Sum64(), ShouldEqual, CRC64_XZ.Check)
+		So(vH.Sum([]byte{0xAA}), ShouldResemble, []byte{0xAA, 0x99, 0x5D, 0xC9, 0xBB, 0xDF, 0x19, 0x39, 0xFA})
+
+		vStd := crc64.New(crc64.MakeTable(crc64.ECMA))
+		vStd.Write([]byte("123456789"))
+		So(vH.Sum(nil), ShouldResemble, vStd.Sum(nil))
+
+		vH.Reset()
+		So(vH.Sum64(), ShouldEqual, Checksum(nil, MakeTable(CRC64_XZ)))
+	})
+}
+
+//-----------------------------------------------------------------------------
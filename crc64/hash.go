@@ -0,0 +1,18 @@
+//-----------------------------------------------------------------------------
+
+package crc64
+
+import (
+	"hash"
+
+	"github.com/mbsulliv/crc16/crc"
+)
+
+//-----------------------------------------------------------------------------
+
+// New creates a new CRC64 digest for the given table.
+func New(t *TTable) hash.Hash64 {
+	return crc.New64(t)
+}
+
+//-----------------------------------------------------------------------------
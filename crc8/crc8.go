@@ -0,0 +1,153 @@
+//-----------------------------------------------------------------------------
+
+// Package crc8 implements the 8-bit cyclic redundancy check, or CRC-8, checksum.
+//
+// It provides parameters for the majority of well-known CRC-8 algorithms
+// and instantiates the generic engine of package crc with 8-bit registers.
+package crc8
+
+import "github.com/mbsulliv/crc16/crc"
+
+//-----------------------------------------------------------------------------
+
+// TAlgo represents parameters of CRC-8 algorithms.
+type TAlgo struct {
+	Poly   uint8
+	Init   uint8
+	RefIn  bool
+	RefOut bool
+	XorOut uint8
+	Check  uint8
+	Name   string
+}
+
+// TTable is a 256-byte table representing polinomial and algorithm settings for efficient processing.
+type TTable = crc.TTable[uint8]
+
+// Predefined CRC-8 algorithms.
+//
+// Related links:
+// - http://reveng.sourceforge.net/crc-catalogue/1-15.htm#crc.cat-bits.8
+var (
+	CRC8_SMBUS      = TAlgo{0x07, 0x00, false, false, 0x00, 0xF4, "CRC-8/SMBUS"}
+	CRC8_I_432_1    = TAlgo{0x07, 0x00, false, false, 0x55, 0xA1, "CRC-8/I-432-1"}
+	CRC8_ROHC       = TAlgo{0x07, 0xFF, true, true, 0x00, 0xD0, "CRC-8/ROHC"}
+	CRC8_GSM_A      = TAlgo{0x1D, 0x00, false, false, 0x00, 0x37, "CRC-8/GSM-A"}
+	CRC8_MIFARE_MAD = TAlgo{0x1D, 0xC7, false, false, 0x00, 0x99, "CRC-8/MIFARE-MAD"}
+	CRC8_I_CODE     = TAlgo{0x1D, 0xFD, false, false, 0x00, 0x7E, "CRC-8/I-CODE"}
+	CRC8_HITAG      = TAlgo{0x1D, 0xFF, false, false, 0x00, 0xB4, "CRC-8/HITAG"}
+	CRC8_SAE_J1850  = TAlgo{0x1D, 0xFF, false, false, 0xFF, 0x4B, "CRC-8/SAE-J1850"}
+	CRC8_TECH_3250  = TAlgo{0x1D, 0xFF, true, true, 0x00, 0x97, "CRC-8/TECH-3250"}
+	CRC8_OPENSAFETY = TAlgo{0x2F, 0x00, false, false, 0x00, 0x3E, "CRC-8/OPENSAFETY"}
+	CRC8_AUTOSAR    = TAlgo{0x2F, 0xFF, false, false, 0xFF, 0xDF, "CRC-8/AUTOSAR"}
+	CRC8_MAXIM_DOW  = TAlgo{0x31, 0x00, true, true, 0x00, 0xA1, "CRC-8/MAXIM-DOW"}
+	CRC8_MAXIM      = TAlgo{0x31, 0x00, true, true, 0x00, 0xA1, "CRC-8/MAXIM"}
+	CRC8_NRSC_5     = TAlgo{0x31, 0xFF, false, false, 0x00, 0xF7, "CRC-8/NRSC-5"}
+	CRC8_DARC       = TAlgo{0x39, 0x00, true, true, 0x00, 0x15, "CRC-8/DARC"}
+	CRC8_GSM_B      = TAlgo{0x49, 0x00, false, false, 0xFF, 0x94, "CRC-8/GSM-B"}
+	CRC8_LTE        = TAlgo{0x9B, 0x00, false, false, 0x00, 0xEA, "CRC-8/LTE"}
+	CRC8_WCDMA      = TAlgo{0x9B, 0x00, true, true, 0x00, 0x25, "CRC-8/WCDMA"}
+	CRC8_CDMA2000   = TAlgo{0x9B, 0xFF, false, false, 0x00, 0xDA, "CRC-8/CDMA2000"}
+	CRC8_BLUETOOTH  = TAlgo{0xA7, 0x00, true, true, 0x00, 0x26, "CRC-8/BLUETOOTH"}
+	CRC8_DVB_S2     = TAlgo{0xD5, 0x00, false, false, 0x00, 0xBC, "CRC-8/DVB-S2"}
+)
+
+// The predefined algorithms in the catalogue order.
+var catalogue = []*TAlgo{
+	&CRC8_SMBUS,
+	&CRC8_I_432_1,
+	&CRC8_ROHC,
+	&CRC8_GSM_A,
+	&CRC8_MIFARE_MAD,
+	&CRC8_I_CODE,
+	&CRC8_HITAG,
+	&CRC8_SAE_J1850,
+	&CRC8_TECH_3250,
+	&CRC8_OPENSAFETY,
+	&CRC8_AUTOSAR,
+	&CRC8_MAXIM_DOW,
+	&CRC8_MAXIM,
+	&CRC8_NRSC_5,
+	&CRC8_DARC,
+	&CRC8_GSM_B,
+	&CRC8_LTE,
+	&CRC8_WCDMA,
+	&CRC8_CDMA2000,
+	&CRC8_BLUETOOTH,
+	&CRC8_DVB_S2,
+}
+
+//-----------------------------------------------------------------------------
+
+// MakeTable returns the TTable constructed from the specified algorithm.
+func MakeTable(aAlgo TAlgo) *TTable {
+	return crc.MakeTable(crc.TAlgo[uint8]{
+		Poly: aAlgo.Poly, Init: aAlgo.Init, RefIn: aAlgo.RefIn, RefOut: aAlgo.RefOut,
+		XorOut: aAlgo.XorOut, Check: aAlgo.Check, Name: aAlgo.Name,
+	})
+}
+
+//--------------------------------------
+
+// Algorithms returns the predefined algorithms in the catalogue order.
+func Algorithms() []TAlgo {
+	vRet := make([]TAlgo, len(catalogue))
+	for i, a := range catalogue {
+		vRet[i] = *a
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Init returns the initial value for CRC register corresponding to the specified algorithm.
+func Init(aTable *TTable) uint8 {
+	return crc.Init(aTable)
+}
+
+//--------------------------------------
+
+// Update returns the result of adding the bytes in data to the crc.
+func Update(crc8 uint8, data []byte, aTable *TTable) uint8 {
+	return crc.Update(crc8, data, aTable)
+}
+
+//--------------------------------------
+
+// Complete returns the result of CRC calculation and post-calculation processing of the crc.
+func Complete(crc8 uint8, aTable *TTable) uint8 {
+	return crc.Complete(crc8, aTable)
+}
+
+//--------------------------------------
+
+// Checksum returns CRC checksum of data using specified algorithm represented by the TTable.
+func Checksum(data []byte, aTable *TTable) uint8 {
+	return crc.Checksum(data, aTable)
+}
+
+//-----------------------------------------------------------------------------
+
+// Combine returns CRC checksum of the concatenation of two messages, given the checksum
+// aSum1 of the first one and the checksum aSum2 of the second one of aLen2 bytes.
+func Combine(aSum1, aSum2 uint8, aLen2 int64, aTable *TTable) uint8 {
+	return crc.Combine(aSum1, aSum2, aLen2, aTable)
+}
+
+//--------------------------------------
+
+// ExtendZeros returns CRC checksum of a message followed by n zero bytes,
+// given the checksum aSum of the message.
+func ExtendZeros(aSum uint8, n int64, aTable *TTable) uint8 {
+	return crc.ExtendZeros(aSum, n, aTable)
+}
+
+//--------------------------------------
+
+// ChecksumRepeat returns CRC checksum of pattern repeated count times
+// using the specified algorithm represented by the TTable.
+func ChecksumRepeat(pattern []byte, count int64, aTable *TTable) uint8 {
+	return crc.ChecksumRepeat(pattern, count, aTable)
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,43 @@
+//-----------------------------------------------------------------------------
+
+package crc8
+
+import (
+	"fmt"
+	"path"
+	"runtime"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+// Returns the function name of the calling function.
+func funcName() string {
+	vRet := "?"
+	vPc, _, _, vOk := runtime.Caller(1)
+	if vOk {
+		vRet = path.Base(runtime.FuncForPC(vPc).Name())
+	}
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
+
+func TestCatalogue(aT *testing.T) {
+	vTestData := []byte("123456789")
+	for _, a := range Algorithms() {
+		Convey(fmt.Sprintf("%s: %s", funcName(), a.Name), aT, func() {
+			vTable := MakeTable(a)
+			So(fmt.Sprintf("0x%02X", Checksum(vTestData, vTable)), ShouldEqual, fmt.Sprintf("0x%02X", a.Check))
+			So(Complete(Update(Update(Init(vTable), vTestData[:4], vTable), vTestData[4:], vTable), vTable), ShouldEqual, a.Check)
+		})
+	}
+	Convey(funcName(), aT, func() {
+		So(len(Algorithms()), ShouldEqual, 21)
+		So(Checksum([]byte{0x01, 0x02}, MakeTable(CRC8_SMBUS)), ShouldEqual, 0x1B)
+	})
+}
+
+//-----------------------------------------------------------------------------
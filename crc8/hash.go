@@ -0,0 +1,14 @@
+//-----------------------------------------------------------------------------
+
+package crc8
+
+import "github.com/mbsulliv/crc16/crc"
+
+//-----------------------------------------------------------------------------
+
+// New creates a new CRC8 digest for the given table.
+func New(t *TTable) crc.Hash8 {
+	return crc.New8(t)
+}
+
+//-----------------------------------------------------------------------------
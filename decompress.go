@@ -0,0 +1,83 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package crc16
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+)
+
+//-----------------------------------------------------------------------------
+
+// This file contains the readers verifying the checksum of decompressed content,
+// for archive formats storing the checksum of the original payload.
+
+type tVerifyingReader struct {
+	reader   io.Reader
+	table    *TTable
+	crc      uint16
+	length   int64
+	expected uint16
+	err      error
+}
+
+// Pairs a reader with the closer of the decompressor it reads through.
+type tReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+//-----------------------------------------------------------------------------
+
+// NewVerifyingReader returns a reader delivering the content of r and computing its checksum.
+// At the end of r, it returns io.EOF if the checksum is aExpected and a *ChecksumError otherwise,
+// with an unknown offset. Any decompressing reader can be wrapped, e.g. a zstd decoder.
+func NewVerifyingReader(r io.Reader, aTable *TTable, aExpected uint16) io.Reader {
+	return &tVerifyingReader{reader: r, table: aTable, crc: Init(aTable), expected: aExpected}
+}
+
+//--------------------------------------
+
+func (aR *tVerifyingReader) Read(p []byte) (int, error) {
+	if aR.err != nil {
+		return 0, aR.err
+	}
+	vN, err := aR.reader.Read(p)
+	aR.crc = Update(aR.crc, p[:vN], aR.table)
+	aR.length += int64(vN)
+	if err == io.EOF {
+		vSum := Complete(aR.crc, aR.table)
+		countVerified(int(aR.length), vSum == aR.expected)
+		if vSum != aR.expected {
+			err = &ChecksumError{Algo: aR.table.algo.Name, Expected: aR.expected, Actual: vSum, Offset: -1}
+		}
+	}
+	aR.err = err
+	return vN, err
+}
+
+//--------------------------------------
+
+// NewGzipReader returns the reader decompressing the gzip stream r and verifying the checksum
+// of the decompressed content like NewVerifyingReader. Closing it closes the decompressor, not r.
+func NewGzipReader(r io.Reader, aTable *TTable, aExpected uint16) (io.ReadCloser, error) {
+	vZ, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return tReadCloser{NewVerifyingReader(vZ, aTable, aExpected), vZ}, nil
+}
+
+//--------------------------------------
+
+// NewFlateReader returns the reader decompressing the raw DEFLATE stream r and verifying
+// the checksum of the decompressed content like NewVerifyingReader.
+func NewFlateReader(r io.Reader, aTable *TTable, aExpected uint16) io.ReadCloser {
+	vZ := flate.NewReader(r)
+	return tReadCloser{NewVerifyingReader(vZ, aTable, aExpected), vZ}
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,55 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || crc16_ibm)
+
+package crc16
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"errors"
+	"io"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestDecompress(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_ARC)
+		vData := bytes.Repeat([]byte("123456789"), 1000)
+		vSum := Checksum(vData, vTable)
+
+		var vGz, vFl bytes.Buffer
+		vW := gzip.NewWriter(&vGz)
+		vW.Write(vData)
+		vW.Close()
+		vF, _ := flate.NewWriter(&vFl, flate.BestSpeed)
+		vF.Write(vData)
+		vF.Close()
+
+		vR, err := NewGzipReader(bytes.NewReader(vGz.Bytes()), vTable, vSum)
+		So(err, ShouldBeNil)
+		vOut, err := io.ReadAll(vR)
+		So(err, ShouldBeNil)
+		So(vOut, ShouldResemble, vData)
+		So(vR.Close(), ShouldBeNil)
+
+		vR = NewFlateReader(bytes.NewReader(vFl.Bytes()), vTable, vSum^1)
+		_, err = io.ReadAll(vR)
+		var vMismatch *ChecksumError
+		So(errors.As(err, &vMismatch), ShouldBeTrue)
+		So(vMismatch.Actual, ShouldEqual, vSum)
+		So(vMismatch.Offset, ShouldEqual, -1)
+		_, err = vR.Read(make([]byte, 1))
+		So(errors.Is(err, ErrChecksumMismatch), ShouldBeTrue)
+
+		_, err = NewGzipReader(bytes.NewReader(vData), vTable, vSum)
+		So(err, ShouldNotBeNil)
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,125 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package crc16
+
+import (
+	"encoding/binary"
+	"sort"
+)
+
+//-----------------------------------------------------------------------------
+
+// This file contains the statistical detection of the algorithm used by a corpus of frames.
+
+// TDetection describes a hypothesis about how checksums are embedded in captured frames
+// and how well the corpus supports it.
+//
+// The checksum is assumed to cover the frame except its first Skip bytes and to be stored
+// in the Order byte order right before the last Tail bytes of the frame.
+type TDetection struct {
+	Algo    TAlgo
+	Skip    int
+	Tail    int
+	Order   binary.ByteOrder
+	Matched int
+	Total   int
+}
+
+//-----------------------------------------------------------------------------
+
+// Ratio returns the fraction of frames of the corpus validated by the hypothesis.
+func (aD TDetection) Ratio() float64 {
+	if aD.Total == 0 {
+		return 0
+	}
+	return float64(aD.Matched) / float64(aD.Total)
+}
+
+//--------------------------------------
+
+// Returns the predefined and registered algorithms with duplicated parameter sets removed,
+// keeping the first name in the catalogue order.
+func distinctCatalogue() []*TAlgo {
+	var vRet []*TAlgo
+next:
+	for a := range predefined() {
+		for _, b := range vRet {
+			if sameParams(a, b) {
+				continue next
+			}
+		}
+		vRet = append(vRet, a)
+	}
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
+
+// DetectAlgo ranks the predefined algorithms by the fraction of frames they validate.
+//
+// Every combination of algorithm, number of uncovered leading bytes up to aMaxSkip,
+// number of bytes following the checksum up to aMaxTail and checksum byte order is tried
+// on every frame. Hypotheses validating at least one frame are returned, best first.
+// Since a random frame passes a 16-bit check with probability 2^-16, only hypotheses
+// validating a substantial part of a large corpus are meaningful.
+func DetectAlgo(aFrames [][]byte, aMaxSkip, aMaxTail int) []TDetection {
+	type tKey struct {
+		skip, tail int
+		le         bool
+	}
+
+	var vRet []TDetection
+	for _, vAlgo := range distinctCatalogue() {
+		vTable := MakeTable(*vAlgo)
+		vCounts := make(map[tKey]int)
+		for _, vFrame := range aFrames {
+			for s := 0; s <= aMaxSkip; s++ {
+				vCrc := Init(vTable)
+				for e := s; e+2 <= len(vFrame); e++ {
+					// vCrc covers vFrame[s:e], the checksum would be at vFrame[e:e+2].
+					t := len(vFrame) - e - 2
+					if t <= aMaxTail {
+						vSum := Complete(vCrc, vTable)
+						vTrailer := vFrame[e : e+2]
+						if binary.BigEndian.Uint16(vTrailer) == vSum {
+							vCounts[tKey{s, t, false}]++
+						}
+						if binary.LittleEndian.Uint16(vTrailer) == vSum {
+							vCounts[tKey{s, t, true}]++
+						}
+					}
+					vCrc = Update(vCrc, vFrame[e:e+1], vTable)
+				}
+			}
+		}
+		for k, n := range vCounts {
+			vD := TDetection{Algo: *vAlgo, Skip: k.skip, Tail: k.tail, Order: binary.BigEndian, Matched: n, Total: len(aFrames)}
+			if k.le {
+				vD.Order = binary.LittleEndian
+			}
+			vRet = append(vRet, vD)
+		}
+	}
+
+	sort.SliceStable(vRet, func(i, j int) bool {
+		a, b := &vRet[i], &vRet[j]
+		if a.Matched != b.Matched {
+			return a.Matched > b.Matched
+		}
+		if a.Skip+a.Tail != b.Skip+b.Tail {
+			return a.Skip+a.Tail < b.Skip+b.Tail
+		}
+		if a.Skip != b.Skip {
+			return a.Skip < b.Skip
+		}
+		if a.Algo.Name != b.Algo.Name {
+			return a.Algo.Name < b.Algo.Name
+		}
+		return a.Order == binary.BigEndian && b.Order != binary.BigEndian
+	})
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
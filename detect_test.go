@@ -0,0 +1,49 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || crc16_ibm)
+
+package crc16
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestDetectAlgo(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vRand := rand.New(rand.NewSource(1))
+		vTable := MakeTable(CRC16_MODBUS)
+
+		var vFrames [][]byte
+		for i := 0; i < 20; i++ {
+			vPayload := make([]byte, 4+vRand.Intn(20))
+			vRand.Read(vPayload)
+			vFrame := append([]byte{0x7E}, vPayload...)
+			vFrame = binary.LittleEndian.AppendUint16(vFrame, Checksum(vPayload, vTable))
+			vFrames = append(vFrames, append(vFrame, 0x0D))
+		}
+		// A corrupted frame must lower the ratio but not the ranking.
+		vFrames[3][2] ^= 0x10
+
+		vRes := DetectAlgo(vFrames, 2, 2)
+		So(len(vRes), ShouldBeGreaterThan, 0)
+		So(vRes[0].Algo.Name, ShouldEqual, CRC16_MODBUS.Name)
+		So(vRes[0].Skip, ShouldEqual, 1)
+		So(vRes[0].Tail, ShouldEqual, 1)
+		So(vRes[0].Order, ShouldEqual, binary.LittleEndian)
+		So(vRes[0].Matched, ShouldEqual, 19)
+		So(vRes[0].Ratio(), ShouldAlmostEqual, 0.95)
+		for _, vD := range vRes[1:] {
+			So(vD.Matched, ShouldBeLessThan, 3)
+		}
+
+		So(DetectAlgo(nil, 2, 2), ShouldBeEmpty)
+	})
+}
+
+//-----------------------------------------------------------------------------
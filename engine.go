@@ -0,0 +1,305 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+//-----------------------------------------------------------------------------
+
+// This file contains the selection of the engine processing the input of Update by its length.
+// Short inputs, e.g. Modbus frames, are processed a byte at a time by the lookup table,
+// and long ones eight bytes at a time by the slicing engine, whose 4 KB of tables are built
+// on first use. On amd64 and arm64 processors supporting carry-less multiplication, inputs
+// of 64 bytes and more are folded 64 bytes at a time by the folding engine instead, unless
+// built with the purego tag. The slicing and folding engines are left out of builds with the crc16_nibble tag.
+//
+// The selection can be forced with SetImplementation or, except in builds with the crc16_tiny
+// tag, the crc16impl setting of the GODEBUG environment variable, e.g. GODEBUG=crc16impl=generic,
+// to reproduce and bisect discrepancies and performance regressions.
+
+// TImplementation is the engine used by Update.
+type TImplementation byte
+
+const (
+	// ImplAuto selects the engine by the length of the input.
+	ImplAuto TImplementation = iota
+	// ImplGeneric processes the input a byte at a time.
+	ImplGeneric
+	// ImplSlicing8 processes the input eight bytes at a time, and the last few a byte at a time.
+	ImplSlicing8
+	// ImplCLMUL folds the input by carry-less multiplication 64 bytes at a time, and processes
+	// the last few as ImplSlicing8.
+	ImplCLMUL
+)
+
+// The names of the implementations, as spelled in GODEBUG.
+var implNames = [...]string{"auto", "generic", "slicing8", "clmul"}
+
+// The input length from which the slicing engine hands over to the folding engine.
+const cFoldMin = 64
+
+// The slicing engine hands over to the folding engine.
+var foldEnabled = hasFold
+
+// The input length from which Update uses the slicing engine, and the one ImplAuto uses.
+var slicingMin, slicingAuto = 64, 64
+
+// The engine forced.
+var implementation TImplementation
+
+//-----------------------------------------------------------------------------
+
+// SetImplementation forces the engine used by Update, or lets it be selected by input length
+// for ImplAuto. It must not be called concurrently with Update, and it fails if the engine
+// is not built in.
+func SetImplementation(aImpl TImplementation) error {
+	if err := implAvailable(aImpl); err != nil {
+		return err
+	}
+	switch aImpl {
+	case ImplAuto:
+		slicingMin, foldEnabled = slicingAuto, hasFold
+	case ImplGeneric:
+		slicingMin = math.MaxInt
+	case ImplSlicing8:
+		slicingMin, foldEnabled = 0, false
+	case ImplCLMUL:
+		slicingMin, foldEnabled = 0, true
+	}
+	implementation = aImpl
+	return nil
+}
+
+//--------------------------------------
+
+// Returns an error if the engine is not built in.
+func implAvailable(aImpl TImplementation) error {
+	switch {
+	case aImpl == ImplSlicing8 && !cSlicingEngine:
+		return errors.New("crc16: slicing engine left out of the build")
+	case aImpl == ImplCLMUL && !hasFold:
+		return errors.New("crc16: folding engine not supported")
+	case int(aImpl) >= len(implNames):
+		return errors.New("crc16: unknown implementation")
+	}
+	return nil
+}
+
+//--------------------------------------
+
+// MakeTableSliced returns the TTable constructed from the specified algorithm, like MakeTable,
+// with the tables of the slicing engine built up front rather than on first use, for tables
+// checksumming large buffers, e.g. multi-gigabyte files.
+func MakeTableSliced(aAlgo TAlgo) *TTable {
+	vTable := MakeTable(aAlgo)
+	vTable.slices.build(vTable)
+	return vTable
+}
+
+//--------------------------------------
+
+// Implementation returns the engine forced, or ImplAuto.
+func Implementation() TImplementation {
+	return implementation
+}
+
+//--------------------------------------
+
+// ImplementationFor returns the engine Update uses for inputs of aLen bytes, as selected
+// or forced, e.g. to log the code path in use. It never returns ImplAuto.
+func ImplementationFor(aLen int) TImplementation {
+	switch {
+	case aLen < slicingMin || !cSlicingEngine:
+		return ImplGeneric
+	case foldEnabled && aLen >= cFoldMin:
+		return ImplCLMUL
+	}
+	return ImplSlicing8
+}
+
+//--------------------------------------
+
+// Features returns the names, as spelled in GODEBUG, of the engines built in and supported
+// by the processor, which SetImplementation can force. ImplGeneric, always present, and
+// ImplSlicing8 are portable Go; the others use processor instructions.
+func Features() []string {
+	var vRet []string
+	for i, n := range implNames[ImplGeneric:] {
+		if implAvailable(ImplGeneric+TImplementation(i)) == nil {
+			vRet = append(vRet, n)
+		}
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// String returns the name of the implementation as spelled in GODEBUG.
+func (aImpl TImplementation) String() string {
+	if int(aImpl) < len(implNames) {
+		return implNames[aImpl]
+	}
+	return "unknown"
+}
+
+//--------------------------------------
+
+// Returns the implementation set by the crc16impl setting of a GODEBUG value, the last
+// one winning as in the runtime, and whether there is a known one.
+func godebugImplementation(aGodebug string) (TImplementation, bool) {
+	vRet, vFound := ImplAuto, false
+	for _, s := range strings.Split(aGodebug, ",") {
+		vKey, vVal, _ := strings.Cut(strings.TrimSpace(s), "=")
+		if vKey != "crc16impl" {
+			continue
+		}
+		for i, n := range implNames {
+			if vVal == n {
+				vRet, vFound = TImplementation(i), true
+			}
+		}
+	}
+	return vRet, vFound
+}
+
+//--------------------------------------
+
+// CalibrateEngines measures the throughput of the engines on inputs of increasing length
+// and makes Update switch to the slicing engine from the shortest input it processes faster,
+// which it returns. The default crossover suits most 64-bit processors; calibrating pays off
+// on unusual ones. It takes a few milliseconds and must not be called concurrently with
+// Update, e.g. it may be called by an init function. A forced engine stays in use.
+// It returns 0 if there is no slicing engine.
+func CalibrateEngines() int {
+	if !cSlicingEngine {
+		return 0
+	}
+	vTable := MakeTable(TAlgo{Poly: 0x1021})
+	vData := make([]byte, 4096)
+	vMeasure := func(n, aMin int) time.Duration {
+		slicingMin = aMin
+		vStart := time.Now()
+		for i := 0; i < 1<<18/n; i++ {
+			Update(0, vData[:n], vTable)
+		}
+		return time.Since(vStart)
+	}
+	vMeasure(len(vData), 0) // Builds the slicing tables.
+	vRet := 2 * len(vData)
+	for n := 8; n <= len(vData); n *= 2 {
+		if vMeasure(n, 0) < vMeasure(n, n+1) {
+			vRet = n
+			break
+		}
+	}
+	slicingAuto = vRet
+	SetImplementation(implementation)
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
+
+// Engine computes the checksums of an algorithm with a given engine, regardless of the one
+// forced by SetImplementation, e.g. to compare engines or to pin one in reproducibility tests.
+// Its Init, Update and Complete work as the functions of the same names, and registers
+// can be passed from an engine of the algorithm to another.
+type Engine interface {
+	Init() uint16
+	Update(crc uint16, data []byte) uint16
+	Complete(crc uint16) uint16
+}
+
+// tTableEngine is an Engine updating the register with a table by a given implementation.
+type tTableEngine struct {
+	table *TTable
+	impl  TImplementation
+}
+
+// tBitwiseEngine is an Engine updating the register one bit at a time without a table.
+type tBitwiseEngine struct {
+	algo TAlgo
+}
+
+//-----------------------------------------------------------------------------
+
+// NewEngine returns the Engine using the table with the specified implementation, ImplAuto
+// selecting it by input length like Update. It fails if the engine is not built in.
+func NewEngine(aTable *TTable, aImpl TImplementation) (Engine, error) {
+	if err := implAvailable(aImpl); err != nil {
+		return nil, err
+	}
+	return &tTableEngine{table: aTable, impl: aImpl}, nil
+}
+
+//--------------------------------------
+
+// Init returns the initial register of the algorithm.
+func (aE *tTableEngine) Init() uint16 {
+	return Init(aE.table)
+}
+
+//--------------------------------------
+
+// Update returns the result of adding the bytes in data to the crc.
+func (aE *tTableEngine) Update(crc uint16, data []byte) uint16 {
+	switch aE.impl {
+	case ImplGeneric:
+	case ImplSlicing8:
+		crc, data = aE.table.slices.update(crc, data, aE.table, false)
+	case ImplCLMUL:
+		crc, data = aE.table.slices.update(crc, data, aE.table, true)
+	default:
+		return Update(crc, data, aE.table)
+	}
+	return updateBytes(crc, data, aE.table)
+}
+
+//--------------------------------------
+
+// Complete returns the checksum of the register crc.
+func (aE *tTableEngine) Complete(crc uint16) uint16 {
+	return Complete(crc, aE.table)
+}
+
+//-----------------------------------------------------------------------------
+
+// BitwiseEngine returns the Engine computing checksums of the algorithm one bit at a time
+// like ChecksumBitwise, needing no table.
+func BitwiseEngine(aAlgo TAlgo) Engine {
+	return &tBitwiseEngine{algo: aAlgo}
+}
+
+//--------------------------------------
+
+// Init returns the initial register of the algorithm.
+func (aE *tBitwiseEngine) Init() uint16 {
+	return aE.algo.Init << aE.algo.pad()
+}
+
+//--------------------------------------
+
+// Update returns the result of adding the bytes in data to the crc.
+func (aE *tBitwiseEngine) Update(crc uint16, data []byte) uint16 {
+	return updateBitwise(crc, data, &aE.algo)
+}
+
+//--------------------------------------
+
+// Complete returns the checksum of the register crc.
+func (aE *tBitwiseEngine) Complete(crc uint16) uint16 {
+	return aE.algo.complete(crc)
+}
+
+//-----------------------------------------------------------------------------
+
+// ChecksumWith returns CRC checksum of data computed by the engine.
+func ChecksumWith(data []byte, aEngine Engine) uint16 {
+	return aEngine.Complete(aEngine.Update(aEngine.Init(), data))
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,183 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nocatalogue || (crc16_ccitt && crc16_ibm)
+
+package crc16
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestEngines(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		defer func(n, aAuto int) {
+			slicingMin, slicingAuto, implementation, foldEnabled = n, aAuto, ImplAuto, hasFold
+		}(slicingMin, slicingAuto)
+		vData := make([]byte, 300)
+		for i := range vData {
+			vData[i] = byte(i*7 + i>>3)
+		}
+		for _, a := range []TAlgo{
+			{Poly: 0x1021, Init: 0xFFFF},
+			{Poly: 0x8005, Init: 0xFFFF, RefIn: true, RefOut: true},
+			{Poly: 0x3D65, RefIn: true, XorOut: 0xFFFF},
+		} {
+			vTable := MakeTable(a)
+			vSliced := MakeTableSliced(a)
+			So(Checksum(vData, vSliced), ShouldEqual, Checksum(vData, vTable))
+			for n := 0; n <= len(vData); n += 13 {
+				slicingMin = len(vData) + 1
+				vSum := Checksum(vData[:n], vTable)
+				So(vSum, ShouldEqual, ChecksumBitwise(vData[:n], a))
+				slicingMin = 0
+				So(Checksum(vData[:n], vTable), ShouldEqual, vSum)
+				foldEnabled = false
+				So(Checksum(vData[:n], vTable), ShouldEqual, vSum)
+				foldEnabled = hasFold
+			}
+		}
+
+		So(SetImplementation(ImplGeneric), ShouldBeNil)
+		So(Implementation(), ShouldEqual, ImplGeneric)
+		So(slicingMin, ShouldBeGreaterThan, len(vData))
+		if cSlicingEngine {
+			So(SetImplementation(ImplSlicing8), ShouldBeNil)
+			So(slicingMin, ShouldEqual, 0)
+			So(CalibrateEngines(), ShouldBeGreaterThan, 0)
+			So(slicingMin, ShouldEqual, 0)
+		} else {
+			So(SetImplementation(ImplSlicing8), ShouldNotBeNil)
+		}
+		if hasFold {
+			So(SetImplementation(ImplCLMUL), ShouldBeNil)
+			So(foldEnabled, ShouldBeTrue)
+			So(SetImplementation(ImplSlicing8), ShouldBeNil)
+			So(foldEnabled, ShouldBeFalse)
+		} else {
+			So(SetImplementation(ImplCLMUL), ShouldNotBeNil)
+		}
+		So(SetImplementation(TImplementation(9)), ShouldNotBeNil)
+		So(SetImplementation(ImplAuto), ShouldBeNil)
+		So(Implementation().String(), ShouldEqual, "auto")
+
+		vImpl, vFound := godebugImplementation("madvdontneed=1,crc16impl=slicing8")
+		So(vFound, ShouldBeTrue)
+		So(vImpl, ShouldEqual, ImplSlicing8)
+		vImpl, vFound = godebugImplementation("crc16impl=slicing8, crc16impl=generic,crc16impl=avx512")
+		So(vFound, ShouldBeTrue)
+		So(vImpl, ShouldEqual, ImplGeneric)
+		_, vFound = godebugImplementation("crc16impl=avx512")
+		So(vFound, ShouldBeFalse)
+		_, vFound = godebugImplementation("")
+		So(vFound, ShouldBeFalse)
+
+		vThreshold := CalibrateEngines()
+		if cSlicingEngine {
+			So(vThreshold, ShouldBeGreaterThan, 0)
+			So(slicingMin, ShouldEqual, vThreshold)
+		} else {
+			So(vThreshold, ShouldEqual, 0)
+		}
+	})
+}
+
+//--------------------------------------
+
+func TestEngine(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vData := make([]byte, 300)
+		for i := range vData {
+			vData[i] = byte(i*5 + i>>2)
+		}
+		for _, a := range []TAlgo{CRC16_XMODEM, CRC16_MODBUS, CRC16_GENIBUS} {
+			vTable := MakeTable(a)
+			vWant := Checksum(vData, vTable)
+			vEngines := []Engine{BitwiseEngine(a)}
+			for _, i := range []TImplementation{ImplAuto, ImplGeneric, ImplSlicing8, ImplCLMUL} {
+				vE, err := NewEngine(vTable, i)
+				if implAvailable(i) != nil {
+					So(err, ShouldNotBeNil)
+					continue
+				}
+				So(err, ShouldBeNil)
+				vEngines = append(vEngines, vE)
+			}
+			for _, e := range vEngines {
+				So(ChecksumWith(vData, e), ShouldEqual, vWant)
+				So(ChecksumWith([]byte("123456789"), e), ShouldEqual, a.Check)
+
+				// Registers pass between engines.
+				vCrc := e.Update(e.Init(), vData[:100])
+				So(Complete(Update(vCrc, vData[100:], vTable), vTable), ShouldEqual, vWant)
+
+				vH := NewFromEngine(e)
+				vH.Write(vData[:77])
+				vClone := vH.(Cloner).Clone()
+				vH.Write(vData[77:])
+				So(vH.Sum16(), ShouldEqual, vWant)
+				So(vH.Sum(nil), ShouldResemble, []byte{byte(vWant >> 8), byte(vWant)})
+				So(vH.(LittleEndianSummer).SumLE(nil), ShouldResemble, []byte{byte(vWant), byte(vWant >> 8)})
+				So(vClone.Sum16(), ShouldEqual, Checksum(vData[:77], vTable))
+				vH.Reset()
+				So(vH.Sum16(), ShouldEqual, Checksum(nil, vTable))
+				vH.(io.ByteWriter).WriteByte(vData[0])
+				io.WriteString(vH, string(vData[1:77]))
+				n, err := vH.(io.ReaderFrom).ReadFrom(bytes.NewReader(vData[77:]))
+				So(err, ShouldBeNil)
+				So(n, ShouldEqual, len(vData)-77)
+				So(vH.Sum16(), ShouldEqual, vWant)
+				vH.Reset()
+				So(vH.Size(), ShouldEqual, 2)
+				So(vH.BlockSize(), ShouldEqual, 1)
+			}
+		}
+		_, err := NewEngine(MakeTable(CRC16_XMODEM), TImplementation(99))
+		So(err, ShouldNotBeNil)
+	})
+}
+
+//--------------------------------------
+
+func TestFeatures(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		defer func(n, aAuto int) {
+			slicingMin, slicingAuto, implementation, foldEnabled = n, aAuto, ImplAuto, hasFold
+		}(slicingMin, slicingAuto)
+
+		vFeatures := Features()
+		So(vFeatures[0], ShouldEqual, "generic")
+		vCount := 1
+		if cSlicingEngine {
+			vCount++
+		}
+		if hasFold {
+			vCount++
+		}
+		So(len(vFeatures), ShouldEqual, vCount)
+		for _, n := range vFeatures {
+			vImpl, vFound := godebugImplementation("crc16impl=" + n)
+			So(vFound, ShouldBeTrue)
+			So(SetImplementation(vImpl), ShouldBeNil)
+			So(ImplementationFor(1000), ShouldEqual, vImpl)
+		}
+
+		So(SetImplementation(ImplAuto), ShouldBeNil)
+		So(ImplementationFor(10), ShouldEqual, ImplGeneric)
+		switch {
+		case hasFold:
+			So(ImplementationFor(1000), ShouldEqual, ImplCLMUL)
+		case cSlicingEngine:
+			So(ImplementationFor(1000), ShouldEqual, ImplSlicing8)
+		default:
+			So(ImplementationFor(1000), ShouldEqual, ImplGeneric)
+		}
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,127 @@
+//-----------------------------------------------------------------------------
+
+// Package envelope implements a tiny versioned record format protected by a CRC-16,
+// for durable log records and IPC messages needing integrity without a heavyweight framework.
+//
+// A record is the magic bytes 0xC1 0x6E, a version byte for the payload schema, the length
+// of the payload in a big-endian 32-bit word, the payload and the CRC-16/IBM-3740 of all
+// of the former in big-endian byte order.
+package envelope
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+
+	"github.com/mbsulliv/crc16"
+)
+
+//-----------------------------------------------------------------------------
+
+// TRecord is a record with the version of its payload schema.
+type TRecord struct {
+	Version byte
+	Payload []byte
+}
+
+const (
+	cMagic   = 0xC16E
+	cHeader  = 7
+	cTrailer = 2
+)
+
+// ErrMagic is returned for data not starting with a record.
+var ErrMagic = errors.New("envelope: not a record")
+
+// ErrTruncated is returned by Unmarshal for data ending before the end of the record.
+var ErrTruncated = errors.New("envelope: truncated record")
+
+// The algorithm protecting the records.
+var table = crc16.MakeTable(crc16.TAlgo{Poly: 0x1021, Init: 0xFFFF, Check: 0x29B1, Name: "CRC-16/IBM-3740"})
+
+//-----------------------------------------------------------------------------
+
+// Append appends the encoded record to b and returns the extended slice.
+func Append(b []byte, aRec TRecord) ([]byte, error) {
+	if uint64(len(aRec.Payload)) > math.MaxUint32 {
+		return nil, errors.New("envelope: payload too large")
+	}
+	vStart := len(b)
+	b = binary.BigEndian.AppendUint16(b, cMagic)
+	b = append(b, aRec.Version)
+	b = binary.BigEndian.AppendUint32(b, uint32(len(aRec.Payload)))
+	b = append(b, aRec.Payload...)
+	return binary.BigEndian.AppendUint16(b, crc16.Checksum(b[vStart:], table)), nil
+}
+
+//--------------------------------------
+
+// Marshal returns the encoded record.
+func Marshal(aRec TRecord) ([]byte, error) {
+	return Append(make([]byte, 0, cHeader+len(aRec.Payload)+cTrailer), aRec)
+}
+
+//--------------------------------------
+
+// Unmarshal decodes the record at the start of data and returns it with the rest of data.
+// The payload refers to data. A corrupt record yields a *crc16.ChecksumError.
+func Unmarshal(data []byte) (TRecord, []byte, error) {
+	if len(data) >= 2 && binary.BigEndian.Uint16(data) != cMagic {
+		return TRecord{}, data, ErrMagic
+	}
+	if len(data) < cHeader {
+		return TRecord{}, data, ErrTruncated
+	}
+	vLen := uint64(binary.BigEndian.Uint32(data[3:]))
+	if uint64(len(data)) < cHeader+vLen+cTrailer {
+		return TRecord{}, data, ErrTruncated
+	}
+	vEnd := cHeader + int(vLen)
+	vStored, vSum := binary.BigEndian.Uint16(data[vEnd:]), crc16.Checksum(data[:vEnd], table)
+	if vStored != vSum {
+		return TRecord{}, data, &crc16.ChecksumError{Algo: table.Algo().Name, Expected: vStored, Actual: vSum, Offset: int64(vEnd)}
+	}
+	return TRecord{Version: data[2], Payload: data[cHeader:vEnd]}, data[vEnd+cTrailer:], nil
+}
+
+//-----------------------------------------------------------------------------
+
+// Write writes the encoded record to w.
+func Write(w io.Writer, aRec TRecord) error {
+	vData, err := Marshal(aRec)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(vData)
+	return err
+}
+
+//--------------------------------------
+
+// Read reads the next record from r. It returns io.EOF if r ends before the record
+// and io.ErrUnexpectedEOF if it ends inside. A corrupt record yields a *crc16.ChecksumError.
+func Read(r io.Reader) (TRecord, error) {
+	var vB bytes.Buffer
+	if _, err := io.CopyN(&vB, r, cHeader); err != nil {
+		if err == io.EOF && vB.Len() > 0 {
+			err = io.ErrUnexpectedEOF
+		}
+		return TRecord{}, err
+	}
+	if binary.BigEndian.Uint16(vB.Bytes()) != cMagic {
+		return TRecord{}, ErrMagic
+	}
+	// The buffer grows as the data arrives rather than trusting a corrupt length.
+	if _, err := io.CopyN(&vB, r, int64(binary.BigEndian.Uint32(vB.Bytes()[3:]))+cTrailer); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return TRecord{}, err
+	}
+	vRec, _, err := Unmarshal(vB.Bytes())
+	return vRec, err
+}
+
+//-----------------------------------------------------------------------------
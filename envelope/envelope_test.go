@@ -0,0 +1,96 @@
+//-----------------------------------------------------------------------------
+
+package envelope
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"path"
+	"runtime"
+	"testing"
+
+	"github.com/mbsulliv/crc16"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+// Returns the function name of the calling function.
+func funcName() string {
+	vRet := "?"
+	vPc, _, _, vOk := runtime.Caller(1)
+	if vOk {
+		vRet = path.Base(runtime.FuncForPC(vPc).Name())
+	}
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
+
+func TestMarshal(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		So(crc16.Checksum([]byte("123456789"), table), ShouldEqual, table.Algo().Check)
+
+		vData, err := Marshal(TRecord{Version: 3, Payload: []byte("hello")})
+		So(err, ShouldBeNil)
+		So(vData[:12], ShouldResemble, []byte{0xC1, 0x6E, 3, 0, 0, 0, 5, 'h', 'e', 'l', 'l', 'o'})
+		So(len(vData), ShouldEqual, 14)
+
+		vLog, _ := Append(vData, TRecord{Version: 1})
+		vRec, vRest, err := Unmarshal(vLog)
+		So(err, ShouldBeNil)
+		So(vRec, ShouldResemble, TRecord{Version: 3, Payload: []byte("hello")})
+		vRec, vRest, err = Unmarshal(vRest)
+		So(err, ShouldBeNil)
+		So(vRec.Version, ShouldEqual, 1)
+		So(vRec.Payload, ShouldBeEmpty)
+		So(vRest, ShouldBeEmpty)
+
+		vData[8] ^= 0x20
+		_, _, err = Unmarshal(vData)
+		var vMismatch *crc16.ChecksumError
+		So(errors.As(err, &vMismatch), ShouldBeTrue)
+		So(vMismatch.Offset, ShouldEqual, 12)
+
+		for n := 0; n < 14; n++ {
+			_, _, err = Unmarshal(vLog[:n])
+			So(err, ShouldEqual, ErrTruncated)
+		}
+		_, _, err = Unmarshal([]byte("garbage"))
+		So(err, ShouldEqual, ErrMagic)
+	})
+}
+
+//--------------------------------------
+
+func TestReadWrite(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		var vB bytes.Buffer
+		So(Write(&vB, TRecord{Version: 2, Payload: []byte("first")}), ShouldBeNil)
+		So(Write(&vB, TRecord{Version: 2, Payload: []byte("second")}), ShouldBeNil)
+		vLog := vB.Bytes()
+
+		vR := bytes.NewReader(vLog)
+		vRec, err := Read(vR)
+		So(err, ShouldBeNil)
+		So(string(vRec.Payload), ShouldEqual, "first")
+		vRec, err = Read(vR)
+		So(err, ShouldBeNil)
+		So(string(vRec.Payload), ShouldEqual, "second")
+		_, err = Read(vR)
+		So(err, ShouldEqual, io.EOF)
+
+		_, err = Read(bytes.NewReader(vLog[:3]))
+		So(err, ShouldEqual, io.ErrUnexpectedEOF)
+		_, err = Read(bytes.NewReader(vLog[:10]))
+		So(err, ShouldEqual, io.ErrUnexpectedEOF)
+		_, err = Read(bytes.NewReader([]byte{0xC1, 0x6E, 0, 0xFF, 0xFF, 0xFF, 0xFF, 1}))
+		So(err, ShouldEqual, io.ErrUnexpectedEOF)
+		_, err = Read(bytes.NewReader([]byte("garbage")))
+		So(err, ShouldEqual, ErrMagic)
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,57 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import (
+	"errors"
+	"strconv"
+)
+
+//-----------------------------------------------------------------------------
+
+// ErrChecksumMismatch is matched by errors.Is for every *ChecksumError.
+var ErrChecksumMismatch = errors.New("crc16: checksum mismatch")
+
+// ErrTableCorrupt is returned by TTable.Validate for a table modified since its construction.
+var ErrTableCorrupt = errors.New("crc16: corrupt lookup table")
+
+// ChecksumError reports a checksum which does not match the data it covers.
+// The verifying functions returning errors return it on mismatch.
+type ChecksumError struct {
+	Algo     string // Name of the algorithm.
+	Expected uint16 // Checksum received or stored with the data.
+	Actual   uint16 // Checksum computed from the data.
+	Offset   int64  // Offset of the checksum in the frame, stream or image, or -1 if unknown.
+}
+
+//-----------------------------------------------------------------------------
+
+// Returns v in hexadecimal with the 0x prefix and four digits.
+func hex16(v uint16) string {
+	s := strconv.FormatUint(uint64(v), 16)
+	return "0x" + "000"[:4-min(len(s), 4)] + s
+}
+
+//--------------------------------------
+
+// Error returns the description of the mismatch.
+func (aE *ChecksumError) Error() string {
+	vRet := "crc16: "
+	if aE.Algo != "" {
+		vRet += aE.Algo + " "
+	}
+	vRet += "checksum mismatch: expected " + hex16(aE.Expected) + ", computed " + hex16(aE.Actual)
+	if aE.Offset >= 0 {
+		vRet += " at offset " + strconv.FormatInt(aE.Offset, 10)
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Is reports whether aTarget is ErrChecksumMismatch.
+func (aE *ChecksumError) Is(aTarget error) bool {
+	return aTarget == ErrChecksumMismatch
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,47 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import (
+	"io"
+	"os"
+)
+
+//-----------------------------------------------------------------------------
+
+// The size of the buffer ChecksumFile reads files with where they cannot be mapped,
+// large enough for the slicing and folding engines to run at full speed.
+const cFileChunk = 1 << 20
+
+// Files shorter than this are read rather than mapped, mapping costing more than it saves.
+const cMapMin = 64 << 10
+
+//-----------------------------------------------------------------------------
+
+// ChecksumFile returns the checksum of the content of the named file. Regular files are
+// mapped into memory where supported and read through a large buffer otherwise.
+func ChecksumFile(aPath string, aTable *TTable) (uint16, error) {
+	vFile, err := os.Open(aPath)
+	if err != nil {
+		return 0, err
+	}
+	defer vFile.Close()
+
+	if vSum, ok, err := checksumMapped(vFile, aTable); ok {
+		return vSum, err
+	}
+	vBuf := make([]byte, cFileChunk)
+	crc := Init(aTable)
+	for {
+		n, err := io.ReadFull(vFile, vBuf)
+		crc = Update(crc, vBuf[:n], aTable)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return Complete(crc, aTable), nil
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,30 @@
+//-----------------------------------------------------------------------------
+
+//go:build unix && !tinygo && !crc16_tiny
+
+package crc16
+
+import (
+	"math"
+	"os"
+	"syscall"
+)
+
+//-----------------------------------------------------------------------------
+
+// Returns the checksum of the content of the file mapped into memory and true, or false
+// if the file is not a regular file, is too short or too long, or cannot be mapped.
+func checksumMapped(aFile *os.File, aTable *TTable) (uint16, bool, error) {
+	vInfo, err := aFile.Stat()
+	if err != nil || !vInfo.Mode().IsRegular() || vInfo.Size() < cMapMin || vInfo.Size() > math.MaxInt {
+		return 0, false, nil
+	}
+	vData, err := syscall.Mmap(int(aFile.Fd()), 0, int(vInfo.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return 0, false, nil
+	}
+	vSum := Checksum(vData, aTable)
+	return vSum, true, syscall.Munmap(vData)
+}
+
+//-----------------------------------------------------------------------------
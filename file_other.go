@@ -0,0 +1,16 @@
+//-----------------------------------------------------------------------------
+
+//go:build !unix || tinygo || crc16_tiny
+
+package crc16
+
+import "os"
+
+//-----------------------------------------------------------------------------
+
+// Returns false: files are not mapped into memory.
+func checksumMapped(aFile *os.File, aTable *TTable) (uint16, bool, error) {
+	return 0, false, nil
+}
+
+//-----------------------------------------------------------------------------
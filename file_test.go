@@ -0,0 +1,40 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nocatalogue || crc16_ccitt
+
+package crc16
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestChecksumFile(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_KERMIT)
+		vDir := aT.TempDir()
+		for _, vLen := range []int{0, 9, cMapMin - 1, cMapMin, cFileChunk + 1000} {
+			vData := make([]byte, vLen)
+			for i := range vData {
+				vData[i] = byte(i * 31)
+			}
+			vPath := filepath.Join(vDir, "data")
+			So(os.WriteFile(vPath, vData, 0o600), ShouldBeNil)
+			vSum, err := ChecksumFile(vPath, vTable)
+			So(err, ShouldBeNil)
+			So(vSum, ShouldEqual, Checksum(vData, vTable))
+		}
+
+		_, err := ChecksumFile(filepath.Join(vDir, "missing"), vTable)
+		So(os.IsNotExist(err), ShouldBeTrue)
+		_, err = ChecksumFile(vDir, vTable)
+		So(err, ShouldNotBeNil)
+	})
+}
+
+//-----------------------------------------------------------------------------
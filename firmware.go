@@ -0,0 +1,98 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package crc16
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+//-----------------------------------------------------------------------------
+
+// This file contains the support of firmware images carrying their own checksum
+// in a field at a fixed offset.
+
+// TImageLayout describes where an image stores its checksum. The checksum covers the whole
+// image except the checksum field itself and the Skip ranges, e.g. signatures or padding
+// filled in after the checksum. A nil Order means a big-endian field.
+type TImageLayout struct {
+	Offset int
+	Order  binary.ByteOrder
+	Skip   []TRange
+}
+
+//-----------------------------------------------------------------------------
+
+// Returns the byte order of the checksum field.
+func (aL *TImageLayout) order() binary.ByteOrder {
+	if aL.Order == nil {
+		return binary.BigEndian
+	}
+	return aL.Order
+}
+
+//--------------------------------------
+
+// Checksum returns the checksum of the image according to the layout,
+// or an error if the checksum field lies outside the image.
+func (aL *TImageLayout) Checksum(aImage []byte, aTable *TTable) (uint16, error) {
+	if aL.Offset < 0 || aL.Offset+2 > len(aImage) {
+		return 0, fmt.Errorf("crc16: checksum field at 0x%x outside the %d-byte image", aL.Offset, len(aImage))
+	}
+	vSkip := append([]TRange{{aL.Offset, 2}}, aL.Skip...)
+	return ChecksumMasked(aImage, vSkip, aTable), nil
+}
+
+//--------------------------------------
+
+// Stored returns the checksum stored in the field of the image.
+// The field must lie within the image.
+func (aL *TImageLayout) Stored(aImage []byte) uint16 {
+	return aL.order().Uint16(aImage[aL.Offset:])
+}
+
+//-----------------------------------------------------------------------------
+
+// VerifyImage returns true if the checksum stored in the image matches its contents.
+func VerifyImage(aImage []byte, aLayout TImageLayout, aTable *TTable) (bool, error) {
+	vSum, err := aLayout.Checksum(aImage, aTable)
+	if err != nil {
+		return false, err
+	}
+	vOk := aLayout.Stored(aImage) == vSum
+	countVerified(len(aImage), vOk)
+	return vOk, nil
+}
+
+//--------------------------------------
+
+// CheckImage is VerifyImage returning a *ChecksumError, with the offset of the checksum field,
+// if the stored checksum does not match the contents of the image.
+func CheckImage(aImage []byte, aLayout TImageLayout, aTable *TTable) error {
+	vSum, err := aLayout.Checksum(aImage, aTable)
+	if err != nil {
+		return err
+	}
+	vStored := aLayout.Stored(aImage)
+	countVerified(len(aImage), vStored == vSum)
+	if vStored != vSum {
+		return &ChecksumError{Algo: aTable.algo.Name, Expected: vStored, Actual: vSum, Offset: int64(aLayout.Offset)}
+	}
+	return nil
+}
+
+//--------------------------------------
+
+// PatchImage stores the checksum of the image in its field in place and returns it.
+func PatchImage(aImage []byte, aLayout TImageLayout, aTable *TTable) (uint16, error) {
+	vSum, err := aLayout.Checksum(aImage, aTable)
+	if err != nil {
+		return 0, err
+	}
+	aLayout.order().PutUint16(aImage[aLayout.Offset:], vSum)
+	return vSum, nil
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,73 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || crc16_ccitt)
+
+package crc16
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestImage(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_CCITT_FALSE)
+		vImage := make([]byte, 0x200)
+		for i := range vImage {
+			vImage[i] = byte(i)
+		}
+		vLayout := TImageLayout{Offset: 0x1fc, Order: binary.LittleEndian, Skip: []TRange{{0x1fe, 2}}}
+
+		vOk, err := VerifyImage(vImage, vLayout, vTable)
+		So(err, ShouldBeNil)
+		So(vOk, ShouldBeFalse)
+
+		vSum, err := PatchImage(vImage, vLayout, vTable)
+		So(err, ShouldBeNil)
+		So(vSum, ShouldEqual, Checksum(vImage[:0x1fc], vTable))
+		So(binary.LittleEndian.Uint16(vImage[0x1fc:]), ShouldEqual, vSum)
+		So(vLayout.Stored(vImage), ShouldEqual, vSum)
+
+		vImage[0x1ff] ^= 0xff
+		vOk, err = VerifyImage(vImage, vLayout, vTable)
+		So(err, ShouldBeNil)
+		So(vOk, ShouldBeTrue)
+
+		vImage[0x10] ^= 0x01
+		vOk, _ = VerifyImage(vImage, vLayout, vTable)
+		So(vOk, ShouldBeFalse)
+
+		_, err = PatchImage(vImage, TImageLayout{Offset: 0x1ff}, vTable)
+		So(err, ShouldNotBeNil)
+	})
+}
+
+//--------------------------------------
+
+func TestCheckImage(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_CCITT_FALSE)
+		vImage := []byte("firmware\x00\x00")
+		vLayout := TImageLayout{Offset: 8}
+		_, err := PatchImage(vImage, vLayout, vTable)
+		So(err, ShouldBeNil)
+		So(CheckImage(vImage, vLayout, vTable), ShouldBeNil)
+
+		vImage[0] = 'F'
+		var vMismatch *ChecksumError
+		So(errors.As(CheckImage(vImage, vLayout, vTable), &vMismatch), ShouldBeTrue)
+		So(vMismatch.Offset, ShouldEqual, 8)
+		So(vMismatch.Actual, ShouldEqual, Checksum(vImage[:8], vTable))
+
+		err = CheckImage(vImage, TImageLayout{Offset: 9}, vTable)
+		So(err, ShouldNotBeNil)
+		So(errors.Is(err, ErrChecksumMismatch), ShouldBeFalse)
+	})
+}
+
+//-----------------------------------------------------------------------------
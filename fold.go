@@ -0,0 +1,50 @@
+//-----------------------------------------------------------------------------
+
+//go:build (amd64 || arm64) && !crc16_nibble && !purego && !tinygo
+
+package crc16
+
+//-----------------------------------------------------------------------------
+
+// This file contains the folding engine, which folds 64 bytes of input per step by carry-less
+// multiplication, with PCLMULQDQ on amd64 and PMULL on arm64, into a 16-byte remainder
+// congruent to the input modulo the polynomial. Reflected input is reversed bit by bit
+// with nibble lookups, so one kernel serves all algorithms.
+
+// The nibble lookups leaving input bytes as they are or reversing their bits.
+var (
+	foldBytesDirect    = [2][16]byte{{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, {0x00, 0x10, 0x20, 0x30, 0x40, 0x50, 0x60, 0x70, 0x80, 0x90, 0xA0, 0xB0, 0xC0, 0xD0, 0xE0, 0xF0}}
+	foldBytesReflected = [2][16]byte{{0x00, 0x80, 0x40, 0xC0, 0x20, 0xA0, 0x60, 0xE0, 0x10, 0x90, 0x50, 0xD0, 0x30, 0xB0, 0x70, 0xF0}, {0x0, 0x8, 0x4, 0xC, 0x2, 0xA, 0x6, 0xE, 0x1, 0x9, 0x5, 0xD, 0x3, 0xB, 0x7, 0xF}}
+)
+
+// The processor supports carry-less multiplication.
+var hasFold = detectFold()
+
+//-----------------------------------------------------------------------------
+
+// Folds the n bytes at p, a multiple of 16 not less than 64, with the register aInit added
+// to the first two bytes, and stores the remainder in aAcc, most significant byte first.
+//
+//go:noescape
+func foldBlocks(p *byte, n int, aInit uint16, aNibbles *[2][16]byte, aConsts *[4]uint64, aAcc *[16]byte)
+
+//--------------------------------------
+
+// Returns the register after shifting in the leading multiple of 16 bytes of data,
+// and the bytes left.
+func fold(crc uint16, data []byte, aTables *tSliceTables, aTable *TTable) (uint16, []byte) {
+	vNibbles := &foldBytesDirect
+	if aTable.algo.RefIn {
+		vNibbles = &foldBytesReflected
+	}
+	var vAcc [16]byte
+	n := len(data) &^ 15
+	foldBlocks(&data[0], n, crc, vNibbles, &aTables.fold, &vAcc)
+	crc = 0
+	for _, d := range vAcc {
+		crc = aTable.data.shift(crc, d)
+	}
+	return crc, data[n:]
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,25 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nibble && !purego && !tinygo
+
+package crc16
+
+//-----------------------------------------------------------------------------
+
+// Returns the results of the CPUID instruction.
+//
+//go:noescape
+func cpuid(aLeaf, aSubLeaf uint32) (eax, ebx, ecx, edx uint32)
+
+//--------------------------------------
+
+// Reports whether the processor supports PCLMULQDQ, and SSSE3 for PSHUFB.
+func detectFold() bool {
+	if vMax, _, _, _ := cpuid(0, 0); vMax < 1 {
+		return false
+	}
+	_, _, vECX, _ := cpuid(1, 0)
+	return vECX&(1<<1) != 0 && vECX&(1<<9) != 0
+}
+
+//-----------------------------------------------------------------------------
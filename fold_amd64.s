@@ -0,0 +1,123 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nibble && !purego && !tinygo
+
+#include "textflag.h"
+
+//-----------------------------------------------------------------------------
+
+// This file contains the PCLMULQDQ kernel of the folding engine.
+
+// Reverses the bytes of a register, so that the first byte of input is the most significant.
+DATA foldSwap<>+0(SB)/8, $0x08090a0b0c0d0e0f
+DATA foldSwap<>+8(SB)/8, $0x0001020304050607
+GLOBL foldSwap<>(SB), RODATA|NOPTR, $16
+
+// Masks the low nibbles of the bytes.
+DATA foldNibble<>+0(SB)/8, $0x0f0f0f0f0f0f0f0f
+DATA foldNibble<>+8(SB)/8, $0x0f0f0f0f0f0f0f0f
+GLOBL foldNibble<>(SB), RODATA|NOPTR, $16
+
+// Loads the 16 bytes at off(SI) into r, the first most significant, with the bits of
+// the bytes reversed by the nibble lookups in X12 and X13 for reflected input.
+#define LOAD(off, r, t) \
+	MOVOU  off(SI), r; \
+	PSHUFB X15, r;     \
+	MOVOU  r, t;       \
+	PSRLW  $4, t;      \
+	PAND   X14, r;     \
+	PAND   X14, t;     \
+	MOVOU  X12, X8;    \
+	PSHUFB r, X8;      \
+	MOVOU  X13, r;     \
+	PSHUFB t, r;       \
+	POR    X8, r
+
+// Multiplies x by the power of x whose residues are the halves of k, clobbering t, and adds next.
+#define FOLD(k, x, t, next) \
+	MOVOU     x, t;       \
+	PCLMULQDQ $0x11, k, x; \
+	PCLMULQDQ $0x00, k, t; \
+	PXOR      t, x;       \
+	PXOR      next, x
+
+//-----------------------------------------------------------------------------
+
+// func cpuid(aLeaf, aSubLeaf uint32) (eax, ebx, ecx, edx uint32)
+TEXT ·cpuid(SB), NOSPLIT, $0-24
+	MOVL aLeaf+0(FP), AX
+	MOVL aSubLeaf+4(FP), CX
+	CPUID
+	MOVL AX, eax+8(FP)
+	MOVL BX, ebx+12(FP)
+	MOVL CX, ecx+16(FP)
+	MOVL DX, edx+20(FP)
+	RET
+
+//-----------------------------------------------------------------------------
+
+// func foldBlocks(p *byte, n int, aInit uint16, aNibbles *[2][16]byte, aConsts *[4]uint64, aAcc *[16]byte)
+TEXT ·foldBlocks(SB), NOSPLIT, $0-48
+	MOVQ  p+0(FP), SI
+	MOVQ  n+8(FP), CX
+	MOVQ  aNibbles+24(FP), AX
+	MOVOU 0(AX), X12
+	MOVOU 16(AX), X13
+	MOVQ  aConsts+32(FP), AX
+	MOVOU 0(AX), X10
+	MOVOU 16(AX), X11
+	MOVOU foldSwap<>(SB), X15
+	MOVOU foldNibble<>(SB), X14
+
+	// Four lanes of 16 bytes are folded by 512 bits at a time, the register
+	// added to the most significant bits of the first.
+	LOAD(0, X0, X4)
+	LOAD(16, X1, X5)
+	LOAD(32, X2, X6)
+	LOAD(48, X3, X7)
+	MOVWQZX aInit+16(FP), AX
+	SHLQ    $48, AX
+	MOVQ    AX, X4
+	PSLLO   $8, X4
+	PXOR    X4, X0
+	ADDQ    $64, SI
+	SUBQ    $64, CX
+
+loop64:
+	CMPQ CX, $64
+	JB   fold4
+	LOAD(0, X4, X9)
+	FOLD(X11, X0, X9, X4)
+	LOAD(16, X4, X9)
+	FOLD(X11, X1, X9, X4)
+	LOAD(32, X4, X9)
+	FOLD(X11, X2, X9, X4)
+	LOAD(48, X4, X9)
+	FOLD(X11, X3, X9, X4)
+	ADDQ $64, SI
+	SUBQ $64, CX
+	JMP  loop64
+
+fold4:
+	// The lanes are folded into one by 128 bits at a time,
+	// followed by the blocks left.
+	FOLD(X10, X0, X9, X1)
+	FOLD(X10, X0, X9, X2)
+	FOLD(X10, X0, X9, X3)
+
+loop16:
+	CMPQ CX, $16
+	JB   done
+	LOAD(0, X4, X9)
+	FOLD(X10, X0, X9, X4)
+	ADDQ $16, SI
+	SUBQ $16, CX
+	JMP  loop16
+
+done:
+	PSHUFB X15, X0
+	MOVQ   aAcc+40(FP), DI
+	MOVOU  X0, 0(DI)
+	RET
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,46 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nibble && !purego && !tinygo
+
+package crc16
+
+import (
+	"os"
+	"runtime"
+)
+
+//-----------------------------------------------------------------------------
+
+// Reports whether the processor supports PMULL, as reported by the auxiliary vector
+// on Linux. All the processors macOS runs on do.
+func detectFold() bool {
+	switch runtime.GOOS {
+	case "darwin", "ios":
+		return true
+	case "linux", "android":
+		const cHwcap, cHwcapPMULL = 16, 1 << 4
+		vAuxv, err := os.ReadFile("/proc/self/auxv")
+		if err != nil {
+			return false
+		}
+		for ; len(vAuxv) >= 16; vAuxv = vAuxv[16:] {
+			if le64(vAuxv) == cHwcap {
+				return le64(vAuxv[8:])&cHwcapPMULL != 0
+			}
+		}
+	}
+	return false
+}
+
+//--------------------------------------
+
+// Returns the little-endian word at the start of b.
+func le64(b []byte) uint64 {
+	var vRet uint64
+	for i := 7; i >= 0; i-- {
+		vRet = vRet<<8 | uint64(b[i])
+	}
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,92 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nibble && !purego && !tinygo
+
+#include "textflag.h"
+
+//-----------------------------------------------------------------------------
+
+// This file contains the PMULL kernel of the folding engine.
+
+// Loads the next 16 bytes at R0 into r, the first most significant, with the bits of
+// the bytes reversed by the nibble lookups in V12 and V13 for reflected input.
+#define LOAD(r, t) \
+	VLD1.P 16(R0), [r.B16];        \
+	VREV64 r.B16, r.B16;           \
+	VEXT   $8, r.B16, r.B16, r.B16; \
+	VUSHR  $4, r.B16, t.B16;       \
+	VAND   V14.B16, r.B16, r.B16;  \
+	VTBL   r.B16, [V12.B16], r.B16; \
+	VTBL   t.B16, [V13.B16], t.B16; \
+	VORR   t.B16, r.B16, r.B16
+
+// Multiplies x by the power of x whose residues are the halves of k, clobbering t and u,
+// and adds next.
+#define FOLD(k, x, t, u, next) \
+	VPMULL  k.D1, x.D1, t.Q1;    \
+	VPMULL2 k.D2, x.D2, u.Q1;    \
+	VEOR    t.B16, u.B16, x.B16; \
+	VEOR    next.B16, x.B16, x.B16
+
+//-----------------------------------------------------------------------------
+
+// func foldBlocks(p *byte, n int, aInit uint16, aNibbles *[2][16]byte, aConsts *[4]uint64, aAcc *[16]byte)
+TEXT ·foldBlocks(SB), NOSPLIT, $0-48
+	MOVD  p+0(FP), R0
+	MOVD  n+8(FP), R1
+	MOVD  aNibbles+24(FP), R3
+	VLD1  (R3), [V12.B16, V13.B16]
+	MOVD  aConsts+32(FP), R3
+	VLD1  (R3), [V10.D2, V11.D2]
+	VMOVI $15, V14.B16
+
+	// Four lanes of 16 bytes are folded by 512 bits at a time, the register
+	// added to the most significant bits of the first.
+	LOAD(V0, V4)
+	LOAD(V1, V5)
+	LOAD(V2, V6)
+	LOAD(V3, V7)
+	MOVHU aInit+16(FP), R2
+	LSL   $48, R2
+	VEOR  V4.B16, V4.B16, V4.B16
+	VMOV  R2, V4.D[1]
+	VEOR  V4.B16, V0.B16, V0.B16
+	SUB   $64, R1
+
+loop64:
+	CMP  $64, R1
+	BLT  fold4
+	LOAD(V4, V9)
+	FOLD(V11, V0, V8, V9, V4)
+	LOAD(V4, V9)
+	FOLD(V11, V1, V8, V9, V4)
+	LOAD(V4, V9)
+	FOLD(V11, V2, V8, V9, V4)
+	LOAD(V4, V9)
+	FOLD(V11, V3, V8, V9, V4)
+	SUB  $64, R1
+	B    loop64
+
+fold4:
+	// The lanes are folded into one by 128 bits at a time,
+	// followed by the blocks left.
+	FOLD(V10, V0, V8, V9, V1)
+	FOLD(V10, V0, V8, V9, V2)
+	FOLD(V10, V0, V8, V9, V3)
+
+loop16:
+	CMP  $16, R1
+	BLT  done
+	LOAD(V4, V9)
+	FOLD(V10, V0, V8, V9, V4)
+	SUB  $16, R1
+	B    loop16
+
+done:
+	VREV64 V0.B16, V0.B16
+	VEXT   $8, V0.B16, V0.B16, V0.B16
+	MOVD   aAcc+40(FP), R3
+	VST1   [V0.B16], (R3)
+	RET
+
+//-----------------------------------------------------------------------------
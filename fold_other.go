@@ -0,0 +1,19 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nibble && (!(amd64 || arm64) || purego || tinygo)
+
+package crc16
+
+//-----------------------------------------------------------------------------
+
+// There is no folding engine.
+const hasFold = false
+
+//-----------------------------------------------------------------------------
+
+// Returns the register and data unchanged.
+func fold(crc uint16, data []byte, aTables *tSliceTables, aTable *TTable) (uint16, []byte) {
+	return crc, data
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,105 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import "sort"
+
+//-----------------------------------------------------------------------------
+
+// This file contains facilities crafting data with chosen checksums.
+//
+// Bit positions follow the convention of the error correction facilities:
+// position p refers to bit p%8 (least significant bit is 0) of byte p/8.
+
+//-----------------------------------------------------------------------------
+
+// FindBitFlips returns the smallest set of bit positions within [aFrom, aTo) whose flipping
+// changes the checksum of data to aTarget. Data itself is left unmodified.
+// It returns false if no combination of bits in the region reaches the target.
+//
+// The search is a breadth-first walk over the 2^16 checksum differences, so its cost is
+// bounded by the region size times 65536 regardless of the number of flips needed.
+func FindBitFlips(data []byte, aFrom, aTo int, aTarget uint16, aTable *TTable) ([]int, bool) {
+	aFrom = max(aFrom, 0)
+	aTo = min(aTo, 8*len(data))
+	vDiff := Checksum(data, aTable) ^ aTarget
+	if vDiff == 0 {
+		return []int{}, true
+	}
+
+	// Distinct checksum differences reachable by a single flip and a position producing each.
+	var vGen [65536]int32
+	for i := range vGen {
+		vGen[i] = -1
+	}
+	var vGens []uint16
+	vSyn := bitSyndromes(aTable, len(data))
+	for p := aFrom; p < aTo; p++ {
+		if s := vSyn[p]; s != 0 && vGen[s] < 0 {
+			vGen[s] = int32(p)
+			vGens = append(vGens, s)
+		}
+	}
+	if len(vGens) == 0 {
+		return nil, false
+	}
+
+	// Breadth-first search from vDiff towards 0; vVia records the difference
+	// removed last to reach every visited node.
+	var vVia [65536]uint16
+	var vSeen [65536]bool
+	vSeen[vDiff] = true
+	vPath := func(v uint16) []int {
+		var vRet []int
+		for v != vDiff {
+			vRet = append(vRet, int(vGen[vVia[v]]))
+			v ^= vVia[v]
+		}
+		return vRet
+	}
+
+	vLayer := []uint16{vDiff}
+	for len(vLayer) > 0 {
+		var vNext []uint16
+		for _, v := range vLayer {
+			if vGen[v] >= 0 {
+				vRet := append(vPath(v), int(vGen[v]))
+				sort.Ints(vRet)
+				return vRet, true
+			}
+			for _, g := range vGens {
+				if w := v ^ g; !vSeen[w] {
+					vSeen[w] = true
+					vVia[w] = g
+					vNext = append(vNext, w)
+				}
+			}
+		}
+		vLayer = vNext
+	}
+	return nil, false
+}
+
+//--------------------------------------
+
+// ForgeSuffix returns the two bytes which, appended to data, make its checksum aTarget.
+// It returns false for polynomials without the x^0 term, whose checksums cannot reach
+// every value that way.
+func ForgeSuffix(data []byte, aTarget uint16, aTable *TTable) ([]byte, bool) {
+	// The checksum is an affine function of the suffix bits.
+	vCrc := Update(Init(aTable), data, aTable)
+	vBase := Complete(Update(vCrc, []byte{0, 0}, aTable), aTable)
+	var vM tMatrix
+	for i := range vM {
+		vBit := uint16(1) << i
+		vSuffix := []byte{byte(vBit >> 8), byte(vBit)}
+		vM[i] = Complete(Update(vCrc, vSuffix, aTable), aTable) ^ vBase
+	}
+	vSol, vOk := vM.solve(aTarget ^ vBase)
+	if !vOk {
+		return nil, false
+	}
+	return []byte{byte(vSol >> 8), byte(vSol)}, true
+}
+
+//-----------------------------------------------------------------------------
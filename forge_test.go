@@ -0,0 +1,93 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nocatalogue || crc16_ccitt
+
+package crc16
+
+import (
+	"math/bits"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestFindBitFlips(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_CCITT_FALSE)
+		vData := []byte("a frame to be corrupted minimally")
+		vApply := func(aPos []int) []byte {
+			vRet := append([]byte(nil), vData...)
+			for _, p := range aPos {
+				vRet[p/8] ^= 1 << (p % 8)
+			}
+			return vRet
+		}
+
+		vPos, vOk := FindBitFlips(vData, 0, 8*len(vData), Checksum(vData, vTable), vTable)
+		So(vOk, ShouldBeTrue)
+		So(vPos, ShouldBeEmpty)
+
+		for _, vTarget := range []uint16{0x0000, 0xBEEF, 0x1234} {
+			vPos, vOk = FindBitFlips(vData, 16, 8*len(vData), vTarget, vTable)
+			So(vOk, ShouldBeTrue)
+			So(Checksum(vApply(vPos), vTable), ShouldEqual, vTarget)
+			for _, p := range vPos {
+				So(p, ShouldBeGreaterThanOrEqualTo, 16)
+			}
+		}
+
+		// Compare with exhaustive search over a narrow region.
+		const cFrom, cWidth = 40, 14
+		vReachable := Checksum(vApply([]int{cFrom + 1, cFrom + 6, cFrom + 11}), vTable)
+		for _, vTarget := range []uint16{vReachable, 0xBEEF, 0x5555} {
+			vBest := -1
+			for m := uint32(0); m < 1<<cWidth; m++ {
+				var vSet []int
+				for b := 0; b < cWidth; b++ {
+					if m&(1<<b) != 0 {
+						vSet = append(vSet, cFrom+b)
+					}
+				}
+				if Checksum(vApply(vSet), vTable) == vTarget && (vBest < 0 || bits.OnesCount32(m) < vBest) {
+					vBest = bits.OnesCount32(m)
+				}
+			}
+			vPos, vOk = FindBitFlips(vData, cFrom, cFrom+cWidth, vTarget, vTable)
+			So(vOk, ShouldEqual, vBest >= 0)
+			if vOk {
+				So(len(vPos), ShouldEqual, vBest)
+				So(Checksum(vApply(vPos), vTable), ShouldEqual, vTarget)
+			}
+		}
+
+		_, vOk = FindBitFlips(vData, 8, 8, 0xBEEF, vTable)
+		So(vOk, ShouldBeFalse)
+	})
+}
+
+//--------------------------------------
+
+func TestForgeSuffix(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vData := []byte("123456789")
+		for _, a := range Algorithms() {
+			vTable := MakeTable(a)
+			for _, vTarget := range []uint16{0, 0xbeef, a.Check, 0xffff} {
+				vSuffix, vOk := ForgeSuffix(vData, vTarget, vTable)
+				So(vOk, ShouldBeTrue)
+				So(Checksum(append(vData[:9:9], vSuffix...), vTable), ShouldEqual, vTarget)
+			}
+		}
+
+		vSuffix, vOk := ForgeSuffix(nil, 0x1234, MakeTable(CRC16_XMODEM))
+		So(vOk, ShouldBeTrue)
+		So(Checksum(vSuffix, MakeTable(CRC16_XMODEM)), ShouldEqual, 0x1234)
+
+		_, vOk = ForgeSuffix(vData, 1, MakeTable(TAlgo{Poly: 0x1020}))
+		So(vOk, ShouldBeFalse)
+	})
+}
+
+//-----------------------------------------------------------------------------
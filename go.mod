@@ -2,9 +2,10 @@ module github.com/mbsulliv/crc16
 
 go 1.23.4
 
+require github.com/smartystreets/goconvey v1.8.1
+
 require (
 	github.com/gopherjs/gopherjs v1.17.2 // indirect
 	github.com/jtolds/gls v4.20.0+incompatible // indirect
 	github.com/smarty/assertions v1.15.0 // indirect
-	github.com/smartystreets/goconvey v1.8.1 // indirect
 )
@@ -0,0 +1,19 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package crc16
+
+import "os"
+
+//-----------------------------------------------------------------------------
+
+// Applies the crc16impl setting of GODEBUG, unknown or unavailable engines being ignored
+// like unknown GODEBUG settings.
+func init() {
+	if vImpl, vFound := godebugImplementation(os.Getenv("GODEBUG")); vFound {
+		SetImplementation(vImpl)
+	}
+}
+
+//-----------------------------------------------------------------------------
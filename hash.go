@@ -2,7 +2,11 @@
 
 package crc16
 
-import "hash"
+import (
+	"hash"
+	"io"
+	"sync"
+)
 
 //-----------------------------------------------------------------------------
 
@@ -14,6 +18,18 @@ type Hash16 interface {
 	Sum16() uint16
 }
 
+// Cloner is implemented by the digests of this package, which copy their state with Clone,
+// e.g. to checksum a common prefix once and then several candidate suffixes.
+type Cloner interface {
+	Clone() Hash16
+}
+
+// LittleEndianSummer is implemented by the digests of this package, which append their
+// checksum little-endian with SumLE, e.g. for the Modbus and HDLC trailers.
+type LittleEndianSummer interface {
+	SumLE(b []byte) []byte
+}
+
 type digest struct {
 	sum uint16
 	t   *TTable
@@ -30,6 +46,36 @@ func (aH *digest) Write(data []byte) (int, error) {
 
 //--------------------------------------
 
+// WriteByte adds b to the running digest, e.g. when parsing headers byte by byte.
+// It never returns an error.
+func (aH *digest) WriteByte(b byte) error {
+	aH.sum = Update(aH.sum, []byte{b}, aH.t)
+	return nil
+}
+
+//--------------------------------------
+
+// WriteString adds the bytes of s to the running digest like Write, without converting s
+// to a byte slice.
+// It never returns an error.
+func (aH *digest) WriteString(s string) (int, error) {
+	aH.sum = Update(aH.sum, stringBytes(s), aH.t)
+	return len(s), nil
+}
+
+//--------------------------------------
+
+// ReadFrom adds the content of r, read until EOF, to the running digest in large chunks,
+// so that io.Copy bypasses its own buffer. It returns the number of bytes read and
+// any read error other than EOF, the bytes read before the error being added.
+func (aH *digest) ReadFrom(r io.Reader) (int64, error) {
+	vSum, vLen, err := updateReader(aH.sum, r, aH.t)
+	aH.sum = vSum
+	return vLen, err
+}
+
+//--------------------------------------
+
 // Sum appends the current digest (leftmost byte first, big-endian)
 // to b and returns the resulting slice.
 // It does not change the underlying digest state.
@@ -40,9 +86,28 @@ func (aH digest) Sum(b []byte) []byte {
 
 //--------------------------------------
 
+// SumLE appends the current digest little-endian, rightmost byte first,
+// to b and returns the resulting slice, e.g. for the Modbus and HDLC trailers.
+// It does not change the underlying digest state.
+func (aH digest) SumLE(b []byte) []byte {
+	s := aH.Sum16()
+	return append(b, byte(s), byte(s>>8))
+}
+
+//--------------------------------------
+
+// Clone returns an independent copy of the digest in its current state, e.g. to checksum
+// a common prefix once and then several candidate suffixes.
+func (aH *digest) Clone() Hash16 {
+	vRet := *aH
+	return &vRet
+}
+
+//--------------------------------------
+
 // Reset resets the Hash to its initial state.
 func (aH *digest) Reset() {
-	aH.sum = aH.t.algo.Init
+	aH.sum = Init(aH.t)
 }
 
 //--------------------------------------
@@ -77,3 +142,268 @@ func New(t *TTable) Hash16 {
 }
 
 //-----------------------------------------------------------------------------
+
+// engineDigest is the digest computing the checksum with an Engine.
+type engineDigest struct {
+	engine Engine
+	sum    uint16
+}
+
+//-----------------------------------------------------------------------------
+
+// NewFromEngine creates a new CRC16 digest computing the checksum with the given engine.
+// Unlike the digest of New, it does not implement encoding.BinaryMarshaler, since an
+// Engine does not identify its algorithm.
+func NewFromEngine(e Engine) Hash16 {
+	return &engineDigest{engine: e, sum: e.Init()}
+}
+
+//--------------------------------------
+
+// Write adds more data to the running digest.
+// It never returns an error.
+func (aH *engineDigest) Write(data []byte) (int, error) {
+	aH.sum = aH.engine.Update(aH.sum, data)
+	return len(data), nil
+}
+
+//--------------------------------------
+
+// WriteByte adds b to the running digest.
+// It never returns an error.
+func (aH *engineDigest) WriteByte(b byte) error {
+	aH.sum = aH.engine.Update(aH.sum, []byte{b})
+	return nil
+}
+
+//--------------------------------------
+
+// WriteString adds the bytes of s to the running digest like Write, without converting s
+// to a byte slice.
+// It never returns an error.
+func (aH *engineDigest) WriteString(s string) (int, error) {
+	aH.sum = aH.engine.Update(aH.sum, stringBytes(s))
+	return len(s), nil
+}
+
+//--------------------------------------
+
+// ReadFrom adds the content of r, read until EOF, to the running digest in large chunks,
+// like the digest of New.
+func (aH *engineDigest) ReadFrom(r io.Reader) (int64, error) {
+	vBuf := make([]byte, cReadChunk)
+	var vLen int64
+	for {
+		n, err := r.Read(vBuf)
+		aH.sum = aH.engine.Update(aH.sum, vBuf[:n])
+		vLen += int64(n)
+		if err == io.EOF {
+			return vLen, nil
+		}
+		if err != nil {
+			return vLen, err
+		}
+	}
+}
+
+//--------------------------------------
+
+// Sum appends the current digest (leftmost byte first, big-endian)
+// to b and returns the resulting slice.
+// It does not change the underlying digest state.
+func (aH *engineDigest) Sum(b []byte) []byte {
+	s := aH.Sum16()
+	return append(b, byte(s>>8), byte(s))
+}
+
+//--------------------------------------
+
+// SumLE appends the current digest little-endian, rightmost byte first,
+// to b and returns the resulting slice.
+// It does not change the underlying digest state.
+func (aH *engineDigest) SumLE(b []byte) []byte {
+	s := aH.Sum16()
+	return append(b, byte(s), byte(s>>8))
+}
+
+//--------------------------------------
+
+// Clone returns an independent copy of the digest in its current state.
+func (aH *engineDigest) Clone() Hash16 {
+	vRet := *aH
+	return &vRet
+}
+
+//--------------------------------------
+
+// Reset resets the Hash to its initial state.
+func (aH *engineDigest) Reset() {
+	aH.sum = aH.engine.Init()
+}
+
+//--------------------------------------
+
+// Size returns the number of bytes Sum will return.
+func (aH *engineDigest) Size() int {
+	return 2
+}
+
+//--------------------------------------
+
+// BlockSize returns the underlying block size.
+func (aH *engineDigest) BlockSize() int {
+	return 1
+}
+
+//--------------------------------------
+
+// Sum16 returns the CRC16 checksum.
+func (aH *engineDigest) Sum16() uint16 {
+	return aH.engine.Complete(aH.sum)
+}
+
+//-----------------------------------------------------------------------------
+
+// digest32 and digest64 are the digest with the checksum zero-extended, for libraries
+// requiring hash.Hash32 or hash.Hash64.
+type digest32 struct {
+	digest
+}
+
+type digest64 struct {
+	digest
+}
+
+//-----------------------------------------------------------------------------
+
+// NewHash32 creates a new CRC16 digest for the given table summing to 32 bits,
+// the checksum zero-extended.
+func NewHash32(t *TTable) hash.Hash32 {
+	return &digest32{digest{sum: Init(t), t: t}}
+}
+
+//--------------------------------------
+
+// Sum appends the zero-extended digest big-endian to b and returns the resulting slice.
+// It does not change the underlying digest state.
+func (aH *digest32) Sum(b []byte) []byte {
+	return append(b, 0, 0, byte(aH.Sum16()>>8), byte(aH.Sum16()))
+}
+
+//--------------------------------------
+
+// Size returns the number of bytes Sum will return.
+func (aH *digest32) Size() int {
+	return 4
+}
+
+//--------------------------------------
+
+// Sum32 returns the CRC16 checksum zero-extended.
+func (aH *digest32) Sum32() uint32 {
+	return uint32(aH.Sum16())
+}
+
+//--------------------------------------
+
+// SumLE appends the zero-extended digest little-endian to b and returns the resulting slice.
+// It does not change the underlying digest state.
+func (aH *digest32) SumLE(b []byte) []byte {
+	return append(aH.digest.SumLE(b), 0, 0)
+}
+
+//--------------------------------------
+
+// Clone returns an independent copy of the digest in its current state, also summing
+// to 32 bits.
+func (aH *digest32) Clone() Hash16 {
+	vRet := *aH
+	return &vRet
+}
+
+//-----------------------------------------------------------------------------
+
+// NewHash64 creates a new CRC16 digest for the given table summing to 64 bits,
+// the checksum zero-extended.
+func NewHash64(t *TTable) hash.Hash64 {
+	return &digest64{digest{sum: Init(t), t: t}}
+}
+
+//--------------------------------------
+
+// Sum appends the zero-extended digest big-endian to b and returns the resulting slice.
+// It does not change the underlying digest state.
+func (aH *digest64) Sum(b []byte) []byte {
+	return append(b, 0, 0, 0, 0, 0, 0, byte(aH.Sum16()>>8), byte(aH.Sum16()))
+}
+
+//--------------------------------------
+
+// Size returns the number of bytes Sum will return.
+func (aH *digest64) Size() int {
+	return 8
+}
+
+//--------------------------------------
+
+// Sum64 returns the CRC16 checksum zero-extended.
+func (aH *digest64) Sum64() uint64 {
+	return uint64(aH.Sum16())
+}
+
+//--------------------------------------
+
+// SumLE appends the zero-extended digest little-endian to b and returns the resulting slice.
+// It does not change the underlying digest state.
+func (aH *digest64) SumLE(b []byte) []byte {
+	return append(aH.digest.SumLE(b), 0, 0, 0, 0, 0, 0)
+}
+
+//--------------------------------------
+
+// Clone returns an independent copy of the digest in its current state, also summing
+// to 64 bits.
+func (aH *digest64) Clone() Hash16 {
+	vRet := *aH
+	return &vRet
+}
+
+//-----------------------------------------------------------------------------
+
+// THashPool recycles the digests of a table, for services creating many short-lived ones.
+// Checksum, which never allocates, is the cheaper choice for data available at once.
+type THashPool struct {
+	table *TTable
+	pool  sync.Pool
+}
+
+//-----------------------------------------------------------------------------
+
+// NewHashPool returns a THashPool of digests for the given table.
+func NewHashPool(t *TTable) *THashPool {
+	vP := &THashPool{table: t}
+	vP.pool.New = func() any { return New(t) }
+	return vP
+}
+
+//--------------------------------------
+
+// Get returns a digest in its initial state.
+func (aP *THashPool) Get() Hash16 {
+	return aP.pool.Get().(Hash16)
+}
+
+//--------------------------------------
+
+// Put resets the digest and returns it to the pool. It must not be used afterwards.
+// Put panics if the digest was not created by New for the table of the pool.
+func (aP *THashPool) Put(aH Hash16) {
+	vD, ok := aH.(*digest)
+	if !ok || vD.t != aP.table {
+		panic("crc16: digest of another table put in the pool")
+	}
+	vD.Reset()
+	aP.pool.Put(vD)
+}
+
+//-----------------------------------------------------------------------------
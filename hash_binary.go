@@ -0,0 +1,158 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package crc16
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+//-----------------------------------------------------------------------------
+
+// This file contains the binary encoding of digest states, so that hashing can be
+// checkpointed and resumed, e.g. across process restarts or chunked uploads,
+// like the digests of hash/crc32 and hash/crc64.
+//
+// The encoding is the magic "c16d" and a version byte, the parameters of the algorithm
+// as in the table encoding without Check followed by the width in bits in a byte, and
+// the register as a big-endian word. Version 1 lacks the width and is rejected.
+// Text-mode digests use the magic "c16n" and append a byte set to 1 while a carriage
+// return is held back. The digests of NewHash32 and NewHash64 use the magics "c32z" and
+// "c64z", so that their states are not restored into digests of another size.
+
+const (
+	cDigestMagic     = "c16d"
+	cTextDigestMagic = "c16n"
+	cHash32Magic     = "c32z"
+	cHash64Magic     = "c64z"
+	cDigestVersion   = 2
+	cDigestSize      = len(cDigestMagic) + 1 + 8 + 2
+)
+
+//-----------------------------------------------------------------------------
+
+// Returns the parameters of the algorithm identifying it in the digest encoding.
+func appendDigestAlgo(b []byte, a *TAlgo) []byte {
+	var vFlags byte
+	if a.RefIn {
+		vFlags |= 1
+	}
+	if a.RefOut {
+		vFlags |= 2
+	}
+	b = binary.BigEndian.AppendUint16(b, a.Poly)
+	b = binary.BigEndian.AppendUint16(b, a.Init)
+	b = append(b, vFlags)
+	b = binary.BigEndian.AppendUint16(b, a.XorOut)
+	return append(b, byte(16-a.pad()))
+}
+
+//--------------------------------------
+
+// Returns the state of the digest encoded with the magic.
+func (aH *digest) appendBinary(b []byte, aMagic string) []byte {
+	b = appendDigestAlgo(append(append(b, aMagic...), cDigestVersion), &aH.t.algo)
+	return binary.BigEndian.AppendUint16(b, aH.sum)
+}
+
+//--------------------------------------
+
+// Restores the state of the digest encoded with the magic, followed by aExtra bytes
+// returned to the caller.
+func (aH *digest) unmarshalBinary(data []byte, aMagic string, aExtra int) ([]byte, error) {
+	if len(data) < len(aMagic)+1 || string(data[:len(aMagic)]) != aMagic {
+		return nil, errors.New("crc16: not an encoded digest state")
+	}
+	if data[len(aMagic)] != cDigestVersion {
+		return nil, errors.New("crc16: unsupported encoded digest state version")
+	}
+	if len(data) != cDigestSize+aExtra {
+		return nil, errors.New("crc16: corrupt encoded digest state")
+	}
+	vAlgo := appendDigestAlgo(nil, &aH.t.algo)
+	if string(data[len(aMagic)+1:cDigestSize-2]) != string(vAlgo) {
+		return nil, errors.New("crc16: encoded digest state of another algorithm")
+	}
+	aH.sum = binary.BigEndian.Uint16(data[cDigestSize-2:])
+	return data[cDigestSize:], nil
+}
+
+//--------------------------------------
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (aH digest) MarshalBinary() ([]byte, error) {
+	return aH.appendBinary(make([]byte, 0, cDigestSize), cDigestMagic), nil
+}
+
+//--------------------------------------
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It fails if the state was encoded
+// by a digest of another algorithm.
+func (aH *digest) UnmarshalBinary(data []byte) error {
+	_, err := aH.unmarshalBinary(data, cDigestMagic, 0)
+	return err
+}
+
+//--------------------------------------
+
+// MarshalBinary implements encoding.BinaryMarshaler, encoding a held back carriage return
+// with the register.
+func (aH *textDigest) MarshalBinary() ([]byte, error) {
+	vRet := aH.appendBinary(make([]byte, 0, cDigestSize+1), cTextDigestMagic)
+	if aH.cr {
+		return append(vRet, 1), nil
+	}
+	return append(vRet, 0), nil
+}
+
+//--------------------------------------
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It fails if the state was encoded
+// by a digest of another algorithm or by one not in text mode.
+func (aH *textDigest) UnmarshalBinary(data []byte) error {
+	if len(data) == cDigestSize+1 && data[cDigestSize] > 1 {
+		return errors.New("crc16: corrupt encoded digest state")
+	}
+	vExtra, err := aH.unmarshalBinary(data, cTextDigestMagic, 1)
+	if err != nil {
+		return err
+	}
+	aH.cr = vExtra[0] == 1
+	return nil
+}
+
+//--------------------------------------
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (aH *digest32) MarshalBinary() ([]byte, error) {
+	return aH.appendBinary(make([]byte, 0, cDigestSize), cHash32Magic), nil
+}
+
+//--------------------------------------
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It fails if the state was encoded
+// by a digest of another algorithm or size.
+func (aH *digest32) UnmarshalBinary(data []byte) error {
+	_, err := aH.unmarshalBinary(data, cHash32Magic, 0)
+	return err
+}
+
+//--------------------------------------
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (aH *digest64) MarshalBinary() ([]byte, error) {
+	return aH.appendBinary(make([]byte, 0, cDigestSize), cHash64Magic), nil
+}
+
+//--------------------------------------
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It fails if the state was encoded
+// by a digest of another algorithm or size.
+func (aH *digest64) UnmarshalBinary(data []byte) error {
+	_, err := aH.unmarshalBinary(data, cHash64Magic, 0)
+	return err
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,109 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package crc16
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+//-----------------------------------------------------------------------------
+
+// This file contains the identification of predefined algorithms from observed checksums.
+
+// TMatch is a predefined algorithm producing an observed checksum. The checksum is stored
+// in the Order byte order; Raw means it lacks the final XorOut of the algorithm.
+type TMatch struct {
+	Algo  *TAlgo
+	Order binary.ByteOrder
+	Raw   bool
+}
+
+//-----------------------------------------------------------------------------
+
+// IdentifyChecksum returns the predefined algorithms whose checksum of data, in either
+// byte order and with or without the final XorOut, is the 2-byte observed checksum aCrc.
+// Matches are returned in the catalogue order, big-endian before little-endian and
+// finalized before raw; raw matches are only reported for algorithms with a non-zero XorOut.
+// The custom algorithms loaded by LoadCatalog are searched after the predefined ones.
+func IdentifyChecksum(data []byte, aCrc []byte) []TMatch {
+	if len(aCrc) != 2 {
+		return nil
+	}
+	var vRet []TMatch
+	for a := range predefined() {
+		vSum := Checksum(data, Table(*a))
+		for _, vOrder := range []binary.ByteOrder{binary.BigEndian, binary.LittleEndian} {
+			vCrc := vOrder.Uint16(aCrc)
+			if vCrc == vSum {
+				vRet = append(vRet, TMatch{a, vOrder, false})
+			}
+			if a.XorOut != 0 && vCrc == vSum^a.XorOut {
+				vRet = append(vRet, TMatch{a, vOrder, true})
+			}
+		}
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// IdentifyAlgo returns the predefined algorithms producing the checksums of all samples,
+// e.g. captured from a device, in either byte order, in the catalogue order. The checksums
+// of the samples are read big-endian. Like IdentifyChecksum, it also searches the custom
+// algorithms loaded by LoadCatalog. See IdentifyAlgoMatches for the byte order of each
+// match and for checksums lacking the final XorOut.
+func IdentifyAlgo(aSamples []TSample) []*TAlgo {
+	var vRet []*TAlgo
+	for _, m := range IdentifyAlgoMatches(aSamples) {
+		if !m.Raw && (len(vRet) == 0 || vRet[len(vRet)-1] != m.Algo) {
+			vRet = append(vRet, m.Algo)
+		}
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// IdentifyAlgoMatches returns the predefined algorithms producing the checksums of all
+// samples in the same byte order and with or without the final XorOut, like IdentifyAlgo.
+// A little-endian match means the bytes of the checksums are swapped. Matches are ordered
+// as by IdentifyChecksum.
+func IdentifyAlgoMatches(aSamples []TSample) []TMatch {
+	if len(aSamples) == 0 {
+		return nil
+	}
+	var vRet []TMatch
+	for a := range predefined() {
+		vTable := Table(*a)
+		for _, vOrder := range []binary.ByteOrder{binary.BigEndian, binary.LittleEndian} {
+			for _, vRaw := range []bool{false, true} {
+				if vRaw && a.XorOut == 0 {
+					continue
+				}
+				vMatch := true
+				for _, s := range aSamples {
+					vSum := Checksum(s.Data, vTable)
+					if vRaw {
+						vSum ^= a.XorOut
+					}
+					if vOrder == binary.LittleEndian {
+						vSum = bits.ReverseBytes16(vSum)
+					}
+					if vSum != s.Crc {
+						vMatch = false
+						break
+					}
+				}
+				if vMatch {
+					vRet = append(vRet, TMatch{a, vOrder, vRaw})
+				}
+			}
+		}
+	}
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,63 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || (crc16_ccitt && crc16_ibm))
+
+package crc16
+
+import (
+	"encoding/binary"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestIdentifyChecksum(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vData := []byte("123456789")
+		vMatches := IdentifyChecksum(vData, []byte{0x37, 0x4b})
+		So(vMatches, ShouldResemble, []TMatch{
+			{&CRC16_MODBUS, binary.LittleEndian, false},
+			{&CRC16_USB, binary.LittleEndian, true},
+		})
+
+		vMatches = IdentifyChecksum(vData, []byte{0x90, 0x6e})
+		So(len(vMatches), ShouldBeGreaterThan, 0)
+		So(vMatches[0].Algo.Name, ShouldEqual, "CRC-16/IBM-SDLC")
+		So(vMatches[0].Order, ShouldEqual, binary.BigEndian)
+
+		// CRC-16/IBM-SDLC without the final XorOut.
+		vMatches = IdentifyChecksum(vData, []byte{0x6f, 0x91})
+		vFound := false
+		for _, m := range vMatches {
+			vFound = vFound || (m.Algo == &CRC16_IBM_SDLC && m.Raw && m.Order == binary.BigEndian)
+		}
+		So(vFound, ShouldBeTrue)
+
+		So(IdentifyChecksum(vData, []byte{0x01}), ShouldBeNil)
+	})
+}
+
+//--------------------------------------
+
+func TestIdentifyAlgo(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vSamples := []TSample{
+			{[]byte("123456789"), 0x374B},
+			{[]byte{0x01, 0x03, 0x00, 0x00, 0x00, 0x0A}, 0xC5CD},
+		}
+		So(IdentifyAlgoMatches(vSamples), ShouldResemble, []TMatch{
+			{&CRC16_MODBUS, binary.LittleEndian, false},
+			{&CRC16_USB, binary.LittleEndian, true},
+		})
+		So(IdentifyAlgo(vSamples), ShouldResemble, []*TAlgo{&CRC16_MODBUS})
+		vSamples[1].Crc ^= 1
+		So(IdentifyAlgoMatches(vSamples), ShouldBeNil)
+		So(IdentifyAlgo(vSamples), ShouldBeNil)
+		So(IdentifyAlgoMatches(nil), ShouldBeNil)
+		So(IdentifyAlgo(nil), ShouldBeNil)
+	})
+}
+
+//-----------------------------------------------------------------------------
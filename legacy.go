@@ -0,0 +1,31 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import "math/bits"
+
+//-----------------------------------------------------------------------------
+
+// ChecksumLegacyInit returns CRC checksum of data computed with the convention of some old
+// C implementations: the register starts at zero and Init is XORed into the first two message
+// bytes instead, the byte entering the register first taking the half of Init it would meet.
+//
+// For messages of two bytes or more the result equals Checksum with the same parameters,
+// so Init needs no re-deriving; only shorter messages, which receive part or none of Init,
+// differ.
+func ChecksumLegacyInit(data []byte, aTable *TTable) uint16 {
+	vInit := [2]byte{byte(aTable.algo.Init >> 8), byte(aTable.algo.Init)}
+	if aTable.algo.RefIn {
+		vInit[0], vInit[1] = bits.Reverse8(vInit[0]), bits.Reverse8(vInit[1])
+	}
+	var vHead [2]byte
+	n := copy(vHead[:], data)
+	for i := range n {
+		vHead[i] ^= vInit[i]
+	}
+	crc := Update(0, vHead[:n], aTable)
+	crc = Update(crc, data[n:], aTable)
+	return Complete(crc, aTable)
+}
+
+//-----------------------------------------------------------------------------
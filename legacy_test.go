@@ -0,0 +1,38 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestChecksumLegacyInit(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		for _, a := range []TAlgo{
+			{Poly: 0x1021, Init: 0x1D0F},
+			{Poly: 0x8005, Init: 0xB2AA, RefIn: true, RefOut: true, XorOut: 0xFFFF},
+			{Poly: 0x3D65, Init: 0x1234, RefIn: true},
+		} {
+			vTable := MakeTable(a)
+			for _, vData := range []string{"12", "123456789", "\x00\x00\x00"} {
+				So(ChecksumLegacyInit([]byte(vData), vTable), ShouldEqual, Checksum([]byte(vData), vTable))
+			}
+			vZero := a
+			vZero.Init = 0
+			vZeroTable := MakeTable(vZero)
+			So(ChecksumLegacyInit(nil, vTable), ShouldEqual, Checksum(nil, vZeroTable))
+		}
+
+		// A single byte only receives the half of Init entering the register first.
+		vTable := MakeTable(TAlgo{Poly: 0x1021, Init: 0xAB00})
+		So(ChecksumLegacyInit([]byte{0x31}, vTable), ShouldEqual, Checksum([]byte{0x31}, vTable))
+		vTable = MakeTable(TAlgo{Poly: 0x1021, Init: 0x00CD})
+		So(ChecksumLegacyInit([]byte{0x31}, vTable), ShouldEqual, Checksum([]byte{0x31}, MakeTable(TAlgo{Poly: 0x1021})))
+	})
+}
+
+//-----------------------------------------------------------------------------
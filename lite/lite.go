@@ -0,0 +1,44 @@
+//-----------------------------------------------------------------------------
+
+// Package lite implements a single CRC-16 algorithm selected at build time, for bootloaders,
+// WebAssembly and other targets where every kilobyte counts. It has no catalogue, no hash.Hash
+// and no algorithm names; its only data is the lookup table.
+//
+// CRC-16/ARC is compiled in by default. One of the build tags crc16_lite_modbus,
+// crc16_lite_kermit, crc16_lite_x25 (CRC-16/IBM-SDLC), crc16_lite_xmodem or
+// crc16_lite_ibm3740 (CRC-16/CCITT-FALSE) selects another, e.g.
+//
+//	go build -tags crc16_lite_modbus
+package lite
+
+//-----------------------------------------------------------------------------
+
+// Table is a 256-word lookup table of the compiled-in algorithm. Algorithms reflecting their
+// input and output use a reflected table shifting the register right, all others a table
+// shifting it left.
+type Table [256]uint16
+
+//-----------------------------------------------------------------------------
+
+// Update returns the checksum of the data checksummed so far as crc followed by data.
+// To start, crc is the checksum of no data, Checksum(nil).
+func Update(crc uint16, data []byte, aTable *Table) uint16 {
+	crc ^= cXorOut
+	for _, b := range data {
+		if cReflected {
+			crc = crc>>8 ^ aTable[byte(crc)^b]
+		} else {
+			crc = crc<<8 ^ aTable[byte(crc>>8)^b]
+		}
+	}
+	return crc ^ cXorOut
+}
+
+//--------------------------------------
+
+// Checksum returns the checksum of data.
+func Checksum(data []byte) uint16 {
+	return Update(cInit^cXorOut, data, DefaultTable)
+}
+
+//-----------------------------------------------------------------------------
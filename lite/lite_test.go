@@ -0,0 +1,54 @@
+//-----------------------------------------------------------------------------
+
+package lite
+
+import (
+	"math/bits"
+	"math/rand"
+	"path"
+	"runtime"
+	"testing"
+
+	"github.com/mbsulliv/crc16"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+// Returns the function name of the calling function.
+func funcName() string {
+	vRet := "?"
+	vPc, _, _, vOk := runtime.Caller(1)
+	if vOk {
+		vRet = path.Base(runtime.FuncForPC(vPc).Name())
+	}
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
+
+func TestChecksum(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		So(Checksum([]byte("123456789")), ShouldEqual, cCheck)
+
+		// The reference is built from the table rather than taken from the catalogue,
+		// which may be left out with the crc16_nocatalogue tag.
+		vAlgo := crc16.TAlgo{Poly: DefaultTable[1], Init: cInit, XorOut: cXorOut, Check: cCheck}
+		if cReflected {
+			vAlgo.Poly, vAlgo.Init = bits.Reverse16(DefaultTable[128]), bits.Reverse16(cInit)
+			vAlgo.RefIn, vAlgo.RefOut = true, true
+		}
+		vTable := crc16.MakeTable(vAlgo)
+		So(crc16.Checksum([]byte("123456789"), vTable), ShouldEqual, cCheck)
+		vRand := rand.New(rand.NewSource(8))
+		for n := 0; n < 100; n++ {
+			vData := make([]byte, n)
+			vRand.Read(vData)
+			So(Checksum(vData), ShouldEqual, crc16.Checksum(vData, vTable))
+			So(Update(Update(Checksum(nil), vData[:n/3], DefaultTable), vData[n/3:], DefaultTable), ShouldEqual, Checksum(vData))
+		}
+	})
+}
+
+//-----------------------------------------------------------------------------
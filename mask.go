@@ -0,0 +1,76 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import (
+	"cmp"
+	"slices"
+)
+
+//-----------------------------------------------------------------------------
+
+// TRange is a byte range of Len bytes starting at Offset.
+type TRange struct {
+	Offset int
+	Len    int
+}
+
+//-----------------------------------------------------------------------------
+
+// Returns the ranges clipped to data of length aLen, sorted and merged where they overlap or touch.
+func mergeRanges(aRanges []TRange, aLen int) []TRange {
+	var vRet []TRange
+	for _, r := range aRanges {
+		vFrom, vTo := max(r.Offset, 0), min(r.Offset+r.Len, aLen)
+		if vFrom < vTo {
+			vRet = append(vRet, TRange{vFrom, vTo - vFrom})
+		}
+	}
+	slices.SortFunc(vRet, func(a, b TRange) int { return cmp.Compare(a.Offset, b.Offset) })
+	n := 0
+	for _, r := range vRet {
+		if n > 0 && r.Offset <= vRet[n-1].Offset+vRet[n-1].Len {
+			vRet[n-1].Len = max(vRet[n-1].Len, r.Offset+r.Len-vRet[n-1].Offset)
+			continue
+		}
+		vRet[n] = r
+		n++
+	}
+	return vRet[:n]
+}
+
+//--------------------------------------
+
+// ChecksumMasked returns CRC checksum of data with the bytes in the skip ranges left out,
+// as used by record formats storing their checksum within the checksummed data.
+// The ranges may overlap and are clipped to data.
+func ChecksumMasked(data []byte, aSkip []TRange, aTable *TTable) uint16 {
+	vCrc := Init(aTable)
+	vPos := 0
+	for _, r := range mergeRanges(aSkip, len(data)) {
+		vCrc = Update(vCrc, data[vPos:r.Offset], aTable)
+		vPos = r.Offset + r.Len
+	}
+	return Complete(Update(vCrc, data[vPos:], aTable), aTable)
+}
+
+//-----------------------------------------------------------------------------
+
+// ChecksumZeroed returns CRC checksum of data with the bytes in the zero ranges taken as zeros,
+// the other convention of record formats storing their checksum within the checksummed data,
+// without copying data. The ranges may overlap and are clipped to data.
+func ChecksumZeroed(data []byte, aZero []TRange, aTable *TTable) uint16 {
+	var vZeros [64]byte
+	vCrc := Init(aTable)
+	vPos := 0
+	for _, r := range mergeRanges(aZero, len(data)) {
+		vCrc = Update(vCrc, data[vPos:r.Offset], aTable)
+		for n := r.Len; n > 0; n -= len(vZeros) {
+			vCrc = Update(vCrc, vZeros[:min(n, len(vZeros))], aTable)
+		}
+		vPos = r.Offset + r.Len
+	}
+	return Complete(Update(vCrc, data[vPos:], aTable), aTable)
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,55 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nocatalogue || crc16_ccitt
+
+package crc16
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestChecksumMasked(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_XMODEM)
+		vData := []byte("12xx345yy6789zz")
+		vSkip := []TRange{{13, 5}, {7, 2}, {2, 2}, {8, 1}, {-3, 2}}
+		So(ChecksumMasked(vData, vSkip, vTable), ShouldEqual, CRC16_XMODEM.Check)
+		So(ChecksumMasked(vData[:13], vSkip, vTable), ShouldEqual, CRC16_XMODEM.Check)
+		So(ChecksumMasked(vData, nil, vTable), ShouldEqual, Checksum(vData, vTable))
+		So(ChecksumMasked(vData, []TRange{{0, 100}}, vTable), ShouldEqual, Checksum(nil, vTable))
+
+		So(mergeRanges(vSkip, len(vData)), ShouldResemble, []TRange{{2, 2}, {7, 2}, {13, 2}})
+	})
+}
+
+//--------------------------------------
+
+func TestChecksumZeroed(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_XMODEM)
+		vData := make([]byte, 300)
+		for i := range vData {
+			vData[i] = byte(i*3 + 1)
+		}
+		vZero := []TRange{{4, 2}, {100, 150}, {120, 10}, {290, 20}}
+		vPatched := append([]byte(nil), vData...)
+		for _, r := range mergeRanges(vZero, len(vPatched)) {
+			clear(vPatched[r.Offset : r.Offset+r.Len])
+		}
+		So(ChecksumZeroed(vData, vZero, vTable), ShouldEqual, Checksum(vPatched, vTable))
+		So(vData[4], ShouldNotEqual, 0)
+		So(ChecksumZeroed(vData, nil, vTable), ShouldEqual, Checksum(vData, vTable))
+
+		// A record with its checksum stored at offset 4.
+		vRecord := []byte{1, 2, 3, 4, 0, 0, 5, 6}
+		vSum := ChecksumZeroed(vRecord, []TRange{{4, 2}}, vTable)
+		vRecord[4], vRecord[5] = byte(vSum>>8), byte(vSum)
+		So(ChecksumZeroed(vRecord, []TRange{{4, 2}}, vTable), ShouldEqual, vSum)
+	})
+}
+
+//-----------------------------------------------------------------------------
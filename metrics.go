@@ -0,0 +1,55 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import "sync/atomic"
+
+//-----------------------------------------------------------------------------
+
+// This file contains the optional instrumentation of the verifying facilities.
+
+// TMetrics counts the work of the verifying facilities, for dashboards tracking
+// link and storage integrity. The counters only ever grow.
+type TMetrics struct {
+	FramesVerified atomic.Uint64 // Frames, blocks and images whose checksum was verified.
+	Mismatches     atomic.Uint64 // Verifications which failed.
+	BytesHashed    atomic.Uint64 // Bytes covered by the checksums verified.
+	Corrections    atomic.Uint64 // Errors corrected.
+}
+
+// The counters updated by the package, if any.
+var activeMetrics atomic.Pointer[TMetrics]
+
+//-----------------------------------------------------------------------------
+
+// SetMetrics makes the verifying facilities of the package update the counters of aM,
+// or stops counting for nil, the default. Counting costs a few atomic additions per call.
+func SetMetrics(aM *TMetrics) {
+	activeMetrics.Store(aM)
+}
+
+//--------------------------------------
+
+// Records the verification of a checksum covering aLen bytes, which failed unless aOk.
+func countVerified(aLen int, aOk bool) {
+	vM := activeMetrics.Load()
+	if vM == nil {
+		return
+	}
+	vM.FramesVerified.Add(1)
+	vM.BytesHashed.Add(uint64(aLen))
+	if !aOk {
+		vM.Mismatches.Add(1)
+	}
+}
+
+//--------------------------------------
+
+// Records the correction of an error.
+func countCorrection() {
+	if vM := activeMetrics.Load(); vM != nil {
+		vM.Corrections.Add(1)
+	}
+}
+
+//-----------------------------------------------------------------------------
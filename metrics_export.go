@@ -0,0 +1,44 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package crc16
+
+import (
+	"fmt"
+	"io"
+)
+
+//-----------------------------------------------------------------------------
+
+// String returns the counters as a JSON object, so that TMetrics implements expvar.Var, e.g.
+//
+//	expvar.Publish("crc16", vMetrics)
+func (aM *TMetrics) String() string {
+	return fmt.Sprintf(`{"frames_verified": %d, "mismatches": %d, "bytes_hashed": %d, "corrections": %d}`,
+		aM.FramesVerified.Load(), aM.Mismatches.Load(), aM.BytesHashed.Load(), aM.Corrections.Load())
+}
+
+//--------------------------------------
+
+// WritePrometheus writes the counters in the Prometheus text exposition format,
+// with metric names prefixed by aNamespace and an underscore, e.g. crc16_mismatches_total.
+func (aM *TMetrics) WritePrometheus(w io.Writer, aNamespace string) error {
+	for _, c := range []struct {
+		name, help string
+		value      uint64
+	}{
+		{"frames_verified_total", "Frames, blocks and images whose checksum was verified.", aM.FramesVerified.Load()},
+		{"mismatches_total", "Checksum verifications which failed.", aM.Mismatches.Load()},
+		{"bytes_hashed_total", "Bytes covered by the checksums verified.", aM.BytesHashed.Load()},
+		{"corrections_total", "Errors corrected.", aM.Corrections.Load()},
+	} {
+		vName := aNamespace + "_" + c.name
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n%s %d\n", vName, c.help, vName, vName, c.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,52 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || crc16_ibm)
+
+package crc16
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestMetrics(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vM := new(TMetrics)
+		SetMetrics(vM)
+		defer SetMetrics(nil)
+
+		vTable := MakeTable(CRC16_MODBUS)
+		vFrame := AppendChecksum([]byte("123456789"), vTable, binary.LittleEndian)
+		So(VerifyTrailer(vFrame, vTable, binary.LittleEndian), ShouldBeTrue)
+		vFrame[0] ^= 0x10
+		So(VerifyTrailer(vFrame, vTable, binary.LittleEndian), ShouldBeFalse)
+		vPos, vOk := Correct(vFrame[:9], binary.LittleEndian.Uint16(vFrame[9:]), vTable)
+		So(vOk, ShouldBeTrue)
+		So(vPos, ShouldEqual, 4)
+
+		So(vM.FramesVerified.Load(), ShouldEqual, 3)
+		So(vM.Mismatches.Load(), ShouldEqual, 2)
+		So(vM.BytesHashed.Load(), ShouldEqual, 27)
+		So(vM.Corrections.Load(), ShouldEqual, 1)
+
+		var vVars map[string]uint64
+		So(json.Unmarshal([]byte(vM.String()), &vVars), ShouldBeNil)
+		So(vVars, ShouldResemble, map[string]uint64{"frames_verified": 3, "mismatches": 2, "bytes_hashed": 27, "corrections": 1})
+
+		var vB bytes.Buffer
+		So(vM.WritePrometheus(&vB, "link"), ShouldBeNil)
+		So(vB.String(), ShouldContainSubstring, "# TYPE link_corrections_total counter\nlink_corrections_total 1\n")
+
+		SetMetrics(nil)
+		VerifyTrailer(vFrame, vTable, binary.LittleEndian)
+		So(vM.FramesVerified.Load(), ShouldEqual, 3)
+	})
+}
+
+//-----------------------------------------------------------------------------
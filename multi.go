@@ -0,0 +1,75 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+//-----------------------------------------------------------------------------
+
+// This file contains the checksumming of data with several algorithms in a single pass,
+// e.g. to fingerprint images with the checksums expected by several legacy tools.
+
+// cMultiChunk is the number of bytes fed to every algorithm in turn, small enough
+// to be still cached when the next algorithm reads them.
+const cMultiChunk = 8 << 10
+
+// TMultiDigest computes the checksums of the data written to it with several tables.
+type TMultiDigest struct {
+	tables []*TTable
+	crcs   []uint16
+}
+
+//-----------------------------------------------------------------------------
+
+// NewMulti returns a TMultiDigest computing checksums with the given tables.
+func NewMulti(aTables ...*TTable) *TMultiDigest {
+	vD := &TMultiDigest{tables: aTables, crcs: make([]uint16, len(aTables))}
+	vD.Reset()
+	return vD
+}
+
+//--------------------------------------
+
+// Write adds more data to the running checksums, reading it once.
+// It never returns an error.
+func (aD *TMultiDigest) Write(data []byte) (int, error) {
+	vLen := len(data)
+	for len(data) > 0 {
+		vChunk := data[:min(len(data), cMultiChunk)]
+		for i, t := range aD.tables {
+			aD.crcs[i] = Update(aD.crcs[i], vChunk, t)
+		}
+		data = data[len(vChunk):]
+	}
+	return vLen, nil
+}
+
+//--------------------------------------
+
+// Sums appends the checksums, in the order of the tables, to dst and returns
+// the extended slice.
+func (aD *TMultiDigest) Sums(dst []uint16) []uint16 {
+	for i, t := range aD.tables {
+		dst = append(dst, Complete(aD.crcs[i], t))
+	}
+	return dst
+}
+
+//--------------------------------------
+
+// Reset resets the checksums to their initial state.
+func (aD *TMultiDigest) Reset() {
+	for i, t := range aD.tables {
+		aD.crcs[i] = Init(t)
+	}
+}
+
+//-----------------------------------------------------------------------------
+
+// MultiChecksum returns the checksums of data with the given tables, in their order,
+// reading data once.
+func MultiChecksum(data []byte, aTables ...*TTable) []uint16 {
+	vD := NewMulti(aTables...)
+	vD.Write(data)
+	return vD.Sums(make([]uint16, 0, len(aTables)))
+}
+
+//-----------------------------------------------------------------------------
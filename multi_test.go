@@ -0,0 +1,47 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nocatalogue || (crc16_ccitt && crc16_ibm)
+
+package crc16
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestMultiChecksum(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTables := []*TTable{MakeTable(CRC16_ARC), MakeTable(CRC16_XMODEM), MakeTable(CRC16_KERMIT)}
+		So(MultiChecksum([]byte("123456789"), vTables...), ShouldResemble,
+			[]uint16{CRC16_ARC.Check, CRC16_XMODEM.Check, CRC16_KERMIT.Check})
+		So(MultiChecksum(nil), ShouldBeEmpty)
+
+		vData := make([]byte, 3*cMultiChunk+17)
+		for i := range vData {
+			vData[i] = byte(i * 7)
+		}
+		var vWant []uint16
+		for _, t := range vTables {
+			vWant = append(vWant, Checksum(vData, t))
+		}
+		So(MultiChecksum(vData, vTables...), ShouldResemble, vWant)
+
+		vD := NewMulti(vTables...)
+		io.WriteString(vD, "garbage")
+		vD.Reset()
+		vD.Write(vData[:100])
+		vD.Write(vData[100:])
+		So(vD.Sums(nil), ShouldResemble, vWant)
+
+		vD.Reset()
+		io.Copy(vD, strings.NewReader("123456789"))
+		So(vD.Sums([]uint16{1}), ShouldResemble, []uint16{1, CRC16_ARC.Check, CRC16_XMODEM.Check, CRC16_KERMIT.Check})
+	})
+}
+
+//-----------------------------------------------------------------------------
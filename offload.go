@@ -0,0 +1,32 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import "io"
+
+//-----------------------------------------------------------------------------
+
+// This file contains the offload of checksumming large streams to the operating system.
+
+// The size of the chunks streams are read in.
+const cOffloadChunk = 64 << 10
+
+//-----------------------------------------------------------------------------
+
+// ChecksumOffload returns the checksum of the content of r and its length.
+//
+// On Linux, the CRC-16/T10-DIF checksum of the stream is computed by the crct10dif
+// driver of the kernel through an AF_ALG socket, using the CRC instructions of the CPU
+// where the kernel has them. Other algorithms, other systems and kernels without
+// the driver transparently fall back to ChecksumReader.
+func ChecksumOffload(r io.Reader, aTable *TTable) (uint16, int64, error) {
+	if sameParams(&aTable.algo, &TAlgo{Poly: 0x8BB7}) {
+		if vKernel, err := openKernelCRC("crct10dif"); err == nil {
+			defer vKernel.Close()
+			return vKernel.checksum(r)
+		}
+	}
+	return ChecksumReader(r, aTable)
+}
+
+//-----------------------------------------------------------------------------
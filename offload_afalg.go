@@ -0,0 +1,90 @@
+//-----------------------------------------------------------------------------
+
+//go:build linux && (amd64 || arm64) && !crc16_tiny
+
+package crc16
+
+import (
+	"io"
+	"syscall"
+	"unsafe"
+)
+
+//-----------------------------------------------------------------------------
+
+// tKernelCRC is an AF_ALG operation socket computing a CRC in the kernel.
+type tKernelCRC struct {
+	fd int
+}
+
+// The sockaddr_alg structure of linux/if_alg.h.
+type tSockaddrALG struct {
+	family uint16
+	typ    [14]byte
+	feat   uint32
+	mask   uint32
+	name   [64]byte
+}
+
+const cAF_ALG = 38
+
+//-----------------------------------------------------------------------------
+
+// Returns the operation socket of the named kernel hash, or an error if AF_ALG or the driver is unavailable.
+func openKernelCRC(aName string) (*tKernelCRC, error) {
+	vSock, err := syscall.Socket(cAF_ALG, syscall.SOCK_SEQPACKET|syscall.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer syscall.Close(vSock)
+
+	vAddr := tSockaddrALG{family: cAF_ALG}
+	copy(vAddr.typ[:], "hash")
+	copy(vAddr.name[:], aName)
+	if _, _, vErrno := syscall.Syscall(syscall.SYS_BIND, uintptr(vSock), uintptr(unsafe.Pointer(&vAddr)), unsafe.Sizeof(vAddr)); vErrno != 0 {
+		return nil, vErrno
+	}
+	vFd, _, vErrno := syscall.Syscall6(syscall.SYS_ACCEPT4, uintptr(vSock), 0, 0, syscall.SOCK_CLOEXEC, 0, 0)
+	if vErrno != 0 {
+		return nil, vErrno
+	}
+	return &tKernelCRC{fd: int(vFd)}, nil
+}
+
+//--------------------------------------
+
+// Close closes the operation socket.
+func (aK *tKernelCRC) Close() error {
+	return syscall.Close(aK.fd)
+}
+
+//--------------------------------------
+
+// Returns the checksum computed by the kernel of the content of r and its length.
+func (aK *tKernelCRC) checksum(r io.Reader) (uint16, int64, error) {
+	vBuf := make([]byte, cOffloadChunk)
+	var vLen int64
+	for {
+		n, err := r.Read(vBuf)
+		if n > 0 {
+			if vErr := syscall.Sendto(aK.fd, vBuf[:n], syscall.MSG_MORE, nil); vErr != nil {
+				return 0, vLen, vErr
+			}
+			vLen += int64(n)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, vLen, err
+		}
+	}
+	// The digest is the register in the byte order of the CPU, little-endian on these architectures.
+	var vSum [2]byte
+	if _, err := syscall.Read(aK.fd, vSum[:]); err != nil {
+		return 0, vLen, err
+	}
+	return uint16(vSum[0]) | uint16(vSum[1])<<8, vLen, nil
+}
+
+//-----------------------------------------------------------------------------
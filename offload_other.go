@@ -0,0 +1,38 @@
+//-----------------------------------------------------------------------------
+
+//go:build !linux || !(amd64 || arm64) || crc16_tiny
+
+package crc16
+
+import (
+	"errors"
+	"io"
+)
+
+//-----------------------------------------------------------------------------
+
+// tKernelCRC stands for the kernel CRC where AF_ALG is not supported.
+type tKernelCRC struct{}
+
+//-----------------------------------------------------------------------------
+
+// Returns an error: AF_ALG is not supported.
+func openKernelCRC(aName string) (*tKernelCRC, error) {
+	return nil, errors.ErrUnsupported
+}
+
+//--------------------------------------
+
+// Close does nothing.
+func (aK *tKernelCRC) Close() error {
+	return nil
+}
+
+//--------------------------------------
+
+// Never called.
+func (aK *tKernelCRC) checksum(r io.Reader) (uint16, int64, error) {
+	return 0, 0, errors.ErrUnsupported
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,35 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestChecksumOffload(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vData := make([]byte, 3*cOffloadChunk+17)
+		for i := range vData {
+			vData[i] = byte(i * 7)
+		}
+		for _, a := range []TAlgo{{Poly: 0x8BB7, Name: "CRC-16/T10-DIF"}, {Poly: 0x1021, Init: 0xFFFF}} {
+			vTable := MakeTable(a)
+			vSum, vLen, err := ChecksumOffload(bytes.NewReader(vData), vTable)
+			So(err, ShouldBeNil)
+			So(vLen, ShouldEqual, len(vData))
+			So(vSum, ShouldEqual, Checksum(vData, vTable))
+
+			vSum, vLen, err = ChecksumOffload(bytes.NewReader(nil), vTable)
+			So(err, ShouldBeNil)
+			So(vLen, ShouldEqual, 0)
+			So(vSum, ShouldEqual, Checksum(nil, vTable))
+		}
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,75 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package crc16
+
+import (
+	"encoding/binary"
+	"net"
+)
+
+//-----------------------------------------------------------------------------
+
+// This file contains the checksumming of datagrams, e.g. for lightweight UDP telemetry
+// protocols. Every datagram carries the checksum of its payload in a 2-byte trailer.
+
+type tPacketConn struct {
+	net.PacketConn
+	table *TTable
+	order binary.ByteOrder
+}
+
+//-----------------------------------------------------------------------------
+
+// WrapPacketConn returns the connection appending the checksum to every datagram written
+// to c and verifying it on every datagram read, in the specified byte order or, if it is nil,
+// the conventional order of the algorithm.
+//
+// ReadFrom strips the trailer and drops the datagrams which do not verify,
+// counting them in the metrics as mismatches, until a valid one arrives.
+func WrapPacketConn(c net.PacketConn, aTable *TTable, aOrder binary.ByteOrder) net.PacketConn {
+	return &tPacketConn{PacketConn: c, table: aTable, order: aOrder}
+}
+
+//--------------------------------------
+
+// WriteTo writes p with its checksum trailer to addr and returns the number of bytes of p written.
+func (aC *tPacketConn) WriteTo(p []byte, addr net.Addr) (int, error) {
+	vN, err := aC.PacketConn.WriteTo(AppendChecksum(append([]byte{}, p...), aC.table, aC.order), addr)
+	return max(min(vN, len(p)), 0), err
+}
+
+//--------------------------------------
+
+// ReadFrom reads the payload of the next valid datagram into p. As with UDP, payload bytes
+// not fitting in p are discarded, and the datagram is then checked as truncated.
+func (aC *tPacketConn) ReadFrom(p []byte) (int, net.Addr, error) {
+	vBuf := make([]byte, len(p)+2)
+	for {
+		vN, vAddr, err := aC.PacketConn.ReadFrom(vBuf)
+		if err != nil {
+			return 0, vAddr, err
+		}
+		if VerifyTrailer(vBuf[:vN], aC.table, aC.order) {
+			return copy(p, vBuf[:vN-2]), vAddr, nil
+		}
+	}
+}
+
+//-----------------------------------------------------------------------------
+
+// VerifyDatagrams verifies a batch of received datagrams, e.g. read with recvmmsg,
+// and returns the payloads of the valid ones, in order and referring to the datagrams,
+// with the number of datagrams rejected.
+func VerifyDatagrams(datagrams [][]byte, aTable *TTable, aOrder binary.ByteOrder) ([][]byte, int) {
+	vRet := make([][]byte, 0, len(datagrams))
+	for _, d := range datagrams {
+		if VerifyTrailer(d, aTable, aOrder) {
+			vRet = append(vRet, d[:len(d)-2])
+		}
+	}
+	return vRet, len(datagrams) - len(vRet)
+}
+
+//-----------------------------------------------------------------------------
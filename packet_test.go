@@ -0,0 +1,62 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || crc16_ccitt)
+
+package crc16
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestPacketConn(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_XMODEM)
+		vServer, err := net.ListenPacket("udp", "127.0.0.1:0")
+		So(err, ShouldBeNil)
+		defer vServer.Close()
+		vRaw, err := net.ListenPacket("udp", "127.0.0.1:0")
+		So(err, ShouldBeNil)
+		defer vRaw.Close()
+		vClient := WrapPacketConn(vRaw, vTable, binary.BigEndian)
+		vConn := WrapPacketConn(vServer, vTable, binary.BigEndian)
+
+		// A corrupt datagram is dropped.
+		_, err = vRaw.WriteTo([]byte("bogus!"), vServer.LocalAddr())
+		So(err, ShouldBeNil)
+		vN, err := vClient.WriteTo([]byte("telemetry"), vServer.LocalAddr())
+		So(err, ShouldBeNil)
+		So(vN, ShouldEqual, 9)
+
+		vServer.SetReadDeadline(time.Now().Add(5 * time.Second))
+		vBuf := make([]byte, 64)
+		vN, vAddr, err := vConn.ReadFrom(vBuf)
+		So(err, ShouldBeNil)
+		So(string(vBuf[:vN]), ShouldEqual, "telemetry")
+		So(vAddr.String(), ShouldEqual, vRaw.LocalAddr().String())
+	})
+}
+
+//--------------------------------------
+
+func TestVerifyDatagrams(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_XMODEM)
+		vBad := AppendChecksum([]byte("b"), vTable, binary.LittleEndian)
+		vBad[0] ^= 1
+		vValid, vRejected := VerifyDatagrams([][]byte{
+			AppendChecksum([]byte("a"), vTable, binary.LittleEndian), vBad, {0},
+			AppendChecksum([]byte("c"), vTable, binary.LittleEndian),
+		}, vTable, binary.LittleEndian)
+		So(vValid, ShouldResemble, [][]byte{[]byte("a"), []byte("c")})
+		So(vRejected, ShouldEqual, 2)
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,94 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+//-----------------------------------------------------------------------------
+
+// The shortest part of the data worth a goroutine.
+const cParallelMinPart = 64 << 10
+
+// The number of messages ChecksumBatch workers take at a time.
+const cBatchRun = 256
+
+//-----------------------------------------------------------------------------
+
+// ChecksumParallel returns CRC checksum of data using the specified algorithm, computed
+// by up to aWorkers goroutines over consecutive parts of data merged by Combine, e.g.
+// for multi-gigabyte memory-mapped files. A non-positive aWorkers means GOMAXPROCS.
+// Parts are at least 64 KB, so short data is checksummed by the calling goroutine.
+func ChecksumParallel(data []byte, aTable *TTable, aWorkers int) uint16 {
+	if aWorkers <= 0 {
+		aWorkers = runtime.GOMAXPROCS(0)
+	}
+	aWorkers = min(aWorkers, len(data)/cParallelMinPart)
+	if aWorkers <= 1 {
+		return Checksum(data, aTable)
+	}
+
+	vSums := make([]uint16, aWorkers)
+	vPart := (len(data) + aWorkers - 1) / aWorkers
+	var vWg sync.WaitGroup
+	for i := range vSums {
+		vWg.Add(1)
+		go func() {
+			defer vWg.Done()
+			vSums[i] = Checksum(data[i*vPart:min((i+1)*vPart, len(data))], aTable)
+		}()
+	}
+	vWg.Wait()
+
+	vRet := vSums[0]
+	for i := 1; i < len(vSums); i++ {
+		vRet = Combine(vRet, vSums[i], int64(min((i+1)*vPart, len(data))-i*vPart), aTable)
+	}
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
+
+// ChecksumBatch returns the CRC checksums of the messages, in order, computed by up to aWorkers
+// goroutines taking runs of consecutive messages, e.g. for high rates of short payloads.
+// A non-positive aWorkers means GOMAXPROCS. Apart from the result, it does not allocate per
+// message, and batches of a single run are checksummed by the calling goroutine.
+func ChecksumBatch(msgs [][]byte, aTable *TTable, aWorkers int) []uint16 {
+	vRet := make([]uint16, len(msgs))
+	if aWorkers <= 0 {
+		aWorkers = runtime.GOMAXPROCS(0)
+	}
+	aWorkers = min(aWorkers, (len(msgs)+cBatchRun-1)/cBatchRun)
+	if aWorkers <= 1 {
+		for i, m := range msgs {
+			vRet[i] = Checksum(m, aTable)
+		}
+		return vRet
+	}
+
+	var vNext atomic.Int64
+	var vWg sync.WaitGroup
+	for range aWorkers {
+		vWg.Add(1)
+		go func() {
+			defer vWg.Done()
+			for {
+				vStart := int(vNext.Add(cBatchRun)) - cBatchRun
+				if vStart >= len(msgs) {
+					return
+				}
+				vEnd := min(vStart+cBatchRun, len(msgs))
+				for i, m := range msgs[vStart:vEnd] {
+					vRet[vStart+i] = Checksum(m, aTable)
+				}
+			}
+		}()
+	}
+	vWg.Wait()
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,59 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nocatalogue || (crc16_ccitt && crc16_ibm && crc16_misc)
+
+package crc16
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestChecksumParallel(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vData := make([]byte, 5*cParallelMinPart+123)
+		for i := range vData {
+			vData[i] = byte(i*31 + i>>9)
+		}
+		for _, vAlgo := range []TAlgo{CRC16_XMODEM, CRC16_MODBUS, CRC16_DNP} {
+			vTable := MakeTable(vAlgo)
+			vExpected := Checksum(vData, vTable)
+			for _, n := range []int{-1, 0, 1, 2, 3, 5, 16} {
+				So(ChecksumParallel(vData, vTable, n), ShouldEqual, vExpected)
+			}
+			So(ChecksumParallel(vData[:100], vTable, 8), ShouldEqual, Checksum(vData[:100], vTable))
+		}
+	})
+}
+
+//--------------------------------------
+
+func TestChecksumBatch(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_MODBUS)
+		vMsgs := make([][]byte, 10*cBatchRun+17)
+		for i := range vMsgs {
+			vMsgs[i] = make([]byte, i%97)
+			for j := range vMsgs[i] {
+				vMsgs[i][j] = byte(i + j*7)
+			}
+		}
+		for _, n := range []int{-1, 0, 1, 3, 64} {
+			vSums := ChecksumBatch(vMsgs, vTable, n)
+			So(len(vSums), ShouldEqual, len(vMsgs))
+			for i, m := range vMsgs {
+				if vSums[i] != Checksum(m, vTable) {
+					So(vSums[i], ShouldEqual, Checksum(m, vTable))
+				}
+			}
+		}
+		So(ChecksumBatch(nil, vTable, 4), ShouldBeEmpty)
+		So(ChecksumBatch(vMsgs[:3], vTable, 4), ShouldResemble, []uint16{
+			Checksum(vMsgs[0], vTable), Checksum(vMsgs[1], vTable), Checksum(vMsgs[2], vTable)})
+	})
+}
+
+//-----------------------------------------------------------------------------
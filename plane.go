@@ -0,0 +1,22 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+//-----------------------------------------------------------------------------
+
+// Checksum2D returns CRC checksum of the rows of a 2D buffer, e.g. a framebuffer or a DMA'd
+// image plane, skipping the padding after each row: height rows of width bytes, starting
+// stride bytes apart. The last row needs no padding. It panics if the geometry is invalid
+// or exceeds buf.
+func Checksum2D(buf []byte, width, height, stride int, aTable *TTable) uint16 {
+	if width < 0 || height < 0 || stride < width || (height > 0 && (height-1)*stride+width > len(buf)) {
+		panic("crc16: invalid 2D buffer geometry")
+	}
+	crc := Init(aTable)
+	for y := 0; y < height; y++ {
+		crc = Update(crc, buf[y*stride:y*stride+width], aTable)
+	}
+	return Complete(crc, aTable)
+}
+
+//-----------------------------------------------------------------------------
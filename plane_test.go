@@ -0,0 +1,27 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestChecksum2D(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(TAlgo{Poly: 0x1021, Init: 0xFFFF})
+		vBuf := []byte("123..456..789")
+		So(Checksum2D(vBuf, 3, 3, 5, vTable), ShouldEqual, 0x29B1)
+		So(Checksum2D([]byte("123456789"), 9, 1, 9, vTable), ShouldEqual, 0x29B1)
+		So(Checksum2D(nil, 3, 0, 5, vTable), ShouldEqual, 0xFFFF)
+
+		So(func() { Checksum2D(vBuf, 3, 4, 5, vTable) }, ShouldPanic)
+		So(func() { Checksum2D(vBuf, 6, 2, 5, vTable) }, ShouldPanic)
+		So(func() { Checksum2D(vBuf, -1, 2, 5, vTable) }, ShouldPanic)
+	})
+}
+
+//-----------------------------------------------------------------------------
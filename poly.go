@@ -0,0 +1,110 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import (
+	"errors"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+//-----------------------------------------------------------------------------
+
+// This file contains the conversions between the notations of CRC polynomials
+// found in datasheets. TAlgo.Poly is the normal notation, the coefficients of x^15..x^0
+// with the x^16 term implied; x^16 + x^12 + x^5 + 1 is then written:
+//
+//	normal      0x1021  x^15..x^0, x^16 implied
+//	reversed    0x8408  x^0..x^15, x^16 implied
+//	reciprocal  0x0811  the normal notation of x^16 * P(1/x)
+//	Koopman     0x8810  x^16..x^1, x^0 implied
+
+//-----------------------------------------------------------------------------
+
+// ReversedPoly returns the reversed notation of the polynomial in normal notation,
+// the one used by the table-driven implementations of algorithms with reflected input.
+// The conversion is its own inverse.
+func ReversedPoly(aPoly uint16) uint16 {
+	return bits.Reverse16(aPoly)
+}
+
+//--------------------------------------
+
+// ReciprocalPoly returns the normal notation of the reciprocal of the polynomial in normal
+// notation, x^16 * P(1/x), which has the same error detection properties for bits in reverse
+// order. The conversion is its own inverse for polynomials with the x^0 term.
+func ReciprocalPoly(aPoly uint16) uint16 {
+	return bits.Reverse16(aPoly)<<1 | 1
+}
+
+//--------------------------------------
+
+// KoopmanPoly returns the Koopman notation of the polynomial in normal notation,
+// which must have the x^0 term.
+func KoopmanPoly(aPoly uint16) uint16 {
+	return aPoly>>1 | 0x8000
+}
+
+//--------------------------------------
+
+// PolyFromKoopman returns the normal notation of the polynomial in Koopman notation.
+func PolyFromKoopman(aKoopman uint16) uint16 {
+	return aKoopman<<1 | 1
+}
+
+//--------------------------------------
+
+// PolyString returns the expansion of the polynomial in normal notation, e.g.
+// "x^16+x^12+x^5+1" for 0x1021.
+func PolyString(aPoly uint16) string {
+	vRet := "x^16"
+	for i := 15; i >= 0; i-- {
+		if aPoly&(1<<i) == 0 {
+			continue
+		}
+		switch i {
+		case 0:
+			vRet += "+1"
+		case 1:
+			vRet += "+x"
+		default:
+			vRet += "+x^" + strconv.Itoa(i)
+		}
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// ParsePoly returns the normal notation of the polynomial of degree 16 expanded as by PolyString,
+// with optional spaces and the terms in any order, e.g. "x^16 + x^15 + x^2 + 1".
+func ParsePoly(s string) (uint16, error) {
+	var vRet uint32
+	for _, t := range strings.Split(strings.ReplaceAll(s, " ", ""), "+") {
+		vExp := 0
+		switch {
+		case t == "1":
+		case t == "x":
+			vExp = 1
+		case strings.HasPrefix(t, "x^"):
+			n, err := strconv.Atoi(t[2:])
+			if err != nil || n < 0 || n > 16 {
+				return 0, errors.New("crc16: invalid polynomial term " + strconv.Quote(t))
+			}
+			vExp = n
+		default:
+			return 0, errors.New("crc16: invalid polynomial term " + strconv.Quote(t))
+		}
+		if vRet&(1<<vExp) != 0 {
+			return 0, errors.New("crc16: repeated polynomial term " + strconv.Quote(t))
+		}
+		vRet |= 1 << vExp
+	}
+	if vRet&0x10000 == 0 {
+		return 0, errors.New("crc16: polynomial " + strconv.Quote(s) + " not of degree 16")
+	}
+	return uint16(vRet), nil
+}
+
+//-----------------------------------------------------------------------------
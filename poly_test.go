@@ -0,0 +1,48 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestPolyNotations(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		So(ReversedPoly(0x1021), ShouldEqual, 0x8408)
+		So(ReversedPoly(0x8005), ShouldEqual, 0xa001)
+		So(ReciprocalPoly(0x1021), ShouldEqual, 0x0811)
+		So(ReciprocalPoly(0x8005), ShouldEqual, 0x4003)
+		So(KoopmanPoly(0x1021), ShouldEqual, 0x8810)
+		So(KoopmanPoly(0x8005), ShouldEqual, 0xc002)
+		So(PolyFromKoopman(0x8810), ShouldEqual, 0x1021)
+		So(PolyFromKoopman(0xc86c), ShouldEqual, 0x90d9)
+
+		for a := range predefined() {
+			So(ReversedPoly(ReversedPoly(a.Poly)), ShouldEqual, a.Poly)
+			So(ReciprocalPoly(ReciprocalPoly(a.Poly)), ShouldEqual, a.Poly)
+			So(PolyFromKoopman(KoopmanPoly(a.Poly)), ShouldEqual, a.Poly)
+			vPoly, err := ParsePoly(PolyString(a.Poly))
+			So(err, ShouldBeNil)
+			So(vPoly, ShouldEqual, a.Poly)
+			// The reciprocal polynomial has the same period.
+			So(Period(TAlgo{Poly: ReciprocalPoly(a.Poly)}), ShouldEqual, Period(*a))
+		}
+
+		So(PolyString(0x1021), ShouldEqual, "x^16+x^12+x^5+1")
+		So(PolyString(0x8005), ShouldEqual, "x^16+x^15+x^2+1")
+		So(PolyString(0x0003), ShouldEqual, "x^16+x+1")
+		vPoly, err := ParsePoly("1 + x^5 + x^12 + x^16")
+		So(err, ShouldBeNil)
+		So(vPoly, ShouldEqual, 0x1021)
+		for _, s := range []string{"x^15+1", "x^16+x^17", "x^16+y", "x^16+x^5+x^5", "", "x^16+"} {
+			_, err = ParsePoly(s)
+			So(err, ShouldNotBeNil)
+		}
+	})
+}
+
+//-----------------------------------------------------------------------------
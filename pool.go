@@ -0,0 +1,110 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import "sync"
+
+//-----------------------------------------------------------------------------
+
+// This file contains the worker pool checksumming buffers concurrently, e.g. as a stage
+// of a high-rate ingestion service.
+
+// TJob is a buffer to checksum with the table of the pool, or Table if it is not nil.
+// The result is passed to Done if it is not nil and sent to the results channel otherwise.
+// ID is left to the caller to match results with jobs.
+type TJob struct {
+	ID    uint64
+	Data  []byte
+	Table *TTable
+	Done  func(TResult)
+}
+
+// TResult is the checksum of the data of a job.
+type TResult struct {
+	ID  uint64
+	Sum uint16
+}
+
+// TPool is a bounded set of workers checksumming submitted jobs.
+type TPool struct {
+	table   *TTable
+	jobs    chan TJob
+	results chan TResult
+	wg      sync.WaitGroup
+}
+
+//-----------------------------------------------------------------------------
+
+// NewPool starts aWorkers workers checksumming jobs with the specified table by default.
+// Up to aQueue jobs wait for a worker and as many results wait to be received before
+// submitting blocks, so that a slow consumer slows the producers down.
+func NewPool(aTable *TTable, aWorkers, aQueue int) *TPool {
+	if aWorkers <= 0 || aQueue < 0 {
+		panic("crc16: invalid pool size")
+	}
+	vP := &TPool{table: aTable, jobs: make(chan TJob, aQueue), results: make(chan TResult, aQueue)}
+	vP.wg.Add(aWorkers)
+	for range aWorkers {
+		go vP.work()
+	}
+	return vP
+}
+
+//--------------------------------------
+
+// Runs a worker until the pool is closed.
+func (aP *TPool) work() {
+	defer aP.wg.Done()
+	for j := range aP.jobs {
+		vTable := j.Table
+		if vTable == nil {
+			vTable = aP.table
+		}
+		vRes := TResult{ID: j.ID, Sum: Checksum(j.Data, vTable)}
+		if j.Done != nil {
+			j.Done(vRes)
+		} else {
+			aP.results <- vRes
+		}
+	}
+}
+
+//--------------------------------------
+
+// Submit queues the job, blocking while the queue is full.
+// The data must not be modified until the result is delivered.
+func (aP *TPool) Submit(aJob TJob) {
+	aP.jobs <- aJob
+}
+
+//--------------------------------------
+
+// TrySubmit queues the job unless the queue is full and reports whether it did.
+func (aP *TPool) TrySubmit(aJob TJob) bool {
+	select {
+	case aP.jobs <- aJob:
+		return true
+	default:
+		return false
+	}
+}
+
+//--------------------------------------
+
+// Results returns the channel delivering the results of the jobs without Done,
+// in completion order. It is closed by Close.
+func (aP *TPool) Results() <-chan TResult {
+	return aP.results
+}
+
+//--------------------------------------
+
+// Close stops accepting jobs, waits for the queued ones to complete and closes the results
+// channel, which must be drained meanwhile. Submitting after Close panics.
+func (aP *TPool) Close() {
+	close(aP.jobs)
+	aP.wg.Wait()
+	close(aP.results)
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,76 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import (
+	"sync"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestPool(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vXmodem := MakeTable(TAlgo{Poly: 0x1021})
+		vModbus := MakeTable(TAlgo{Poly: 0x8005, Init: 0xFFFF, RefIn: true, RefOut: true})
+		vData := []byte("123456789")
+
+		vP := NewPool(vXmodem, 4, 2)
+		var vMu sync.Mutex
+		vCallbacks := map[uint64]uint16{}
+		vDone := func(aRes TResult) {
+			vMu.Lock()
+			vCallbacks[aRes.ID] = aRes.Sum
+			vMu.Unlock()
+		}
+		go func() {
+			for i := uint64(0); i < 100; i++ {
+				switch i % 3 {
+				case 0:
+					vP.Submit(TJob{ID: i, Data: vData})
+				case 1:
+					vP.Submit(TJob{ID: i, Data: vData, Table: vModbus})
+				default:
+					vP.Submit(TJob{ID: i, Data: vData, Done: vDone})
+				}
+			}
+			vP.Close()
+		}()
+
+		vResults := map[uint64]uint16{}
+		for r := range vP.Results() {
+			vResults[r.ID] = r.Sum
+		}
+		So(len(vResults)+len(vCallbacks), ShouldEqual, 100)
+		for vID, vSum := range vResults {
+			if vID%3 == 0 {
+				So(vSum, ShouldEqual, 0x31C3)
+			} else {
+				So(vSum, ShouldEqual, 0x4B37)
+			}
+		}
+		for vID, vSum := range vCallbacks {
+			So(vID%3, ShouldEqual, 2)
+			So(vSum, ShouldEqual, 0x31C3)
+		}
+
+		// Backpressure: with nobody receiving, the queues fill up.
+		vP = NewPool(vXmodem, 1, 1)
+		vAccepted := 0
+		for i := 0; i < 10; i++ {
+			if vP.TrySubmit(TJob{Data: vData}) {
+				vAccepted++
+			}
+		}
+		So(vAccepted, ShouldBeBetweenOrEqual, 1, 3)
+		go vP.Close()
+		for range vP.Results() {
+		}
+
+		So(func() { NewPool(vXmodem, 0, 1) }, ShouldPanic)
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,236 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || (crc16_ccitt && crc16_ibm && crc16_misc))
+
+package crc16
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+//-----------------------------------------------------------------------------
+
+// This file contains stream splitters of common serial protocols framed with CRC-16.
+//
+// The tokens of the splitters are the raw frames as they appear in the stream and end
+// where the split function advances to, so callers can track the offsets of the frames.
+
+// Protocol constants.
+const (
+	cHDLCFlag   = 0x7e
+	cHDLCEscape = 0x7d
+	cDNP3Start  = 0x0564
+	cDNP3Header = 10
+	cDNP3Block  = 16
+
+	// The register, not complemented, after a PPP or HDLC frame followed by its valid FCS (RFC 1662).
+	cFCS16Good = 0xF0B8
+)
+
+//-----------------------------------------------------------------------------
+
+// ModbusSplit returns a bufio.SplitFunc producing the Modbus RTU frames with a valid
+// CRC-16/MODBUS trailer in the scanned stream, resynchronizing on corrupt data.
+func ModbusSplit() bufio.SplitFunc {
+	return ResyncSplit(TResyncConfig{Table: Table(CRC16_MODBUS), MinLen: 4, MaxLen: 256, Order: binary.LittleEndian})
+}
+
+//--------------------------------------
+
+// AppendModbusCRC appends the CRC-16/MODBUS of the Modbus RTU frame to it, least significant
+// byte first as transmitted, and returns the extended frame.
+func AppendModbusCRC(frame []byte) []byte {
+	return AppendChecksum(frame, Table(CRC16_MODBUS), binary.LittleEndian)
+}
+
+//--------------------------------------
+
+// VerifyModbusFrame returns true if the Modbus RTU frame, address and function code at least,
+// ends in a valid CRC-16/MODBUS transmitted least significant byte first.
+func VerifyModbusFrame(frame []byte) bool {
+	return len(frame) >= 4 && VerifyTrailer(frame, Table(CRC16_MODBUS), binary.LittleEndian)
+}
+
+//--------------------------------------
+
+// HDLCSplit returns a bufio.SplitFunc producing the byte-stuffed contents of HDLC frames
+// delimited by flag bytes, valid or not. Use VerifyHDLCFrame to check their frame check sequence.
+// The closing flag of a frame is left to open the next one; a frame without closing flag
+// at the end of the stream is dropped.
+func HDLCSplit() bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		vStart := bytes.IndexByte(data, cHDLCFlag)
+		if vStart < 0 {
+			return len(data), nil, nil
+		}
+		for vStart+1 < len(data) && data[vStart+1] == cHDLCFlag {
+			vStart++
+		}
+		vEnd := bytes.IndexByte(data[vStart+1:], cHDLCFlag)
+		if vEnd < 0 {
+			if atEOF {
+				return len(data), nil, nil
+			}
+			return vStart, nil, nil
+		}
+		vEnd += vStart + 1
+		return vEnd, data[vStart+1 : vEnd], nil
+	}
+}
+
+//--------------------------------------
+
+// VerifyHDLCFrame returns true if the byte-stuffed content of an HDLC frame ends
+// in a valid CRC-16/IBM-SDLC frame check sequence.
+func VerifyHDLCFrame(frame []byte) bool {
+	vData := make([]byte, 0, len(frame))
+	for i := 0; i < len(frame); i++ {
+		if frame[i] == cHDLCEscape {
+			i++
+			if i == len(frame) {
+				return false
+			}
+			vData = append(vData, frame[i]^0x20)
+			continue
+		}
+		vData = append(vData, frame[i])
+	}
+	return CheckFCS16(vData)
+}
+
+//--------------------------------------
+
+// AppendFCS16 appends the 16-bit frame check sequence of PPP, HDLC and X.25, the CRC-16/IBM-SDLC
+// of frame, to it least significant byte first as transmitted, and returns the extended frame.
+func AppendFCS16(frame []byte) []byte {
+	return AppendChecksum(frame, Table(CRC16_IBM_SDLC), binary.LittleEndian)
+}
+
+//--------------------------------------
+
+// CheckFCS16 returns true if the frame ends in its valid 16-bit frame check sequence, as appended
+// by AppendFCS16. The CRC is run over the whole frame, FCS included, and compared with the good
+// FCS residue 0xF0B8 as in RFC 1662.
+func CheckFCS16(frame []byte) bool {
+	if len(frame) < 2 {
+		return false
+	}
+	vOk := Checksum(frame, Table(CRC16_IBM_SDLC))^CRC16_IBM_SDLC.XorOut == cFCS16Good
+	countVerified(len(frame)-2, vOk)
+	return vOk
+}
+
+//-----------------------------------------------------------------------------
+
+// Returns the length of the DNP3 link frame with the header at the start of data.
+func dnp3FrameLen(aHeader []byte) int {
+	vUser := int(aHeader[2]) - 5
+	return cDNP3Header + vUser + 2*((vUser+cDNP3Block-1)/cDNP3Block)
+}
+
+//--------------------------------------
+
+// DNP3Split returns a bufio.SplitFunc producing the DNP3 link layer frames in the scanned stream.
+// Frames are located by their start bytes and a header with a valid CRC-16/DNP; the CRCs
+// of the user data blocks are not checked, use VerifyDNP3Frame for that.
+func DNP3Split() bufio.SplitFunc {
+	vTable := Table(CRC16_DNP)
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		for o := 0; o+1 < len(data); o++ {
+			if binary.BigEndian.Uint16(data[o:]) != cDNP3Start {
+				continue
+			}
+			if o+cDNP3Header > len(data) {
+				if atEOF {
+					break
+				}
+				return o, nil, nil
+			}
+			vHeader := data[o : o+cDNP3Header]
+			if vHeader[2] < 5 || !VerifyTrailer(vHeader, vTable, binary.LittleEndian) {
+				continue
+			}
+			vLen := dnp3FrameLen(vHeader)
+			if o+vLen > len(data) {
+				if atEOF {
+					continue
+				}
+				return o, nil, nil
+			}
+			return o + vLen, data[o : o+vLen], nil
+		}
+		if atEOF || len(data) == 0 {
+			return len(data), nil, nil
+		}
+		// Keep a trailing start byte which may be completed by more data.
+		return len(data) - 1, nil, nil
+	}
+}
+
+//--------------------------------------
+
+// VerifyDNP3Frame returns true if frame is a complete DNP3 link layer frame
+// with valid CRCs of the header and all user data blocks.
+func VerifyDNP3Frame(frame []byte) bool {
+	if len(frame) < cDNP3Header || binary.BigEndian.Uint16(frame) != cDNP3Start || frame[2] < 5 ||
+		len(frame) != dnp3FrameLen(frame) {
+		return false
+	}
+	vTable := Table(CRC16_DNP)
+	if !VerifyTrailer(frame[:cDNP3Header], vTable, binary.LittleEndian) {
+		return false
+	}
+	for vRest := frame[cDNP3Header:]; len(vRest) > 0; {
+		vBlock := vRest[:min(len(vRest), cDNP3Block+2)]
+		if !VerifyTrailer(vBlock, vTable, binary.LittleEndian) {
+			return false
+		}
+		vRest = vRest[len(vBlock):]
+	}
+	return true
+}
+
+//--------------------------------------
+
+// AppendDNP3Blocks appends the DNP3 link layer user data to dst in its wire form, split into
+// blocks of 16 bytes, the last one possibly shorter, each followed by its CRC-16/DNP least
+// significant byte first, and returns the extended slice.
+func AppendDNP3Blocks(dst, aUser []byte) []byte {
+	vTable := Table(CRC16_DNP)
+	for len(aUser) > 0 {
+		n := min(len(aUser), cDNP3Block)
+		vSum := Checksum(aUser[:n], vTable)
+		dst = binary.LittleEndian.AppendUint16(append(dst, aUser[:n]...), vSum)
+		aUser = aUser[n:]
+	}
+	return dst
+}
+
+//--------------------------------------
+
+// StripDNP3Blocks returns the user data of the DNP3 link layer blocks in their wire form,
+// as following the header of a frame, with the CRCs removed. If the CRC of a block does
+// not match, it returns a *ChecksumError with the offset of the CRC in data; the block
+// failing is the one numbered Offset/18 from zero.
+func StripDNP3Blocks(data []byte) ([]byte, error) {
+	vTable := Table(CRC16_DNP)
+	vRet := make([]byte, 0, len(data))
+	for o := 0; o < len(data); {
+		vBlock := data[o:min(len(data), o+cDNP3Block+2)]
+		if len(vBlock) < 3 {
+			return nil, errors.New("crc16: truncated DNP3 block")
+		}
+		if err := CheckTrailer(vBlock, vTable, binary.LittleEndian); err != nil {
+			err.(*ChecksumError).Offset += int64(o)
+			return nil, err
+		}
+		vRet = append(vRet, vBlock[:len(vBlock)-2]...)
+		o += len(vBlock)
+	}
+	return vRet, nil
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,164 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || (crc16_ccitt && crc16_ibm && crc16_misc))
+
+package crc16
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+// Returns the tokens of the stream split by aSplit with a small scanner buffer.
+func scanTokens(aStream []byte, aSplit bufio.SplitFunc) []string {
+	var vRet []string
+	vScanner := bufio.NewScanner(bytes.NewReader(aStream))
+	vScanner.Buffer(make([]byte, 300), 300)
+	vScanner.Split(aSplit)
+	for vScanner.Scan() {
+		vRet = append(vRet, vScanner.Text())
+	}
+	So(vScanner.Err(), ShouldBeNil)
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
+
+func TestModbusSplit(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_MODBUS)
+		vA := AppendChecksum([]byte{0x01, 0x03, 0x00, 0x00, 0x00, 0x0a}, vTable, binary.LittleEndian)
+		vB := AppendChecksum([]byte{0x01, 0x03, 0x02, 0x12, 0x34}, vTable, binary.LittleEndian)
+		vStream := append(append(append([]byte{0xff}, vA...), 0x00, 0x13), vB...)
+		So(scanTokens(vStream, ModbusSplit()), ShouldResemble, []string{string(vA), string(vB)})
+	})
+}
+
+//--------------------------------------
+
+func TestModbusFrame(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vFrame := AppendModbusCRC([]byte{0x01, 0x03, 0x00, 0x00, 0x00, 0x0a})
+		So(vFrame, ShouldResemble, []byte{0x01, 0x03, 0x00, 0x00, 0x00, 0x0a, 0xc5, 0xcd})
+		So(VerifyModbusFrame(vFrame), ShouldBeTrue)
+
+		vFrame[7], vFrame[6] = vFrame[6], vFrame[7]
+		So(VerifyModbusFrame(vFrame), ShouldBeFalse)
+		So(VerifyModbusFrame(AppendModbusCRC([]byte{0x01})), ShouldBeFalse)
+		So(VerifyModbusFrame(nil), ShouldBeFalse)
+	})
+}
+
+//--------------------------------------
+
+func TestHDLCSplit(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_IBM_SDLC)
+		vA := AppendChecksum([]byte{0xff, 0x03, 0xc0, 0x21}, vTable, binary.LittleEndian)
+		vB := AppendChecksum([]byte{0xff, 0x03, 0x7e, 0x7d}, vTable, binary.LittleEndian)
+		vStuffed := []byte{0xff, 0x03, 0x7d, 0x5e, 0x7d, 0x5d}
+		vStuffed = append(vStuffed, vB[4:]...)
+
+		vStream := []byte{0x55, 0x7e}
+		vStream = append(vStream, vA...)
+		vStream = append(vStream, 0x7e, 0x7e)
+		vStream = append(vStream, vStuffed...)
+		vStream = append(vStream, 0x7e, 0x01, 0x02)
+
+		vTokens := scanTokens(vStream, HDLCSplit())
+		So(vTokens, ShouldResemble, []string{string(vA), string(vStuffed)})
+		So(VerifyHDLCFrame([]byte(vTokens[0])), ShouldBeTrue)
+		So(VerifyHDLCFrame([]byte(vTokens[1])), ShouldBeTrue)
+		So(VerifyHDLCFrame(vB), ShouldBeFalse)
+		So(VerifyHDLCFrame([]byte{0x01, 0x7d}), ShouldBeFalse)
+	})
+}
+
+//--------------------------------------
+
+func TestFCS16(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vFrame := AppendFCS16([]byte{0xff, 0x03, 0xc0, 0x21, 0x01, 0x01, 0x00, 0x04})
+		So(vFrame[8:], ShouldResemble, binary.LittleEndian.AppendUint16(nil, Checksum(vFrame[:8], MakeTable(CRC16_X_25))))
+		So(CheckFCS16(vFrame), ShouldBeTrue)
+		So(CheckFCS16(AppendFCS16(nil)), ShouldBeTrue)
+
+		vFrame[3] ^= 0x40
+		So(CheckFCS16(vFrame), ShouldBeFalse)
+		So(CheckFCS16([]byte{0xb8}), ShouldBeFalse)
+	})
+}
+
+//--------------------------------------
+
+func TestDNP3Split(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_DNP)
+		vFrame := func(aUser []byte) []byte {
+			vRet := AppendChecksum([]byte{0x05, 0x64, byte(5 + len(aUser)), 0xc4, 0x01, 0x00, 0x02, 0x00}, vTable, binary.LittleEndian)
+			for len(aUser) > 0 {
+				n := min(len(aUser), 16)
+				vRet = append(vRet, AppendChecksum(append([]byte(nil), aUser[:n]...), vTable, binary.LittleEndian)...)
+				aUser = aUser[n:]
+			}
+			return vRet
+		}
+		vA := vFrame(nil)
+		vB := vFrame(bytes.Repeat([]byte{0xa5}, 40))
+		So(len(vB), ShouldEqual, 10+40+6)
+		So(VerifyDNP3Frame(vA), ShouldBeTrue)
+		So(VerifyDNP3Frame(vB), ShouldBeTrue)
+
+		vBad := append([]byte(nil), vB...)
+		vBad[20] ^= 1
+		So(VerifyDNP3Frame(vBad), ShouldBeFalse)
+
+		vStream := append([]byte{0x05, 0x64, 0x00}, vA...)
+		vStream = append(vStream, vBad...)
+		vStream = append(vStream, vB...)
+		vStream = append(vStream, vB[:20]...)
+		So(scanTokens(vStream, DNP3Split()), ShouldResemble, []string{string(vA), string(vBad), string(vB)})
+	})
+}
+
+//--------------------------------------
+
+func TestDNP3Blocks(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_DNP)
+		vUser := bytes.Repeat([]byte{0xa5, 0x5a, 0x01}, 14)
+		vWire := AppendDNP3Blocks([]byte{0xff}, vUser)
+		So(len(vWire), ShouldEqual, 1+42+3*2)
+		So(vWire[1:19], ShouldResemble, AppendChecksum(append([]byte(nil), vUser[:16]...), vTable, binary.LittleEndian))
+		So(AppendDNP3Blocks(nil, nil), ShouldBeNil)
+
+		vData, err := StripDNP3Blocks(vWire[1:])
+		So(err, ShouldBeNil)
+		So(vData, ShouldResemble, vUser)
+		vData, err = StripDNP3Blocks(nil)
+		So(err, ShouldBeNil)
+		So(vData, ShouldBeEmpty)
+
+		vWire[1+20] ^= 0x10
+		_, err = StripDNP3Blocks(vWire[1:])
+		So(errors.Is(err, ErrChecksumMismatch), ShouldBeTrue)
+		var vErr *ChecksumError
+		So(errors.As(err, &vErr), ShouldBeTrue)
+		So(vErr.Offset, ShouldEqual, 18+16)
+		So(vErr.Offset/18, ShouldEqual, 1)
+		vWire[1+20] ^= 0x10
+
+		_, err = StripDNP3Blocks(vWire[1:3])
+		So(err, ShouldNotBeNil)
+		So(errors.Is(err, ErrChecksumMismatch), ShouldBeFalse)
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,169 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package crc16
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+//-----------------------------------------------------------------------------
+
+// TReader reads from an underlying reader while computing the checksum of the bytes read,
+// so that frames can be parsed field by field and their checksum verified without buffering them.
+type TReader struct {
+	reader io.Reader
+	table  *TTable
+	crc    uint16
+	length int64
+}
+
+//-----------------------------------------------------------------------------
+
+// NewReader returns a TReader reading from r and computing the checksum with the specified table.
+func NewReader(r io.Reader, aTable *TTable) *TReader {
+	return &TReader{reader: r, table: aTable, crc: Init(aTable)}
+}
+
+//--------------------------------------
+
+// Read reads from the underlying reader into p and adds the bytes read to the checksum.
+func (aR *TReader) Read(p []byte) (int, error) {
+	n, err := aR.reader.Read(p)
+	aR.crc = Update(aR.crc, p[:n], aR.table)
+	aR.length += int64(n)
+	return n, err
+}
+
+//--------------------------------------
+
+// Sum16 returns the checksum of the bytes read since the reader was created or reset.
+func (aR *TReader) Sum16() uint16 {
+	return Complete(aR.crc, aR.table)
+}
+
+//--------------------------------------
+
+// Len returns the number of bytes read since the reader was created or reset.
+func (aR *TReader) Len() int64 {
+	return aR.length
+}
+
+//--------------------------------------
+
+// Reset starts a new checksum without reading anything.
+func (aR *TReader) Reset() {
+	aR.crc, aR.length = Init(aR.table), 0
+}
+
+//--------------------------------------
+
+// ReadSum reads a 2-byte trailer in the specified byte order, the conventional order of the
+// algorithm if nil, from the underlying reader and returns a *ChecksumError, with the number
+// of bytes read before it as offset, if it is not the checksum of the bytes read since the
+// reader was created or reset. The reader is reset for the next frame unless reading fails.
+func (aR *TReader) ReadSum(aOrder binary.ByteOrder) error {
+	var vTrailer [2]byte
+	if _, err := io.ReadFull(aR.reader, vTrailer[:]); err != nil {
+		return err
+	}
+	vStored, vSum, vLen := trailerOrder(aOrder, aR.table).Uint16(vTrailer[:]), aR.Sum16(), aR.length
+	countVerified(int(vLen), vStored == vSum)
+	aR.Reset()
+	if vStored != vSum {
+		return &ChecksumError{Algo: aR.table.algo.Name, Expected: vStored, Actual: vSum, Offset: vLen}
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+
+// TTrailerReader delivers the payload of a stream ending with a 2-byte checksum trailer,
+// withholding the trailer and verifying it against the payload at the end of the stream.
+type TTrailerReader struct {
+	reader  io.Reader
+	table   *TTable
+	order   binary.ByteOrder
+	crc     uint16
+	length  int64
+	tail    [2]byte
+	tailLen int
+	err     error
+}
+
+//-----------------------------------------------------------------------------
+
+// NewTrailerReader returns a TTrailerReader reading from r, with the checksum computed with
+// the specified table stored in the specified byte order, the conventional order of the
+// algorithm if nil.
+func NewTrailerReader(r io.Reader, aTable *TTable, aOrder binary.ByteOrder) *TTrailerReader {
+	return &TTrailerReader{reader: r, table: aTable, order: trailerOrder(aOrder, aTable), crc: Init(aTable)}
+}
+
+//--------------------------------------
+
+// Read reads the payload into p. At the end of the stream it returns io.EOF if the trailer
+// is the checksum of the payload, a *ChecksumError, with the length of the payload as offset,
+// if it is not, or io.ErrUnexpectedEOF if the stream is shorter than the trailer.
+func (aR *TTrailerReader) Read(p []byte) (int, error) {
+	if aR.err != nil {
+		return 0, aR.err
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	for {
+		m, err := aR.reader.Read(p)
+		// The stream read so far ends with the withheld bytes followed by p[:m], of which
+		// all but the last two are delivered.
+		vLen := aR.tailLen + m
+		vAt := func(i int) byte {
+			if i < aR.tailLen {
+				return aR.tail[i]
+			}
+			return p[i-aR.tailLen]
+		}
+		var vTail [2]byte
+		vTailLen := min(vLen, 2)
+		for i := range vTailLen {
+			vTail[i] = vAt(vLen - vTailLen + i)
+		}
+		n := vLen - vTailLen
+		if n > aR.tailLen {
+			copy(p[aR.tailLen:n], p[:n-aR.tailLen])
+		}
+		copy(p[:n], aR.tail[:aR.tailLen])
+		aR.tail, aR.tailLen = vTail, vTailLen
+		aR.crc = Update(aR.crc, p[:n], aR.table)
+		aR.length += int64(n)
+		if err == io.EOF {
+			err = aR.verify()
+		}
+		if err != nil {
+			aR.err = err
+			return n, err
+		}
+		if n > 0 {
+			return n, nil
+		}
+	}
+}
+
+//--------------------------------------
+
+// verify returns the error to report at the end of the stream.
+func (aR *TTrailerReader) verify() error {
+	if aR.tailLen < 2 {
+		return io.ErrUnexpectedEOF
+	}
+	vStored, vSum := aR.order.Uint16(aR.tail[:]), Complete(aR.crc, aR.table)
+	countVerified(int(aR.length), vStored == vSum)
+	if vStored != vSum {
+		return &ChecksumError{Algo: aR.table.algo.Name, Expected: vStored, Actual: vSum, Offset: aR.length}
+	}
+	return io.EOF
+}
+
+//-----------------------------------------------------------------------------
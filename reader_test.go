@@ -0,0 +1,104 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || crc16_ibm)
+
+package crc16
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestReader(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_MODBUS)
+		vWire := []byte{0x01, 0x03, 0x00, 0x00, 0x00, 0x0a, 0xc5, 0xcd, '1', '2', '3', '4', '5', '6', '7', '8', '9', 0x4b, 0x37}
+		vR := NewReader(iotest.OneByteReader(bytes.NewReader(vWire)), vTable)
+		So(vR.Sum16(), ShouldEqual, Checksum(nil, vTable))
+
+		var vHeader [2]byte
+		var vCount uint32
+		_, err := io.ReadFull(vR, vHeader[:])
+		So(err, ShouldBeNil)
+		So(binary.Read(vR, binary.BigEndian, &vCount), ShouldBeNil)
+		So(vCount, ShouldEqual, 10)
+		So(vR.Len(), ShouldEqual, 6)
+		So(vR.Sum16(), ShouldEqual, 0xcdc5)
+		So(vR.ReadSum(nil), ShouldBeNil)
+		So(vR.Len(), ShouldEqual, 0)
+
+		vBody := make([]byte, 9)
+		_, err = io.ReadFull(vR, vBody)
+		So(err, ShouldBeNil)
+		So(vR.ReadSum(binary.BigEndian), ShouldBeNil)
+		So(vR.ReadSum(nil), ShouldEqual, io.EOF)
+
+		vWire[3] ^= 0x10
+		vR = NewReader(bytes.NewReader(vWire), vTable)
+		io.ReadFull(vR, vHeader[:])
+		binary.Read(vR, binary.BigEndian, &vCount)
+		err = vR.ReadSum(nil)
+		So(errors.Is(err, ErrChecksumMismatch), ShouldBeTrue)
+		var vSumErr *ChecksumError
+		So(errors.As(err, &vSumErr), ShouldBeTrue)
+		So(vSumErr.Offset, ShouldEqual, 6)
+		So(vSumErr.Expected, ShouldEqual, 0xcdc5)
+
+		vR.Reset()
+		So(vR.Sum16(), ShouldEqual, Checksum(nil, vTable))
+	})
+}
+
+//--------------------------------------
+
+func TestTrailerReader(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_MODBUS)
+		vPayload := make([]byte, 1000)
+		for i := range vPayload {
+			vPayload[i] = byte(i * 13)
+		}
+		vFile := AppendChecksum(vPayload, vTable, nil)
+		for _, vWrap := range []func(io.Reader) io.Reader{
+			func(r io.Reader) io.Reader { return r },
+			iotest.OneByteReader,
+			iotest.HalfReader,
+			iotest.DataErrReader,
+		} {
+			vR := NewTrailerReader(vWrap(bytes.NewReader(vFile)), vTable, nil)
+			vData, err := io.ReadAll(vR)
+			So(err, ShouldBeNil)
+			So(vData, ShouldResemble, vPayload)
+			n, err := vR.Read(make([]byte, 8))
+			So(n, ShouldEqual, 0)
+			So(err, ShouldEqual, io.EOF)
+		}
+		So(iotest.TestReader(NewTrailerReader(bytes.NewReader(vFile), vTable, nil), vPayload), ShouldBeNil)
+
+		vFile[500] ^= 4
+		_, err := io.ReadAll(NewTrailerReader(iotest.OneByteReader(bytes.NewReader(vFile)), vTable, nil))
+		So(errors.Is(err, ErrChecksumMismatch), ShouldBeTrue)
+		var vSumErr *ChecksumError
+		So(errors.As(err, &vSumErr), ShouldBeTrue)
+		So(vSumErr.Offset, ShouldEqual, 1000)
+		vFile[500] ^= 4
+
+		_, err = io.ReadAll(NewTrailerReader(bytes.NewReader(vFile), vTable, binary.BigEndian))
+		So(errors.Is(err, ErrChecksumMismatch), ShouldBeTrue)
+		vData, err := io.ReadAll(NewTrailerReader(bytes.NewReader(AppendChecksum(nil, vTable, nil)), vTable, nil))
+		So(err, ShouldBeNil)
+		So(vData, ShouldBeEmpty)
+		_, err = io.ReadAll(NewTrailerReader(bytes.NewReader(vFile[:1]), vTable, nil))
+		So(err, ShouldEqual, io.ErrUnexpectedEOF)
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,162 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package crc16
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+)
+
+//-----------------------------------------------------------------------------
+
+// This file contains the frame resynchronizer locating frame boundaries
+// in a raw byte stream by checksum validity, e.g. after sync loss on a lossy serial link.
+
+// TResyncConfig describes the frames looked for by the resynchronizer.
+// Frame lengths include the 2-byte checksum trailer, which covers the rest of the frame.
+// A nil Order means big-endian trailers.
+type TResyncConfig struct {
+	Table  *TTable
+	MinLen int
+	MaxLen int
+	Order  binary.ByteOrder
+}
+
+// TFrame is a frame located in a stream.
+type TFrame struct {
+	Offset int
+	Data   []byte
+}
+
+//-----------------------------------------------------------------------------
+
+// Returns the byte order of frame trailers.
+func (aCfg *TResyncConfig) order() binary.ByteOrder {
+	if aCfg.Order == nil {
+		return binary.BigEndian
+	}
+	return aCfg.Order
+}
+
+//--------------------------------------
+
+// Returns the length of the shortest valid frame starting at data[0], or 0 if there is none.
+// When more is true, a frame might still be found once more data is available.
+func (aCfg *TResyncConfig) match(data []byte) (n int, more bool) {
+	vOrder := aCfg.order()
+	vMin := max(aCfg.MinLen, 3)
+	vCrc := Init(aCfg.Table)
+	for l := 3; l <= aCfg.MaxLen; l++ {
+		if l > len(data) {
+			return 0, true
+		}
+		vCrc = Update(vCrc, data[l-3:l-2], aCfg.Table)
+		if l >= vMin && vOrder.Uint16(data[l-2:l]) == Complete(vCrc, aCfg.Table) {
+			return l, false
+		}
+	}
+	return 0, false
+}
+
+//--------------------------------------
+
+// Returns the offset and length of the first valid frame in data, or -1 as the offset
+// if there is none. Unless atEOF is set, the search stops with a zero length at the first
+// offset where a frame might still be found once more data is available.
+func (aCfg *TResyncConfig) find(data []byte, atEOF bool) (int, int) {
+	for o := range data {
+		vN, vMore := aCfg.match(data[o:])
+		if vN > 0 || (vMore && !atEOF) {
+			return o, vN
+		}
+	}
+	return -1, 0
+}
+
+//-----------------------------------------------------------------------------
+
+// Resync returns the frames found in data.
+//
+// Starting from the beginning of data, the shortest frame with a valid checksum trailer
+// and a length within the configured bounds is taken and the scan continues right after it;
+// where no such frame starts, a single byte is skipped. Offsets not covered by any
+// returned frame are the garbage discarded while resynchronizing.
+func Resync(data []byte, aCfg TResyncConfig) []TFrame {
+	var vRet []TFrame
+	for o := 0; o < len(data); {
+		vOff, vN := aCfg.find(data[o:], true)
+		if vOff < 0 {
+			break
+		}
+		o += vOff
+		vRet = append(vRet, TFrame{Offset: o, Data: data[o : o+vN]})
+		o += vN
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// ResyncSplit returns a bufio.SplitFunc producing the frames Resync would find in the scanned stream.
+// The scanner buffer must hold at least MaxLen bytes.
+func ResyncSplit(aCfg TResyncConfig) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		vOff, vN := aCfg.find(data, atEOF)
+		switch {
+		case vOff < 0:
+			return len(data), nil, nil
+		case vN == 0:
+			return vOff, nil, nil
+		default:
+			return vOff + vN, data[vOff : vOff+vN], nil
+		}
+	}
+}
+
+//-----------------------------------------------------------------------------
+
+// FrameSplit returns a bufio.SplitFunc producing the frames of a stream whose frame lengths
+// are known from their headers, like Modbus RTU, that end with a valid 2-byte checksum trailer
+// in the specified byte order, the conventional order of the algorithm if nil.
+//
+// aFrameLen returns the length of the frame starting at data[0], trailer included, or 0 if
+// more data is needed to tell. Frames with an invalid trailer are skipped, as is the truncated
+// frame at the end of the stream; when aBad is not nil, it is called with them first.
+func FrameSplit(aFrameLen func(data []byte) int, aTable *TTable, aOrder binary.ByteOrder, aBad func(frame []byte)) bufio.SplitFunc {
+	vOrder := trailerOrder(aOrder, aTable)
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		vSkipped := 0
+		for len(data) > 0 {
+			vN := aFrameLen(data)
+			if vN == 0 || vN > len(data) {
+				if !atEOF {
+					return vSkipped, nil, nil
+				}
+				if aBad != nil {
+					aBad(data)
+				}
+				return vSkipped + len(data), nil, nil
+			}
+			if vN < 2 {
+				return 0, nil, errors.New("crc16: frame shorter than its checksum trailer")
+			}
+			vFrame := data[:vN]
+			vOk := vOrder.Uint16(vFrame[vN-2:]) == Checksum(vFrame[:vN-2], aTable)
+			countVerified(vN-2, vOk)
+			if vOk {
+				return vSkipped + vN, vFrame, nil
+			}
+			if aBad != nil {
+				aBad(vFrame)
+			}
+			vSkipped += vN
+			data = data[vN:]
+		}
+		return vSkipped, nil, nil
+	}
+}
+
+//-----------------------------------------------------------------------------
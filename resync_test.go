@@ -0,0 +1,116 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || crc16_ibm)
+
+package crc16
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"math/rand"
+	"testing"
+	"testing/iotest"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestResync(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vRand := rand.New(rand.NewSource(7))
+		vCfg := TResyncConfig{Table: MakeTable(CRC16_MODBUS), MinLen: 6, MaxLen: 24, Order: binary.LittleEndian}
+
+		var vStream []byte
+		var vOffsets []int
+		for i := 0; i < 10; i++ {
+			// Sync loss: a partial frame of garbage.
+			vGarbage := make([]byte, vRand.Intn(5))
+			vRand.Read(vGarbage)
+			vStream = append(vStream, vGarbage...)
+
+			vPayload := make([]byte, 4+vRand.Intn(18))
+			vRand.Read(vPayload)
+			vOffsets = append(vOffsets, len(vStream))
+			vStream = append(vStream, vPayload...)
+			vStream = binary.LittleEndian.AppendUint16(vStream, Checksum(vPayload, vCfg.Table))
+		}
+		vStream = append(vStream, 0x01, 0x02, 0x03)
+
+		vFrames := Resync(vStream, vCfg)
+		So(len(vFrames), ShouldEqual, len(vOffsets))
+		for i, vF := range vFrames {
+			So(vF.Offset, ShouldEqual, vOffsets[i])
+		}
+
+		vScanner := bufio.NewScanner(bytes.NewReader(vStream))
+		vScanner.Buffer(make([]byte, 32), 32)
+		vScanner.Split(ResyncSplit(vCfg))
+		i := 0
+		for vScanner.Scan() {
+			So(i, ShouldBeLessThan, len(vFrames))
+			So(vScanner.Bytes(), ShouldResemble, vFrames[i].Data)
+			i++
+		}
+		So(vScanner.Err(), ShouldBeNil)
+		So(i, ShouldEqual, len(vFrames))
+
+		So(Resync(nil, vCfg), ShouldBeEmpty)
+	})
+}
+
+//--------------------------------------
+
+func TestFrameSplit(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_MODBUS)
+		// Modbus RTU read responses: address, function, byte count, data and checksum.
+		vLen := func(data []byte) int {
+			if len(data) < 3 {
+				return 0
+			}
+			return 5 + int(data[2])
+		}
+		var vFrames [][]byte
+		var vStream []byte
+		for i := range 20 {
+			vFrame := []byte{byte(i), 0x03, byte(2 * (i % 4))}
+			for j := range int(vFrame[2]) {
+				vFrame = append(vFrame, byte(i*j))
+			}
+			vFrame = AppendChecksum(vFrame, vTable, nil)
+			vFrames = append(vFrames, vFrame)
+			vStream = append(vStream, vFrame...)
+		}
+		vStream[len(vFrames[0])+1] ^= 0x80
+		vStream = append(vStream, 0x01, 0x03, 0x04, 0x00)
+
+		var vBad [][]byte
+		vScanner := bufio.NewScanner(iotest.OneByteReader(bytes.NewReader(vStream)))
+		vScanner.Split(FrameSplit(vLen, vTable, nil, func(aFrame []byte) {
+			vBad = append(vBad, bytes.Clone(aFrame))
+		}))
+		var vGot [][]byte
+		for vScanner.Scan() {
+			vGot = append(vGot, bytes.Clone(vScanner.Bytes()))
+		}
+		So(vScanner.Err(), ShouldBeNil)
+		So(vGot, ShouldResemble, append(vFrames[:1:1], vFrames[2:]...))
+		So(len(vBad), ShouldEqual, 2)
+		So(vBad[0][0], ShouldEqual, 1)
+		So(vBad[1], ShouldResemble, []byte{0x01, 0x03, 0x04, 0x00})
+
+		vScanner = bufio.NewScanner(bytes.NewReader(vStream))
+		vScanner.Split(FrameSplit(vLen, vTable, binary.BigEndian, nil))
+		So(vScanner.Scan(), ShouldBeFalse)
+		So(vScanner.Err(), ShouldBeNil)
+
+		vScanner = bufio.NewScanner(bytes.NewReader(vStream))
+		vScanner.Split(FrameSplit(func([]byte) int { return 1 }, vTable, nil, nil))
+		So(vScanner.Scan(), ShouldBeFalse)
+		So(vScanner.Err(), ShouldNotBeNil)
+	})
+}
+
+//-----------------------------------------------------------------------------
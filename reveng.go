@@ -0,0 +1,262 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import (
+	"errors"
+	"math/bits"
+)
+
+//-----------------------------------------------------------------------------
+
+// This file contains the recovery of unknown algorithm parameters from
+// message/checksum samples, in the manner of CRC RevEng.
+//
+// The solver works on its own bitwise model of the register, most significant bit
+// first, which is also the convention the catalogue uses for Init.
+
+// TSample is a message together with the checksum observed for it.
+type TSample struct {
+	Data []byte
+	Crc  uint16
+}
+
+//-----------------------------------------------------------------------------
+
+// Returns a*b modulo x^16 + aPoly.
+func polyMulMod(a, b, aPoly uint16) uint16 {
+	var vRet uint16
+	for i := 15; i >= 0; i-- {
+		vHigh := vRet&0x8000 != 0
+		vRet <<= 1
+		if vHigh {
+			vRet ^= aPoly
+		}
+		if b&(1<<i) != 0 {
+			vRet ^= a
+		}
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Returns x^n modulo x^16 + aPoly.
+func polyPowX(n int64, aPoly uint16) uint16 {
+	vRet, vBase := uint16(1), uint16(2)
+	for ; n > 0; n >>= 1 {
+		if n&1 != 0 {
+			vRet = polyMulMod(vRet, vBase, aPoly)
+		}
+		vBase = polyMulMod(vBase, vBase, aPoly)
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Returns the register after feeding data into a zero register, most significant bit first.
+func rawRegister(data []byte, aPoly uint16, aRefIn bool) uint16 {
+	var vReg uint16
+	for _, d := range data {
+		if aRefIn {
+			d = bits.Reverse8(d)
+		}
+		vReg ^= uint16(d) << 8
+		for i := 0; i < 8; i++ {
+			if vReg&0x8000 != 0 {
+				vReg = vReg<<1 ^ aPoly
+			} else {
+				vReg <<= 1
+			}
+		}
+	}
+	return vReg
+}
+
+//--------------------------------------
+
+// Returns the register as it is output, reflected or not.
+func outputRegister(aReg uint16, aRefOut bool) uint16 {
+	if aRefOut {
+		return bits.Reverse16(aReg)
+	}
+	return aReg
+}
+
+//-----------------------------------------------------------------------------
+
+// Solves the samples for Init and XorOut given the other parameters.
+// aRaw holds the registers of the samples fed into a zero register.
+//
+// Each sample gives 16 linear equations out(Init*x^(8*len)) ^ XorOut = Crc ^ out(raw)
+// over the 32 unknown bits, packed as XorOut | Init<<16. It returns a solution and a basis
+// of the differences between solutions. XorOut is eliminated first, so unknown Init bits
+// are the free ones and the returned solution has them zero.
+func solveInitXorOut(aSamples []TSample, aRaw []uint16, aPoly uint16, aRefOut bool) (uint32, []uint32, bool) {
+	// Rows are equations: bits 0-15 select XorOut bits, bits 16-31 Init bits, bit 32 is the constant.
+	vRows := make([]uint64, 0, 16*len(aSamples))
+	for i, s := range aSamples {
+		vShift := polyPowX(8*int64(len(s.Data)), aPoly)
+		var vCols [16]uint16
+		for k := range vCols {
+			vCols[k] = outputRegister(vShift, aRefOut)
+			vShift = polyMulMod(vShift, 2, aPoly)
+		}
+		vRhs := s.Crc ^ outputRegister(aRaw[i], aRefOut)
+		for b := 0; b < 16; b++ {
+			vRow := uint64(1)<<b | uint64(vRhs>>b&1)<<32
+			for k, c := range vCols {
+				vRow |= uint64(c>>b&1) << (16 + k)
+			}
+			vRows = append(vRows, vRow)
+		}
+	}
+
+	// Gauss-Jordan elimination; vPivot[v] is the row determining unknown v.
+	var vPivot [32]int
+	vRank := 0
+	for v := 0; v < 32; v++ {
+		vPivot[v] = -1
+		for r := vRank; r < len(vRows); r++ {
+			if vRows[r]&(1<<v) != 0 {
+				vRows[vRank], vRows[r] = vRows[r], vRows[vRank]
+				break
+			}
+		}
+		if vRank == len(vRows) || vRows[vRank]&(1<<v) == 0 {
+			continue
+		}
+		for r := range vRows {
+			if r != vRank && vRows[r]&(1<<v) != 0 {
+				vRows[r] ^= vRows[vRank]
+			}
+		}
+		vPivot[v] = vRank
+		vRank++
+	}
+	for _, r := range vRows[vRank:] {
+		if r != 0 {
+			return 0, nil, false
+		}
+	}
+
+	var vSol uint32
+	var vKernel []uint32
+	for v, r := range vPivot {
+		if r >= 0 {
+			if vRows[r]&(1<<32) != 0 {
+				vSol |= 1 << v
+			}
+			continue
+		}
+		// Setting free unknown v changes every pivot unknown whose row depends on it.
+		vK := uint32(1) << v
+		for u, q := range vPivot {
+			if q >= 0 && vRows[q]&(1<<v) != 0 {
+				vK |= 1 << u
+			}
+		}
+		vKernel = append(vKernel, vK)
+	}
+	return vSol, vKernel, true
+}
+
+//-----------------------------------------------------------------------------
+
+// RecoverAlgos returns the parameter sets consistent with all samples.
+//
+// Every polynomial with the x^0 term is tried with reflected and unreflected input and
+// output; Init and XorOut are solved for linearly. As in RevEng, input and output
+// reflection may differ; such results follow those with matching reflection. Samples of
+// at least two different lengths are needed to tell Init from XorOut; otherwise the
+// parameters of a predefined algorithm, or failing that a zero Init, are reported, which
+// is equivalent for messages of the sampled length. Recovered algorithms matching a
+// predefined one carry its name, and Check is computed for all of them.
+//
+// Each sample eliminates all but about 2^-16 of the wrong candidates once Init and XorOut
+// are determined, so four or five samples are usually needed for a unique answer.
+func RecoverAlgos(aSamples []TSample) ([]TAlgo, error) {
+	if len(aSamples) < 2 {
+		return nil, errors.New("crc16: at least two samples are needed to recover an algorithm")
+	}
+
+	// Pairs of samples of equal length give a cheap test of the polynomial
+	// since Init and XorOut cancel out from their difference.
+	type tPair struct{ i, j int }
+	var vPairs []tPair
+	for i := range aSamples {
+		for j := i + 1; j < len(aSamples); j++ {
+			if len(aSamples[i].Data) == len(aSamples[j].Data) {
+				vPairs = append(vPairs, tPair{i, j})
+			}
+		}
+	}
+
+	var vRet, vMixed []TAlgo
+	vRaw := make([]uint16, len(aSamples))
+	for p := 1; p < 0x10000; p += 2 {
+		vPoly := uint16(p)
+		for _, vRefIn := range []bool{false, true} {
+			for i, s := range aSamples {
+				vRaw[i] = rawRegister(s.Data, vPoly, vRefIn)
+			}
+		refout:
+			for _, vRefOut := range []bool{vRefIn, !vRefIn} {
+				for _, vPair := range vPairs {
+					vDiff := outputRegister(vRaw[vPair.i]^vRaw[vPair.j], vRefOut)
+					if vDiff != aSamples[vPair.i].Crc^aSamples[vPair.j].Crc {
+						continue refout
+					}
+				}
+				vSol, vKernel, vOk := solveInitXorOut(aSamples, vRaw, vPoly, vRefOut)
+				if !vOk {
+					continue
+				}
+				vAlgo := recoveredAlgo(TAlgo{Poly: vPoly, RefIn: vRefIn, RefOut: vRefOut}, vSol, vKernel)
+				if vRefIn == vRefOut {
+					vRet = append(vRet, vAlgo)
+				} else {
+					vMixed = append(vMixed, vAlgo)
+				}
+			}
+		}
+	}
+	return append(vRet, vMixed...), nil
+}
+
+//--------------------------------------
+
+// Completes a recovered algorithm with Init and XorOut, its Check and the name
+// of the matching predefined algorithm.
+//
+// Where the samples allow several Init and XorOut pairs, the pair of a predefined
+// algorithm is preferred. Such pairs are equivalent for all message lengths when
+// the polynomial has the factor x+1, as CRC-16/X-25 and CRC-16/ARC do.
+func recoveredAlgo(aAlgo TAlgo, aSol uint32, aKernel []uint32) TAlgo {
+	aAlgo.Init, aAlgo.XorOut = uint16(aSol>>16), uint16(aSol)
+	for a := range predefined() {
+		if a.Poly != aAlgo.Poly || a.RefIn != aAlgo.RefIn || a.RefOut != aAlgo.RefOut {
+			continue
+		}
+		// Walk all solutions in Gray code order looking for the parameters of a.
+		vSol := aSol
+		for i := uint32(1); ; i++ {
+			if uint16(vSol>>16) == a.Init && uint16(vSol) == a.XorOut {
+				aAlgo.Init, aAlgo.XorOut, aAlgo.Name = a.Init, a.XorOut, a.Name
+				break
+			}
+			if i == 1<<len(aKernel) {
+				break
+			}
+			vSol ^= aKernel[bits.TrailingZeros32(i)]
+		}
+		if aAlgo.Name != "" {
+			break
+		}
+	}
+	aAlgo.Check = Checksum([]byte("123456789"), MakeTable(aAlgo))
+	return aAlgo
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,72 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nocatalogue || (crc16_ccitt && crc16_ibm)
+
+package crc16
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+// Returns samples of the specified messages checksummed with the algorithm.
+func makeSamples(aAlgo TAlgo, aMsgs ...string) []TSample {
+	vTable := MakeTable(aAlgo)
+	vRet := make([]TSample, len(aMsgs))
+	for i, m := range aMsgs {
+		vRet[i] = TSample{Data: []byte(m), Crc: Checksum([]byte(m), vTable)}
+	}
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
+
+func TestRecoverAlgos(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		for _, vAlgo := range []TAlgo{CRC16_X_25, CRC16_DDS_110} {
+			vRes, err := RecoverAlgos(makeSamples(vAlgo, "123456789", "hello, world", "hello, wOrld!!", "x", "0123456789abcdef"))
+			So(err, ShouldBeNil)
+			So(len(vRes), ShouldEqual, 1)
+			So(sameParams(&vRes[0], &vAlgo), ShouldBeTrue)
+			So(vRes[0].Check, ShouldEqual, vAlgo.Check)
+		}
+
+		vRes, err := RecoverAlgos(makeSamples(CRC16_MODBUS, "123456789", "hello", "abcdefghi"))
+		So(err, ShouldBeNil)
+		So(len(vRes), ShouldBeGreaterThan, 0)
+		So(vRes[0].Name, ShouldEqual, CRC16_MODBUS.Name)
+
+		// A single length can not tell Init from XorOut; the catalogue parameters are preferred.
+		// Three samples also leave room for a chance match with mixed reflection, which comes last.
+		vRes, err = RecoverAlgos(makeSamples(CRC16_GENIBUS, "123456789", "abcdefghi", "ABCDEFGHI"))
+		So(err, ShouldBeNil)
+		So(len(vRes), ShouldBeGreaterThan, 0)
+		So(vRes[0].Name, ShouldEqual, CRC16_GENIBUS.Name)
+		for _, a := range vRes[1:] {
+			So(a.RefIn, ShouldNotEqual, a.RefOut)
+		}
+
+		vRes, err = RecoverAlgos(makeSamples(TAlgo{Poly: 0x1021, Init: 0x1234, XorOut: 0x4321}, "123456789", "abcdefghi", "ABCDEFGHI"))
+		So(err, ShouldBeNil)
+		So(len(vRes), ShouldBeGreaterThan, 0)
+		So(vRes[0].Init, ShouldEqual, 0)
+		So(vRes[0].Name, ShouldBeEmpty)
+		for _, a := range vRes[1:] {
+			So(a.RefIn, ShouldNotEqual, a.RefOut)
+		}
+
+		vMixed := TAlgo{Poly: 0x8BB7, Init: 0xFFFF, RefIn: true, XorOut: 0x00FF}
+		vRes, err = RecoverAlgos(makeSamples(vMixed, "123456789", "hello, world", "hello, wOrld!!", "x", "0123456789abcdef"))
+		So(err, ShouldBeNil)
+		So(len(vRes), ShouldEqual, 1)
+		So(sameParams(&vRes[0], &vMixed), ShouldBeTrue)
+
+		_, err = RecoverAlgos(makeSamples(CRC16_ARC, "123456789"))
+		So(err, ShouldNotBeNil)
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,74 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import "io"
+
+//-----------------------------------------------------------------------------
+
+// This file contains the maintenance of the checksum of large seekable targets, e.g. images
+// patched in place, without reading them again as a whole.
+
+// ReverifyRange checks that the n bytes of rs at offset off have the expected checksum
+// and returns a *ChecksumError, with an unknown offset, if they do not.
+func ReverifyRange(rs io.ReadSeeker, off, n int64, aTable *TTable, aExpected uint16) error {
+	if _, err := rs.Seek(off, io.SeekStart); err != nil {
+		return err
+	}
+	vH := New(aTable)
+	if _, err := io.CopyN(vH, rs, n); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	vSum := vH.Sum16()
+	countVerified(int(n), vSum == aExpected)
+	if vSum != aExpected {
+		return &ChecksumError{Algo: aTable.algo.Name, Expected: aExpected, Actual: vSum, Offset: -1}
+	}
+	return nil
+}
+
+//--------------------------------------
+
+// RewriteSum returns the checksum of data after the bytes aOld are replaced in place with
+// aNew of the same length, given its checksum aSum before and the number of bytes
+// following them aAfter. It runs in O(len(aOld) + log(aAfter)) time.
+func RewriteSum(aSum uint16, aOld, aNew []byte, aAfter int64, aTable *TTable) uint16 {
+	if len(aOld) != len(aNew) {
+		panic("crc16: rewritten ranges differ in length")
+	}
+	// The checksum is affine in the data: the change of the checksum is the linear part
+	// of the checksum of the change.
+	vDelta := make([]byte, len(aOld))
+	for i := range vDelta {
+		vDelta[i] = aOld[i] ^ aNew[i]
+	}
+	vM := zerosMatrix(aAfter, aTable)
+	vReg := vM.apply(Update(0, vDelta, aTable))
+	return aSum ^ Complete(vReg, aTable) ^ Complete(0, aTable)
+}
+
+//--------------------------------------
+
+// RewriteAt writes aNew at offset off of f, of aSize bytes and checksum aSum,
+// and returns the checksum of f updated by RewriteSum from the bytes overwritten.
+func RewriteAt(f interface {
+	io.ReaderAt
+	io.WriterAt
+}, off int64, aNew []byte, aSize int64, aSum uint16, aTable *TTable) (uint16, error) {
+	if off < 0 || off+int64(len(aNew)) > aSize {
+		return aSum, io.ErrUnexpectedEOF
+	}
+	vOld := make([]byte, len(aNew))
+	if _, err := f.ReadAt(vOld, off); err != nil {
+		return aSum, err
+	}
+	if _, err := f.WriteAt(aNew, off); err != nil {
+		return aSum, err
+	}
+	return RewriteSum(aSum, vOld, aNew, aSize-off-int64(len(aNew)), aTable), nil
+}
+
+//-----------------------------------------------------------------------------
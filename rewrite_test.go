@@ -0,0 +1,61 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestReverifyRange(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(TAlgo{Poly: 0x1021, Init: 0xFFFF})
+		vData := []byte("header123456789trailer")
+		So(ReverifyRange(bytes.NewReader(vData), 6, 9, vTable, 0x29B1), ShouldBeNil)
+		err := ReverifyRange(bytes.NewReader(vData), 5, 9, vTable, 0x29B1)
+		So(errors.Is(err, ErrChecksumMismatch), ShouldBeTrue)
+		So(ReverifyRange(bytes.NewReader(vData), 20, 9, vTable, 0x29B1), ShouldNotBeNil)
+	})
+}
+
+//--------------------------------------
+
+func TestRewrite(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vData := make([]byte, 5000)
+		for i := range vData {
+			vData[i] = byte(i * 13)
+		}
+		for _, a := range []TAlgo{{Poly: 0x1021, Init: 0xFFFF}, {Poly: 0x8005, Init: 0xFFFF, RefIn: true, RefOut: true, XorOut: 0x1234}} {
+			vTable := MakeTable(a)
+			vNew := bytes.Clone(vData)
+			copy(vNew[1000:], "patched")
+			vSum := RewriteSum(Checksum(vData, vTable), vData[1000:1007], vNew[1000:1007], 3993, vTable)
+			So(vSum, ShouldEqual, Checksum(vNew, vTable))
+		}
+
+		vTable := MakeTable(TAlgo{Poly: 0x1021, Init: 0xFFFF})
+		vName := filepath.Join(aT.TempDir(), "image.bin")
+		So(os.WriteFile(vName, vData, 0o644), ShouldBeNil)
+		vFile, err := os.OpenFile(vName, os.O_RDWR, 0)
+		So(err, ShouldBeNil)
+		defer vFile.Close()
+		vSum, err := RewriteAt(vFile, 4990, []byte("end"), 5000, Checksum(vData, vTable), vTable)
+		So(err, ShouldBeNil)
+		vNew, _ := os.ReadFile(vName)
+		So(string(vNew[4990:4993]), ShouldEqual, "end")
+		So(vSum, ShouldEqual, Checksum(vNew, vTable))
+		_, err = RewriteAt(vFile, 4999, []byte("end"), 5000, vSum, vTable)
+		So(err, ShouldNotBeNil)
+		So(func() { RewriteSum(0, []byte{1}, nil, 0, vTable) }, ShouldPanic)
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,78 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+//-----------------------------------------------------------------------------
+
+// This file contains the rolling checksum of a sliding window, e.g. for content-defined
+// chunking or signature scanning.
+//
+// With R(w) the register after the n-byte window w, the algebra of combine.go gives
+// R(w[1:]+in) = Update(R(w), in) ^ Z^n*Update(0, out) ^ Z^n*(Z*Init ^ Init),
+// where Z is the zero byte operator, so a 256-entry table indexed by the byte leaving
+// the window makes rolling as cheap as updating.
+
+// TRoller rolls the checksums of windows of a fixed length.
+type TRoller struct {
+	table  *TTable
+	window int
+	out    [256]uint16
+}
+
+//-----------------------------------------------------------------------------
+
+// NewRoller returns a TRoller for windows of aWindow bytes checksummed with the table.
+// It panics if aWindow is not positive.
+func NewRoller(aTable *TTable, aWindow int) *TRoller {
+	if aWindow <= 0 {
+		panic("crc16: invalid rolling window length")
+	}
+	vR := &TRoller{table: aTable, window: aWindow}
+	vZn := zerosMatrix(int64(aWindow), aTable)
+	vZn1 := zerosMatrix(int64(aWindow)+1, aTable)
+	vInit := Init(aTable)
+	vK := vZn1.apply(vInit) ^ vZn.apply(vInit)
+	for b := range vR.out {
+		vR.out[b] = vZn.apply(Update(0, []byte{byte(b)}, aTable)) ^ vK
+	}
+	return vR
+}
+
+//--------------------------------------
+
+// Window returns the length of the windows.
+func (aR *TRoller) Window() int {
+	return aR.window
+}
+
+//--------------------------------------
+
+// Roll returns the checksum of the window moved by a byte, given the checksum aSum
+// of the window, the byte aOut leaving it at the front and the byte aIn entering it
+// at the back. It runs in constant time.
+func (aR *TRoller) Roll(aSum uint16, aOut, aIn byte) uint16 {
+	vReg := Update(register(aSum, aR.table), []byte{aIn}, aR.table) ^ aR.out[aOut]
+	return Complete(vReg, aR.table)
+}
+
+//--------------------------------------
+
+// Scan calls yield with the offset and checksum of every window of data, in order,
+// until it returns false, e.g. to find the chunk boundaries of data.
+func (aR *TRoller) Scan(data []byte, yield func(aOffset int, aSum uint16) bool) {
+	if len(data) < aR.window {
+		return
+	}
+	vSum := Checksum(data[:aR.window], aR.table)
+	if !yield(0, vSum) {
+		return
+	}
+	for i := aR.window; i < len(data); i++ {
+		vSum = aR.Roll(vSum, data[i-aR.window], data[i])
+		if !yield(i-aR.window+1, vSum) {
+			return
+		}
+	}
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,48 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nocatalogue || (crc16_ccitt && crc16_ibm)
+
+package crc16
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestRoller(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vData := make([]byte, 300)
+		for i := range vData {
+			vData[i] = byte(i*i + 3*i)
+		}
+		for _, a := range []TAlgo{CRC16_ARC, CRC16_XMODEM, CRC16_GENIBUS, CRC16_MODBUS} {
+			vTable := MakeTable(a)
+			for _, w := range []int{1, 2, 16, 48} {
+				vR := NewRoller(vTable, w)
+				So(vR.Window(), ShouldEqual, w)
+				vSum := Checksum(vData[:w], vTable)
+				for i := w; i < len(vData); i++ {
+					vSum = vR.Roll(vSum, vData[i-w], vData[i])
+					So(vSum, ShouldEqual, Checksum(vData[i-w+1:i+1], vTable))
+				}
+
+				n := 0
+				vR.Scan(vData, func(aOffset int, aSum uint16) bool {
+					So(aOffset, ShouldEqual, n)
+					So(aSum, ShouldEqual, Checksum(vData[aOffset:aOffset+w], vTable))
+					n++
+					return n < 10
+				})
+				So(n, ShouldEqual, 10)
+			}
+		}
+		vR := NewRoller(MakeTable(CRC16_ARC), 8)
+		vR.Scan(vData[:7], func(int, uint16) bool { panic("no window") })
+		So(func() { NewRoller(MakeTable(CRC16_ARC), 0) }, ShouldPanic)
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,86 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import "errors"
+
+//-----------------------------------------------------------------------------
+
+// algoError attributes an error to the algorithm it was found in.
+type algoError struct {
+	algo string
+	err  error
+}
+
+//-----------------------------------------------------------------------------
+
+// Error returns the description of the error naming the algorithm.
+func (aE *algoError) Error() string {
+	return aE.err.Error() + " in " + aE.algo
+}
+
+//--------------------------------------
+
+// Unwrap returns the error found.
+func (aE *algoError) Unwrap() error {
+	return aE.err
+}
+
+//-----------------------------------------------------------------------------
+
+// Validate returns an error if the parameters of the algorithm are impossible, like a polynomial
+// without the x^0 term or an unknown trailer order, or a *ChecksumError if its checksum of
+// "123456789", computed bitwise from the parameters, is not its Check value.
+func (aAlgo *TAlgo) Validate() error {
+	if aAlgo.Poly&1 == 0 {
+		return errors.New("crc16: polynomial " + hex16(aAlgo.Poly) + " of " + aAlgo.Name + " lacks the x^0 term")
+	}
+	if aAlgo.Trailer > TrailerBigEndian {
+		return errors.New("crc16: invalid trailer order of " + aAlgo.Name)
+	}
+	if v := ChecksumBitwise([]byte("123456789"), *aAlgo); v != aAlgo.Check {
+		return &ChecksumError{Algo: aAlgo.Name, Expected: aAlgo.Check, Actual: v, Offset: -1}
+	}
+	return nil
+}
+
+//--------------------------------------
+
+// MakeTableChecked returns the TTable constructed from the specified algorithm like MakeTable,
+// or the error of its Validate, e.g. for algorithms read from configuration.
+func MakeTableChecked(aAlgo TAlgo) (*TTable, error) {
+	if err := aAlgo.Validate(); err != nil {
+		return nil, err
+	}
+	return MakeTable(aAlgo), nil
+}
+
+//--------------------------------------
+
+// SelfTest validates every predefined algorithm, and every one loaded by LoadCatalog, and checks
+// its table against the bitwise reference on messages of lengths exercising all engines,
+// returning the first error found, ErrEngineDivergence if the table disagrees with the reference. Long-running systems can call
+// it at startup, as functional-safety standards require of checksum implementations.
+func SelfTest() error {
+	var vData [300]byte
+	for i := range vData {
+		vData[i] = byte(i*i + 7*i)
+	}
+	for a := range predefined() {
+		if err := a.Validate(); err != nil {
+			return err
+		}
+		vTable := MakeTable(*a)
+		if err := vTable.Validate(); err != nil {
+			return &algoError{a.Name, err}
+		}
+		for _, n := range [...]int{0, 1, 9, 63, 64, 65, 200, len(vData)} {
+			if Checksum(vData[:n], vTable) != ChecksumBitwise(vData[:n], *a) {
+				return &algoError{a.Name, ErrEngineDivergence}
+			}
+		}
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
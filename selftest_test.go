@@ -0,0 +1,54 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nocatalogue || crc16_ibm
+
+package crc16
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestAlgoValidate(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		for a := range predefined() {
+			So(a.Validate(), ShouldBeNil)
+		}
+
+		vAlgo := CRC16_MODBUS
+		vAlgo.Check ^= 1
+		err := vAlgo.Validate()
+		So(errors.Is(err, ErrChecksumMismatch), ShouldBeTrue)
+		_, err = MakeTableChecked(vAlgo)
+		So(errors.Is(err, ErrChecksumMismatch), ShouldBeTrue)
+
+		vAlgo = CRC16_MODBUS
+		vAlgo.Poly = 0x8004
+		So(vAlgo.Validate(), ShouldNotBeNil)
+		vAlgo = CRC16_MODBUS
+		vAlgo.Trailer = 7
+		So(vAlgo.Validate(), ShouldNotBeNil)
+
+		vTable, err := MakeTableChecked(CRC16_MODBUS)
+		So(err, ShouldBeNil)
+		So(Checksum([]byte("123456789"), vTable), ShouldEqual, CRC16_MODBUS.Check)
+	})
+}
+
+//--------------------------------------
+
+func TestSelfTest(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		So(SelfTest(), ShouldBeNil)
+
+		vErr := &algoError{CRC16_ARC.Name, ErrEngineDivergence}
+		So(errors.Is(vErr, ErrEngineDivergence), ShouldBeTrue)
+		So(vErr.Error(), ShouldEqual, "crc16: table and bitwise engines diverge in CRC-16/ARC")
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,27 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import "iter"
+
+//-----------------------------------------------------------------------------
+
+// ChecksumSeq returns the checksum of the concatenation of the chunks yielded by seq,
+// e.g. by a parser or a decoder, without materializing it. Chunks may be reused by seq
+// once the next one is requested.
+func ChecksumSeq(seq iter.Seq[[]byte], aTable *TTable) uint16 {
+	return Complete(UpdateSeq(Init(aTable), seq, aTable), aTable)
+}
+
+//--------------------------------------
+
+// UpdateSeq returns the result of adding the chunks yielded by seq to the crc, e.g. the
+// segments of a scatter/gather frame following a header already added.
+func UpdateSeq(crc uint16, seq iter.Seq[[]byte], aTable *TTable) uint16 {
+	for vChunk := range seq {
+		crc = Update(crc, vChunk, aTable)
+	}
+	return crc
+}
+
+//-----------------------------------------------------------------------------
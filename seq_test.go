@@ -0,0 +1,51 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nocatalogue || crc16_ibm
+
+package crc16
+
+import (
+	"slices"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestChecksumSeq(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(TAlgo{Poly: 0x1021, Init: 0xFFFF})
+		So(ChecksumSeq(slices.Values([][]byte{[]byte("1234"), nil, []byte("56789")}), vTable), ShouldEqual, 0x29B1)
+		So(ChecksumSeq(slices.Values([][]byte(nil)), vTable), ShouldEqual, 0xFFFF)
+
+		// A generator reusing its buffer.
+		vDigits := func(yield func([]byte) bool) {
+			vBuf := make([]byte, 1)
+			for c := byte('1'); c <= '9'; c++ {
+				vBuf[0] = c
+				if !yield(vBuf) {
+					return
+				}
+			}
+		}
+		So(ChecksumSeq(vDigits, vTable), ShouldEqual, 0x29B1)
+	})
+}
+
+//--------------------------------------
+
+func TestUpdateSeq(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_MODBUS)
+		vSegments := [][]byte{[]byte("234"), make([]byte, 200), []byte("56789")}
+		vFrame := slices.Concat([]byte("1"), vSegments[0], vSegments[1], vSegments[2])
+
+		crc := Update(Init(vTable), []byte("1"), vTable)
+		crc = UpdateSeq(crc, slices.Values(vSegments), vTable)
+		So(Complete(crc, vTable), ShouldEqual, Checksum(vFrame, vTable))
+		So(UpdateSeq(0x1234, slices.Values([][]byte(nil)), vTable), ShouldEqual, 0x1234)
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,72 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import "math/bits"
+
+//-----------------------------------------------------------------------------
+
+// This file contains the 16-entry lookup tables processing four bits per step,
+// selectable at run time for the algorithms used rarely enough not to deserve
+// a TTable, while the crc16_nibble build uses them for all tables.
+
+// TSmallTable is the 32-byte lookup table of an algorithm.
+type TSmallTable struct {
+	algo    TAlgo
+	entries [16]uint16
+}
+
+//-----------------------------------------------------------------------------
+
+// MakeSmallTable returns the TSmallTable of the specified algorithm.
+func MakeSmallTable(aAlgo TAlgo) *TSmallTable {
+	vT := &TSmallTable{algo: aAlgo}
+	for n := range vT.entries {
+		crc := uint16(n) << 12
+		for range 4 {
+			vHigh := crc & 0x8000
+			crc <<= 1
+			if vHigh != 0 {
+				crc ^= aAlgo.Poly
+			}
+		}
+		vT.entries[n] = crc
+	}
+	return vT
+}
+
+//--------------------------------------
+
+// Algo returns the algorithm of the table.
+func (aT *TSmallTable) Algo() TAlgo {
+	return aT.algo
+}
+
+//--------------------------------------
+
+// Update returns the result of adding the bytes in data to the crc, a register
+// starting from the Init of the algorithm like that of Update.
+func (aT *TSmallTable) Update(crc uint16, data []byte) uint16 {
+	for _, d := range data {
+		if aT.algo.RefIn {
+			d = bits.Reverse8(d)
+		}
+		crc = crc<<4 ^ aT.entries[byte(crc>>12)^d>>4]
+		crc = crc<<4 ^ aT.entries[byte(crc>>12)^d&0x0f]
+	}
+	return crc
+}
+
+//--------------------------------------
+
+// Checksum returns CRC checksum of data, like Checksum with the TTable of the algorithm
+// at about half the speed.
+func (aT *TSmallTable) Checksum(data []byte) uint16 {
+	crc := aT.Update(aT.algo.Init, data)
+	if aT.algo.RefOut {
+		crc = bits.Reverse16(crc)
+	}
+	return crc ^ aT.algo.XorOut
+}
+
+//-----------------------------------------------------------------------------
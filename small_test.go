@@ -0,0 +1,32 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import (
+	"testing"
+	"unsafe"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestSmallTable(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vData := make([]byte, 100)
+		for i := range vData {
+			vData[i] = byte(i * 29)
+		}
+		for a := range predefined() {
+			vT := MakeSmallTable(*a)
+			So(vT.Algo(), ShouldResemble, *a)
+			So(vT.Checksum([]byte("123456789")), ShouldEqual, a.Check)
+			So(vT.Checksum(vData), ShouldEqual, ChecksumBitwise(vData, *a))
+			vCrc := vT.Update(a.Init, vData[:33])
+			So(vCrc, ShouldEqual, Update(a.Init, vData[:33], MakeTable(*a)))
+		}
+		So(unsafe.Sizeof(TSmallTable{}.entries), ShouldEqual, uintptr(32))
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,176 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package crc16
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//-----------------------------------------------------------------------------
+
+// This file contains the parser of algorithm definitions in the notation
+// of the CRC RevEng catalogue, e.g.
+//
+//	width=16 poly=0x1021 init=0xffff refin=true refout=true xorout=0xffff check=0x906e name="CRC-16/X-25"
+//
+// Widths of 1 to 16 bits are supported, e.g. width=12 for CRC-12/UMTS.
+
+//-----------------------------------------------------------------------------
+
+// Splits a specification into its key=value fields; values may be double-quoted.
+func specFields(aSpec string) ([][2]string, error) {
+	var vRet [][2]string
+	s := aSpec
+	for {
+		s = strings.TrimLeft(s, " \t\r\n")
+		if s == "" {
+			return vRet, nil
+		}
+		vKey, vRest, vOk := strings.Cut(s, "=")
+		if !vOk || vKey == "" || strings.ContainsAny(vKey, " \t\r\n") {
+			return nil, fmt.Errorf("crc16: malformed field %q in algorithm specification", strings.Fields(s)[0])
+		}
+		var vVal string
+		if strings.HasPrefix(vRest, `"`) {
+			vEnd := strings.IndexByte(vRest[1:], '"')
+			if vEnd < 0 {
+				return nil, fmt.Errorf("crc16: unterminated quoted value of %q in algorithm specification", vKey)
+			}
+			vVal, s = vRest[1:vEnd+1], vRest[vEnd+2:]
+		} else {
+			vEnd := strings.IndexAny(vRest, " \t\r\n")
+			if vEnd < 0 {
+				vEnd = len(vRest)
+			}
+			vVal, s = vRest[:vEnd], vRest[vEnd:]
+		}
+		vRet = append(vRet, [2]string{strings.ToLower(vKey), vVal})
+	}
+}
+
+//--------------------------------------
+
+// Parses a 16-bit specification value given in hexadecimal with the 0x prefix or in decimal.
+func parseSpecWord(aKey, aVal string) (uint16, error) {
+	v, err := strconv.ParseUint(aVal, 0, 16)
+	if err != nil {
+		return 0, fmt.Errorf("crc16: invalid %s value %q in algorithm specification", aKey, aVal)
+	}
+	return uint16(v), nil
+}
+
+//-----------------------------------------------------------------------------
+
+// ParseAlgo parses an algorithm definition in the notation of the CRC RevEng catalogue.
+//
+// The poly field is mandatory; init and xorout default to 0, refin and refout to false,
+// and check is left zero when omitted. The residue field is optional and checked against the
+// residue of the other parameters.
+// With augmented=true, init is the value of the classical augmented definition, see Augmented.
+// The trailer order is taken from the predefined algorithm of the same name and parameters, if any.
+// Values are hexadecimal with the 0x prefix or decimal; the name may be double-quoted.
+func ParseAlgo(aSpec string) (TAlgo, error) {
+	vFields, err := specFields(aSpec)
+	if err != nil {
+		return TAlgo{}, err
+	}
+	return parseSpec(vFields)
+}
+
+//--------------------------------------
+
+// String returns the definition of the algorithm in the notation of the CRC RevEng catalogue,
+// as parsed by ParseAlgo. The name is omitted if empty.
+func (aAlgo TAlgo) String() string {
+	vWidth := 16 - aAlgo.pad()
+	n := (vWidth + 3) / 4
+	vRet := fmt.Sprintf("width=%d poly=0x%0*x init=0x%0*x refin=%t refout=%t xorout=0x%0*x check=0x%0*x residue=0x%0*x",
+		vWidth, n, aAlgo.Poly, n, aAlgo.Init, aAlgo.RefIn, aAlgo.RefOut, n, aAlgo.XorOut, n, aAlgo.Check, n, aAlgo.Residue())
+	if aAlgo.Name != "" {
+		vRet += fmt.Sprintf(" name=%q", aAlgo.Name)
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Returns the algorithm defined by the key=value fields of a specification.
+func parseSpec(aFields [][2]string) (TAlgo, error) {
+	vAlgo := TAlgo{Width: 16}
+	vHasPoly, vAugmented := false, false
+	vResidue, vHasResidue := uint16(0), false
+	for _, f := range aFields {
+		vKey, vVal := f[0], f[1]
+		switch vKey {
+		case "width":
+			v, err := strconv.Atoi(vVal)
+			if err != nil || v < 1 || v > 16 {
+				return TAlgo{}, fmt.Errorf("crc16: unsupported width %q in algorithm specification", vVal)
+			}
+			vAlgo.Width = v
+		case "poly", "init", "xorout", "check", "residue":
+			v, err := parseSpecWord(vKey, vVal)
+			if err != nil {
+				return TAlgo{}, err
+			}
+			switch vKey {
+			case "poly":
+				vAlgo.Poly, vHasPoly = v, true
+			case "init":
+				vAlgo.Init = v
+			case "xorout":
+				vAlgo.XorOut = v
+			case "check":
+				vAlgo.Check = v
+			case "residue":
+				vResidue, vHasResidue = v, true
+			}
+		case "augmented":
+			v, err := strconv.ParseBool(vVal)
+			if err != nil {
+				return TAlgo{}, fmt.Errorf("crc16: invalid %s value %q in algorithm specification", vKey, vVal)
+			}
+			vAugmented = v
+		case "refin", "refout":
+			v, err := strconv.ParseBool(vVal)
+			if err != nil {
+				return TAlgo{}, fmt.Errorf("crc16: invalid %s value %q in algorithm specification", vKey, vVal)
+			}
+			if vKey == "refin" {
+				vAlgo.RefIn = v
+			} else {
+				vAlgo.RefOut = v
+			}
+		case "name":
+			vAlgo.Name = vVal
+		default:
+			return TAlgo{}, fmt.Errorf("crc16: unknown field %q in algorithm specification", vKey)
+		}
+	}
+	if !vHasPoly {
+		return TAlgo{}, errors.New("crc16: missing poly in algorithm specification")
+	}
+	for _, v := range [...]uint16{vAlgo.Poly, vAlgo.Init, vAlgo.XorOut, vAlgo.Check, vResidue} {
+		if v>>vAlgo.Width != 0 {
+			return TAlgo{}, fmt.Errorf("crc16: value %s exceeds the width %d in algorithm specification", hex16(v), vAlgo.Width)
+		}
+	}
+	if vAugmented {
+		vAlgo = Augmented(vAlgo)
+	}
+	if vHasResidue && vResidue != vAlgo.Residue() {
+		return TAlgo{}, fmt.Errorf("crc16: residue %s in algorithm specification does not match the parameters, %s expected",
+			hex16(vResidue), hex16(vAlgo.Residue()))
+	}
+	if a, vFound := AlgoByName(vAlgo.Name); vFound && vAlgo.Name != "" && sameParams(a, &vAlgo) {
+		vAlgo.Trailer = a.Trailer
+	}
+	return vAlgo, nil
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,107 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || (crc16_ccitt && crc16_ibm && crc16_misc))
+
+package crc16
+
+import (
+	"testing"
+
+	"github.com/mbsulliv/crc16/crc"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestParseAlgo(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vAlgo, err := ParseAlgo(`width=16 poly=0x1021 init=0xffff refin=true refout=true xorout=0xffff check=0x906e residue=0xf0b8 name="CRC-16/X-25"`)
+		So(err, ShouldBeNil)
+		So(vAlgo, ShouldResemble, CRC16_X_25)
+
+		vAlgo, err = ParseAlgo("poly=32773 refin=1 refout=1 ")
+		So(err, ShouldBeNil)
+		So(vAlgo, ShouldResemble, TAlgo{Poly: 0x8005, RefIn: true, RefOut: true, Width: 16})
+		So(Checksum([]byte("123456789"), MakeTable(vAlgo)), ShouldEqual, CRC16_ARC.Check)
+
+		vAlgo, err = ParseAlgo("poly=0x1021 init=0xffff augmented=true")
+		So(err, ShouldBeNil)
+		So(vAlgo.Init, ShouldEqual, 0x1D0F)
+
+		vAlgo, err = ParseAlgo(`poly=0x1021 name="two words"`)
+		So(err, ShouldBeNil)
+		So(vAlgo.Name, ShouldEqual, "two words")
+
+		_, err = ParseAlgo("poly=0x1021 init=0xffff refin=true refout=true xorout=0xffff residue=0x0000")
+		So(err, ShouldNotBeNil)
+
+		for _, vBad := range []string{
+			"", "init=0xffff", "width=32 poly=0x04c11db7", "poly=0x10000", "poly=0x1021 refin=maybe",
+			"poly=0x1021 colour=red", "poly=0x1021 augmented=perhaps", `poly=0x1021 name="open`, "poly", "poly=0x1021 =1",
+			"width=0 poly=1", "width=12 poly=0x180f", "width=12 poly=0x80f init=0x1000",
+		} {
+			_, err = ParseAlgo(vBad)
+			So(err, ShouldNotBeNil)
+		}
+	})
+}
+
+//--------------------------------------
+
+func TestAlgoString(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		So(CRC16_X_25.String(), ShouldEqual, `width=16 poly=0x1021 init=0xffff refin=true refout=true xorout=0xffff check=0x906e residue=0xf0b8 name="CRC-16/X-25"`)
+		So(TAlgo{Poly: 0x8005}.String(), ShouldEqual, "width=16 poly=0x8005 init=0x0000 refin=false refout=false xorout=0x0000 check=0x0000 residue=0x0000")
+
+		for a := range predefined() {
+			vAlgo, err := ParseAlgo(a.String())
+			So(err, ShouldBeNil)
+			So(vAlgo, ShouldResemble, *a)
+		}
+	})
+}
+
+//--------------------------------------
+
+func TestAlgoWidth(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		// Long inputs run through the slicing and folding engines.
+		defer func(n int) { slicingMin = n }(slicingMin)
+		slicingMin = 0
+		vData := make([]byte, 1000)
+		for i := range vData {
+			vData[i] = byte(i*29 + i>>5)
+		}
+		// Algorithms of 10 to 15 bits of the CRC RevEng catalogue, the residues checked by ParseAlgo.
+		for _, vSpec := range []string{
+			`width=10 poly=0x233 init=0x000 refin=false refout=false xorout=0x000 check=0x199 residue=0x000 name="CRC-10/ATM"`,
+			`width=10 poly=0x175 init=0x000 refin=false refout=false xorout=0x3ff check=0x12a residue=0x0c6 name="CRC-10/GSM"`,
+			`width=11 poly=0x385 init=0x01a refin=false refout=false xorout=0x000 check=0x5a3 residue=0x000 name="CRC-11/FLEXRAY"`,
+			`width=12 poly=0xd31 init=0x000 refin=false refout=false xorout=0xfff check=0xb34 residue=0x178 name="CRC-12/GSM"`,
+			`width=12 poly=0x80f init=0x000 refin=false refout=true xorout=0x000 check=0xdaf residue=0x000 name="CRC-12/UMTS"`,
+			`width=13 poly=0x1cf5 init=0x0000 refin=false refout=false xorout=0x0000 check=0x04fa residue=0x0000 name="CRC-13/BBC"`,
+			`width=14 poly=0x0805 init=0x0000 refin=true refout=true xorout=0x0000 check=0x082d residue=0x0000 name="CRC-14/DARC"`,
+			`width=14 poly=0x202d init=0x0000 refin=false refout=false xorout=0x3fff check=0x30ae residue=0x031e name="CRC-14/GSM"`,
+			`width=15 poly=0x4599 init=0x0000 refin=false refout=false xorout=0x0000 check=0x059e residue=0x0000 name="CRC-15/CAN"`,
+			`width=15 poly=0x6815 init=0x0000 refin=false refout=false xorout=0x0001 check=0x2566 residue=0x6815 name="CRC-15/MPT1327"`,
+		} {
+			vAlgo, err := ParseAlgo(vSpec)
+			So(err, ShouldBeNil)
+			So(vAlgo.String(), ShouldEqual, vSpec)
+			So(ChecksumBitwise([]byte("123456789"), vAlgo), ShouldEqual, vAlgo.Check)
+			vTable := MakeTable(vAlgo)
+			So(Checksum([]byte("123456789"), vTable), ShouldEqual, vAlgo.Check)
+			So(ChecksumConstantTime([]byte("123456789"), vTable), ShouldEqual, vAlgo.Check)
+			So(Augmented(vAlgo).Init, ShouldEqual, vAlgo.shiftZeros(vAlgo.Init))
+
+			vSum := crc.Checksum(vData, crc.MakeTable(vAlgo.Generic()))
+			So(Checksum(vData, vTable), ShouldEqual, vSum)
+			So(Checksum(vData, MakeTableSliced(vAlgo)), ShouldEqual, vSum)
+			So(Complete(Update(Update(Init(vTable), vData[:333], vTable), vData[333:], vTable), vTable), ShouldEqual, vSum)
+		}
+		So(func() { MakeTable(TAlgo{Poly: 0x1021, Width: 17}) }, ShouldPanic)
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,134 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import "math/bits"
+
+//-----------------------------------------------------------------------------
+
+// This file contains the emulation of the CRC peripheral of STM32 microcontrollers
+// with a programmable polynomial (F0, F3, F7, G0, G4, H7, L0, L4 and later), set to
+// a 16-bit polynomial, so that host software predicts exactly what the MCU computes.
+
+// TSTM32RevIn is the REV_IN setting of the peripheral: the unit the bit order
+// of the data written is reversed in.
+type TSTM32RevIn int
+
+const (
+	STM32RevNone TSTM32RevIn = iota
+	STM32RevByte
+	STM32RevHalfWord
+	STM32RevWord
+)
+
+// TSTM32Config holds the registers configuring the peripheral: CRC_POL, CRC_INIT,
+// and the REV_IN and REV_OUT fields of CRC_CR. The peripheral has no final XOR.
+type TSTM32Config struct {
+	Poly   uint16
+	Init   uint16
+	RevIn  TSTM32RevIn
+	RevOut bool
+}
+
+// TSTM32 emulates the peripheral.
+type TSTM32 struct {
+	cfg   TSTM32Config
+	table *TTable
+	crc   uint16
+}
+
+//-----------------------------------------------------------------------------
+
+// MakeSTM32 returns the peripheral configured and reset.
+func MakeSTM32(aCfg TSTM32Config) *TSTM32 {
+	vP := &TSTM32{cfg: aCfg, table: MakeTable(TAlgo{Poly: aCfg.Poly})}
+	vP.Reset()
+	return vP
+}
+
+//--------------------------------------
+
+// Reset loads CRC_INIT into the register, like setting the RESET bit of CRC_CR.
+func (aP *TSTM32) Reset() {
+	aP.crc = aP.cfg.Init
+}
+
+//--------------------------------------
+
+// Returns v, written with an access of aWidth bits, with the bit order reversed in the configured unit
+// capped at the access width.
+func (aP *TSTM32) reverse(v uint32, aWidth int) uint32 {
+	switch {
+	case aP.cfg.RevIn == STM32RevNone:
+		return v
+	case aP.cfg.RevIn == STM32RevByte || aWidth == 8:
+		return bits.ReverseBytes32(bits.Reverse32(v))
+	case aP.cfg.RevIn == STM32RevHalfWord || aWidth == 16:
+		return bits.RotateLeft32(bits.Reverse32(v), 16)
+	default:
+		return bits.Reverse32(v)
+	}
+}
+
+//--------------------------------------
+
+// Shifts the aWidth bits of v into the register, most significant first.
+func (aP *TSTM32) write(v uint32, aWidth int) {
+	v = aP.reverse(v, aWidth)
+	for s := aWidth - 8; s >= 0; s -= 8 {
+		aP.crc = aP.table.data.shift(aP.crc, byte(v>>s))
+	}
+}
+
+//--------------------------------------
+
+// Write8 emulates a byte write to CRC_DR.
+func (aP *TSTM32) Write8(v byte) {
+	aP.write(uint32(v), 8)
+}
+
+//--------------------------------------
+
+// Write16 emulates a half-word write to CRC_DR.
+func (aP *TSTM32) Write16(v uint16) {
+	aP.write(uint32(v), 16)
+}
+
+//--------------------------------------
+
+// Write32 emulates a word write to CRC_DR.
+func (aP *TSTM32) Write32(v uint32) {
+	aP.write(v, 32)
+}
+
+//--------------------------------------
+
+// WriteBuffer emulates writing data to CRC_DR by accesses of aWidth bits, 8, 16 or 32,
+// loaded from memory in little-endian byte order as the Cortex-M core does;
+// trailing bytes not filling an access are written byte by byte.
+func (aP *TSTM32) WriteBuffer(data []byte, aWidth int) {
+	vStep := max(aWidth/8, 1)
+	for len(data) >= vStep {
+		var v uint32
+		for i := vStep - 1; i >= 0; i-- {
+			v = v<<8 | uint32(data[i])
+		}
+		aP.write(v, 8*vStep)
+		data = data[vStep:]
+	}
+	for _, b := range data {
+		aP.Write8(b)
+	}
+}
+
+//--------------------------------------
+
+// Value returns the content of CRC_DR, bit-reversed if REV_OUT is set.
+func (aP *TSTM32) Value() uint16 {
+	if aP.cfg.RevOut {
+		return bits.Reverse16(aP.crc)
+	}
+	return aP.crc
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,52 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestSTM32(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vCheck := []byte("123456789")
+
+		// Reflected byte input and output is CRC-16/MODBUS, whatever the access width
+		// when the reversal unit matches it.
+		vP := MakeSTM32(TSTM32Config{Poly: 0x8005, Init: 0xFFFF, RevIn: STM32RevByte, RevOut: true})
+		vP.WriteBuffer(vCheck, 8)
+		So(vP.Value(), ShouldEqual, 0x4B37)
+		vP = MakeSTM32(TSTM32Config{Poly: 0x8005, Init: 0xFFFF, RevIn: STM32RevWord, RevOut: true})
+		vP.WriteBuffer(vCheck, 32)
+		So(vP.Value(), ShouldEqual, 0x4B37)
+		vP = MakeSTM32(TSTM32Config{Poly: 0x8005, Init: 0xFFFF, RevIn: STM32RevHalfWord, RevOut: true})
+		vP.WriteBuffer(vCheck, 16)
+		So(vP.Value(), ShouldEqual, 0x4B37)
+
+		// Without reversal, byte writes give CRC-16/IBM-3740.
+		vP = MakeSTM32(TSTM32Config{Poly: 0x1021, Init: 0xFFFF})
+		vP.WriteBuffer(vCheck, 8)
+		So(vP.Value(), ShouldEqual, 0x29B1)
+		vP.Reset()
+		for _, b := range vCheck {
+			vP.Write8(b)
+		}
+		So(vP.Value(), ShouldEqual, 0x29B1)
+
+		// Word writes of little-endian memory feed the bytes of every word in reverse.
+		vP.Reset()
+		vP.WriteBuffer(vCheck, 32)
+		vTable := MakeTable(TAlgo{Poly: 0x1021, Init: 0xFFFF})
+		So(vP.Value(), ShouldEqual, Checksum([]byte("432187659"), vTable))
+		vP.Reset()
+		vP.Write32(0x31323334)
+		vP.Write16(0x3536)
+		vP.Write8(0x37)
+		So(vP.Value(), ShouldEqual, Checksum([]byte("1234567"), vTable))
+	})
+}
+
+//-----------------------------------------------------------------------------
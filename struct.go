@@ -0,0 +1,232 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package crc16
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+//-----------------------------------------------------------------------------
+
+// This file contains the checksumming of Go values in a canonical binary encoding,
+// for integrity checks of configurations and records without hand-written serialization.
+//
+// The encoding is the one of encoding/binary for fixed-size values, in the byte order set
+// by StructByteOrder, big-endian by default: booleans are a byte, integers and floating-point
+// numbers take their size, int and uint take 8 bytes, and arrays and structs are encoded
+// element by element and field by field in declaration order, unexported and blank fields
+// included. Strings and slices are encoded as their length in a 4-byte word followed by
+// their elements. Pointers and interfaces are encoded as the value they refer to.
+// Maps, channels, functions and nil pointers or interfaces can not be encoded.
+//
+// Struct fields tagged crc16:"-" are left out of the encoding. A uint16 field of the
+// checksummed struct tagged crc16:"field" is left out as well and holds the checksum
+// of the struct for Seal and Validate, for messages carrying their own checksum.
+
+// TStructOption configures the encoding of ChecksumStruct.
+type TStructOption func(*tStructEncoder)
+
+// tStructEncoder feeds the canonical encoding of values into a CRC register.
+type tStructEncoder struct {
+	table *TTable
+	order binary.ByteOrder
+	crc   uint16
+	buf   [8]byte
+}
+
+//-----------------------------------------------------------------------------
+
+// StructByteOrder sets the byte order of multi-byte values.
+func StructByteOrder(aOrder binary.ByteOrder) TStructOption {
+	return func(aE *tStructEncoder) {
+		aE.order = aOrder
+	}
+}
+
+//--------------------------------------
+
+// Feeds an unsigned integer of aSize bytes.
+func (aE *tStructEncoder) writeUint(v uint64, aSize int) {
+	switch aSize {
+	case 1:
+		aE.buf[0] = byte(v)
+	case 2:
+		aE.order.PutUint16(aE.buf[:], uint16(v))
+	case 4:
+		aE.order.PutUint32(aE.buf[:], uint32(v))
+	default:
+		aE.order.PutUint64(aE.buf[:], v)
+	}
+	aE.crc = Update(aE.crc, aE.buf[:aSize], aE.table)
+}
+
+//--------------------------------------
+
+// Feeds the encoding of v.
+func (aE *tStructEncoder) encode(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Bool:
+		vBit := uint64(0)
+		if v.Bool() {
+			vBit = 1
+		}
+		aE.writeUint(vBit, 1)
+	case reflect.Int, reflect.Int64:
+		aE.writeUint(uint64(v.Int()), 8)
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		aE.writeUint(uint64(v.Int()), int(v.Type().Size()))
+	case reflect.Uint, reflect.Uint64:
+		aE.writeUint(v.Uint(), 8)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		aE.writeUint(v.Uint(), int(v.Type().Size()))
+	case reflect.Float32:
+		aE.writeUint(uint64(math.Float32bits(float32(v.Float()))), 4)
+	case reflect.Float64:
+		aE.writeUint(math.Float64bits(v.Float()), 8)
+	case reflect.Complex64:
+		aE.writeUint(uint64(math.Float32bits(float32(real(v.Complex())))), 4)
+		aE.writeUint(uint64(math.Float32bits(float32(imag(v.Complex())))), 4)
+	case reflect.Complex128:
+		aE.writeUint(math.Float64bits(real(v.Complex())), 8)
+		aE.writeUint(math.Float64bits(imag(v.Complex())), 8)
+	case reflect.String:
+		aE.writeUint(uint64(v.Len()), 4)
+		aE.crc = Update(aE.crc, []byte(v.String()), aE.table)
+	case reflect.Slice:
+		aE.writeUint(uint64(v.Len()), 4)
+		return aE.encodeElems(v)
+	case reflect.Array:
+		return aE.encodeElems(v)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if vTag := v.Type().Field(i).Tag.Get("crc16"); vTag == "-" || vTag == "field" {
+				continue
+			}
+			if err := aE.encode(v.Field(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return fmt.Errorf("crc16: can not checksum a nil %s", v.Type())
+		}
+		return aE.encode(v.Elem())
+	case reflect.Invalid:
+		return fmt.Errorf("crc16: can not checksum nil")
+	default:
+		return fmt.Errorf("crc16: can not checksum a value of type %s", v.Type())
+	}
+	return nil
+}
+
+//--------------------------------------
+
+// Feeds the encoding of the elements of an array or slice.
+func (aE *tStructEncoder) encodeElems(v reflect.Value) error {
+	if v.Type().Elem().Kind() == reflect.Uint8 && (v.Kind() == reflect.Slice || v.CanAddr()) {
+		aE.crc = Update(aE.crc, v.Bytes(), aE.table)
+		return nil
+	}
+	for i := 0; i < v.Len(); i++ {
+		if err := aE.encode(v.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+
+// ChecksumStruct returns CRC checksum of the canonical encoding of v, usually a struct
+// or a pointer to one, using the specified algorithm represented by the TTable.
+// It returns an error if v holds values which can not be encoded.
+func ChecksumStruct(v any, aTable *TTable, aOpts ...TStructOption) (uint16, error) {
+	return checksumValue(reflect.ValueOf(v), aTable, aOpts)
+}
+
+//--------------------------------------
+
+// Returns CRC checksum of the canonical encoding of v.
+func checksumValue(v reflect.Value, aTable *TTable, aOpts []TStructOption) (uint16, error) {
+	vE := tStructEncoder{table: aTable, order: binary.BigEndian, crc: Init(aTable)}
+	for _, o := range aOpts {
+		o(&vE)
+	}
+	if err := vE.encode(v); err != nil {
+		return 0, err
+	}
+	return Complete(vE.crc, aTable), nil
+}
+
+//-----------------------------------------------------------------------------
+
+// Returns the struct v refers to and its checksum field tagged crc16:"field".
+func checksumField(v any) (reflect.Value, reflect.Value, error) {
+	vStruct := reflect.ValueOf(v)
+	for vStruct.Kind() == reflect.Pointer && !vStruct.IsNil() {
+		vStruct = vStruct.Elem()
+	}
+	if vStruct.Kind() != reflect.Struct {
+		return reflect.Value{}, reflect.Value{}, fmt.Errorf("crc16: %T does not refer to a struct", v)
+	}
+	var vRet reflect.Value
+	for i := 0; i < vStruct.NumField(); i++ {
+		vField := vStruct.Type().Field(i)
+		if vField.Tag.Get("crc16") != "field" {
+			continue
+		}
+		if vRet.IsValid() || vField.Type.Kind() != reflect.Uint16 {
+			return reflect.Value{}, reflect.Value{}, fmt.Errorf("crc16: %s must have a single uint16 checksum field", vStruct.Type())
+		}
+		vRet = vStruct.Field(i)
+	}
+	if !vRet.IsValid() {
+		return reflect.Value{}, reflect.Value{}, fmt.Errorf("crc16: %s has no field tagged crc16:\"field\"", vStruct.Type())
+	}
+	return vStruct, vRet, nil
+}
+
+//--------------------------------------
+
+// Seal stores the checksum of the struct v points to in its field tagged crc16:"field".
+func Seal(v any, aTable *TTable, aOpts ...TStructOption) error {
+	vStruct, vField, err := checksumField(v)
+	if err != nil {
+		return err
+	}
+	if !vField.CanSet() {
+		return fmt.Errorf("crc16: can not set the checksum field of %s", vStruct.Type())
+	}
+	vSum, err := checksumValue(vStruct, aTable, aOpts)
+	if err != nil {
+		return err
+	}
+	vField.SetUint(uint64(vSum))
+	return nil
+}
+
+//--------------------------------------
+
+// Validate returns a *ChecksumError if the field of the struct v tagged crc16:"field"
+// does not hold its checksum.
+func Validate(v any, aTable *TTable, aOpts ...TStructOption) error {
+	vStruct, vField, err := checksumField(v)
+	if err != nil {
+		return err
+	}
+	vSum, err := checksumValue(vStruct, aTable, aOpts)
+	if err != nil {
+		return err
+	}
+	if vStored := uint16(vField.Uint()); vStored != vSum {
+		return &ChecksumError{Algo: aTable.algo.Name, Expected: vStored, Actual: vSum, Offset: -1}
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,114 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || (crc16_ccitt && crc16_ibm))
+
+package crc16
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestChecksumStruct(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		type tHeader struct {
+			Id    uint16
+			Flags [2]byte
+		}
+		type tRecord struct {
+			Header  tHeader
+			Enabled bool
+			Temp    float32
+			count   int32
+			_       uint8
+			Samples [3]int16
+		}
+		vTable := MakeTable(CRC16_MODBUS)
+		vRecord := tRecord{Header: tHeader{0x1234, [2]byte{1, 2}}, Enabled: true, Temp: 21.5, count: -3, Samples: [3]int16{-1, 0, 1}}
+
+		// Fixed-size values are encoded like encoding/binary does.
+		for _, vOrder := range []binary.ByteOrder{binary.BigEndian, binary.LittleEndian} {
+			var vB bytes.Buffer
+			So(binary.Write(&vB, vOrder, struct {
+				Header  tHeader
+				Enabled bool
+				Temp    float32
+				Count   int32
+				Pad     uint8
+				Samples [3]int16
+			}{vRecord.Header, true, 21.5, -3, 0, vRecord.Samples}), ShouldBeNil)
+			vSum, err := ChecksumStruct(&vRecord, vTable, StructByteOrder(vOrder))
+			So(err, ShouldBeNil)
+			So(vSum, ShouldEqual, Checksum(vB.Bytes(), vTable))
+		}
+		vSum, _ := ChecksumStruct(vRecord, vTable)
+		vBig, _ := ChecksumStruct(vRecord, vTable, StructByteOrder(binary.BigEndian))
+		So(vSum, ShouldEqual, vBig)
+
+		// A packed C record whose checksum field is excluded.
+		type tTelemetry struct {
+			Seq   uint32
+			Volts [4]uint16
+			Crc   uint16 `crc16:"-"`
+		}
+		vTelemetry := tTelemetry{Seq: 7, Volts: [4]uint16{3300, 3310, 3290, 0}, Crc: 0xdead}
+		var vB bytes.Buffer
+		binary.Write(&vB, binary.LittleEndian, vTelemetry)
+		vSum, err := ChecksumStruct(vTelemetry, vTable, StructByteOrder(binary.LittleEndian))
+		So(err, ShouldBeNil)
+		So(vSum, ShouldEqual, Checksum(vB.Bytes()[:vB.Len()-2], vTable))
+
+		type tConfig struct {
+			Name  string
+			Ports []uint16
+			Size  int
+		}
+		vSum, err = ChecksumStruct(tConfig{"gw", []uint16{502, 20000}, 1}, vTable)
+		So(err, ShouldBeNil)
+		So(vSum, ShouldEqual, Checksum([]byte{0, 0, 0, 2, 'g', 'w', 0, 0, 0, 2, 0x01, 0xF6, 0x4E, 0x20, 0, 0, 0, 0, 0, 0, 0, 1}, vTable))
+
+		for _, vBad := range []any{nil, map[string]int{}, struct{ F func() }{}, struct{ P *int }{}} {
+			_, err = ChecksumStruct(vBad, vTable)
+			So(err, ShouldNotBeNil)
+		}
+	})
+}
+
+//--------------------------------------
+
+func TestSeal(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		type tMessage struct {
+			Seq     uint32
+			Payload [4]byte
+			Note    string `crc16:"-"`
+			Crc     uint16 `crc16:"field"`
+		}
+		vTable := MakeTable(CRC16_XMODEM)
+		vMsg := tMessage{Seq: 7, Payload: [4]byte{'p', 'i', 'n', 'g'}, Note: "local"}
+		So(Seal(&vMsg, vTable), ShouldBeNil)
+		So(vMsg.Crc, ShouldEqual, Checksum([]byte{0, 0, 0, 7, 'p', 'i', 'n', 'g'}, vTable))
+		So(Validate(vMsg, vTable), ShouldBeNil)
+
+		vMsg.Note = "ignored"
+		So(Validate(&vMsg, vTable), ShouldBeNil)
+		vMsg.Seq++
+		err := Validate(&vMsg, vTable)
+		So(errors.Is(err, ErrChecksumMismatch), ShouldBeTrue)
+
+		So(Seal(vMsg, vTable), ShouldNotBeNil)
+		So(Seal(&struct{ A uint16 }{}, vTable), ShouldNotBeNil)
+		So(Seal(&struct {
+			A uint32 `crc16:"field"`
+		}{}, vTable), ShouldNotBeNil)
+		So(Validate(42, vTable), ShouldNotBeNil)
+	})
+}
+
+//-----------------------------------------------------------------------------
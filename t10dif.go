@@ -0,0 +1,128 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || crc16_misc)
+
+package crc16
+
+import (
+	"encoding/binary"
+	"errors"
+	"strconv"
+)
+
+//-----------------------------------------------------------------------------
+
+// This file contains the T10 protection information of storage sectors, as used by SCSI
+// and NVMe: an 8-byte tuple per sector holding the CRC-16/T10-DIF of the sector as the guard
+// tag, an application tag and a reference tag, all big-endian. With Type 1 protection the
+// reference tag is the low 32 bits of the logical block address, incrementing per sector.
+
+// cDIFSize is the size of a protection information tuple.
+const cDIFSize = 8
+
+// cDIFEscape is the application tag disabling the checks of a sector.
+const cDIFEscape = 0xFFFF
+
+// TDIFTuple is the protection information of a sector.
+type TDIFTuple struct {
+	Guard  uint16 // CRC-16/T10-DIF of the sector.
+	AppTag uint16 // Application tag, owned by the application.
+	RefTag uint32 // Reference tag, usually the low bits of the logical block address.
+}
+
+// DIFRefTagError reports a sector whose protection information carries another reference tag than expected.
+type DIFRefTagError struct {
+	Sector   int    // Index of the sector in the buffer.
+	RefTag   uint32 // Reference tag of the sector.
+	Expected uint32 // Reference tag expected.
+}
+
+//-----------------------------------------------------------------------------
+
+// Error returns the description of the reference tag mismatch.
+func (aE *DIFRefTagError) Error() string {
+	return "crc16: DIF reference tag " + strconv.FormatUint(uint64(aE.RefTag), 10) + " of sector " +
+		strconv.Itoa(aE.Sector) + ", expected " + strconv.FormatUint(uint64(aE.Expected), 10)
+}
+
+//-----------------------------------------------------------------------------
+
+// MakeDIFTuple returns the protection information of the sector with the specified tags.
+func MakeDIFTuple(aSector []byte, aAppTag uint16, aRefTag uint32) TDIFTuple {
+	return TDIFTuple{Guard: Checksum(aSector, Table(CRC16_T10_DIF)), AppTag: aAppTag, RefTag: aRefTag}
+}
+
+//--------------------------------------
+
+// ParseDIFTuple returns the protection information tuple in the first 8 bytes of b.
+func ParseDIFTuple(b []byte) TDIFTuple {
+	return TDIFTuple{Guard: binary.BigEndian.Uint16(b), AppTag: binary.BigEndian.Uint16(b[2:]), RefTag: binary.BigEndian.Uint32(b[4:])}
+}
+
+//--------------------------------------
+
+// Append appends the 8-byte wire form of the tuple to dst and returns the extended slice.
+func (aT *TDIFTuple) Append(dst []byte) []byte {
+	dst = binary.BigEndian.AppendUint16(dst, aT.Guard)
+	dst = binary.BigEndian.AppendUint16(dst, aT.AppTag)
+	return binary.BigEndian.AppendUint32(dst, aT.RefTag)
+}
+
+//--------------------------------------
+
+// Panics unless data holds a whole number of sectors of aSectorSize bytes.
+func checkSectors(data []byte, aSectorSize int) {
+	if aSectorSize <= 0 || len(data)%aSectorSize != 0 {
+		panic("crc16: data is not a whole number of sectors")
+	}
+}
+
+//--------------------------------------
+
+// AppendDIF appends the protection information of the consecutive sectors of aSectorSize
+// bytes, e.g. 512 or 4096, in data to dst and returns the extended slice. The reference tag
+// starts at aRefTag and increments per sector as with Type 1 protection. The guards are
+// computed by the fastest engine available, folding with carry-less multiplication where
+// supported. It panics unless data holds a whole number of sectors.
+func AppendDIF(dst, data []byte, aSectorSize int, aAppTag uint16, aRefTag uint32) []byte {
+	checkSectors(data, aSectorSize)
+	vTable := Table(CRC16_T10_DIF)
+	for i := 0; i < len(data); i += aSectorSize {
+		vTuple := TDIFTuple{Guard: Checksum(data[i:i+aSectorSize], vTable), AppTag: aAppTag, RefTag: aRefTag}
+		dst = vTuple.Append(dst)
+		aRefTag++
+	}
+	return dst
+}
+
+//--------------------------------------
+
+// VerifyDIF checks the protection information pi of the consecutive sectors of aSectorSize
+// bytes in data, as appended by AppendDIF with the first reference tag aRefTag. Sectors with
+// the application tag 0xFFFF are not checked. A guard mismatch is reported as a *ChecksumError
+// with the offset of the guard in pi, and a reference tag mismatch as a *DIFRefTagError.
+// It panics unless data holds a whole number of sectors.
+func VerifyDIF(data, pi []byte, aSectorSize int, aRefTag uint32) error {
+	checkSectors(data, aSectorSize)
+	if len(pi) != len(data)/aSectorSize*cDIFSize {
+		return errors.New("crc16: protection information does not match the number of sectors")
+	}
+	vTable := Table(CRC16_T10_DIF)
+	for n := 0; n*cDIFSize < len(pi); n++ {
+		vTuple := ParseDIFTuple(pi[n*cDIFSize:])
+		if vTuple.AppTag == cDIFEscape {
+			continue
+		}
+		vSum := Checksum(data[n*aSectorSize:(n+1)*aSectorSize], vTable)
+		countVerified(aSectorSize, vSum == vTuple.Guard)
+		if vSum != vTuple.Guard {
+			return &ChecksumError{Algo: CRC16_T10_DIF.Name, Expected: vTuple.Guard, Actual: vSum, Offset: int64(n * cDIFSize)}
+		}
+		if vTuple.RefTag != aRefTag+uint32(n) {
+			return &DIFRefTagError{Sector: n, RefTag: vTuple.RefTag, Expected: aRefTag + uint32(n)}
+		}
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
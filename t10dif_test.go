@@ -0,0 +1,56 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || crc16_misc)
+
+package crc16
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestDIF(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vData := make([]byte, 4*512)
+		for i := range vData {
+			vData[i] = byte(i * 7)
+		}
+		vTuple := MakeDIFTuple(vData[:512], 0x0102, 1000)
+		So(vTuple.Guard, ShouldEqual, ChecksumBitwise(vData[:512], CRC16_T10_DIF))
+		vWire := vTuple.Append(nil)
+		So(vWire[2:], ShouldResemble, []byte{0x01, 0x02, 0x00, 0x00, 0x03, 0xe8})
+		So(ParseDIFTuple(vWire), ShouldResemble, vTuple)
+
+		vPI := AppendDIF(nil, vData, 512, 0x0102, 1000)
+		So(len(vPI), ShouldEqual, 4*8)
+		So(vPI[:8], ShouldResemble, vWire)
+		So(ParseDIFTuple(vPI[24:]).RefTag, ShouldEqual, 1003)
+		So(VerifyDIF(vData, vPI, 512, 1000), ShouldBeNil)
+
+		vData[2*512+9] ^= 0x20
+		vErr := VerifyDIF(vData, vPI, 512, 1000)
+		So(errors.Is(vErr, ErrChecksumMismatch), ShouldBeTrue)
+		var vSumErr *ChecksumError
+		So(errors.As(vErr, &vSumErr), ShouldBeTrue)
+		So(vSumErr.Offset, ShouldEqual, 16)
+
+		// The escape application tag disables the checks of the sector.
+		vPI[16+2], vPI[16+3] = 0xff, 0xff
+		So(VerifyDIF(vData, vPI, 512, 1000), ShouldBeNil)
+		vData[2*512+9] ^= 0x20
+
+		var vRefErr *DIFRefTagError
+		So(errors.As(VerifyDIF(vData, vPI, 512, 999), &vRefErr), ShouldBeTrue)
+		So(*vRefErr, ShouldResemble, DIFRefTagError{Sector: 0, RefTag: 1000, Expected: 999})
+
+		So(VerifyDIF(vData, vPI[:24], 512, 1000), ShouldNotBeNil)
+		So(func() { AppendDIF(nil, vData[:100], 512, 0, 0) }, ShouldPanic)
+		So(func() { VerifyDIF(vData, vPI, 0, 0) }, ShouldPanic)
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,162 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package crc16
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+//-----------------------------------------------------------------------------
+
+// This file contains the binary encoding of tables, so that precomputed tables may be
+// cached on disk or shipped to other processes, also with encoding/gob.
+//
+// The encoding is the magic "c16t" and a version byte, the parameters of the algorithm
+// as big-endian words in the TAlgo order, the name prefixed by its length in a byte,
+// the Width in a byte, the number of entries in a big-endian word and the entries,
+// followed by the CRC-16/IBM-3740 of the whole. The flags RefIn and RefOut, the Trailer
+// order and whether the slicing tables were built are packed in a byte between Init
+// and XorOut. Version 1 lacks the Width, decoded as zero.
+
+const (
+	cTableMagic   = "c16t"
+	cTableVersion = 2
+)
+
+// Seals encoded tables against corruption.
+var tableSeal = TAlgo{Poly: 0x1021, Init: 0xFFFF}
+
+//-----------------------------------------------------------------------------
+
+// MarshalBinary implements encoding.BinaryMarshaler. It returns ErrTableCorrupt for a
+// table modified since its construction, see Validate. The entries of tables built with the crc16_nibble tag are only usable by such builds;
+// the others rebuild them from the polynomial. Tables with the slicing tables built,
+// e.g. by MakeTableSliced, decode with them built too.
+func (aTable *TTable) MarshalBinary() ([]byte, error) {
+	if err := aTable.Validate(); err != nil {
+		return nil, err
+	}
+	a := &aTable.algo
+	if len(a.Name) > 0xFF {
+		return nil, errors.New("crc16: algorithm name too long to encode")
+	}
+	var vFlags byte
+	if a.RefIn {
+		vFlags |= 1
+	}
+	if a.RefOut {
+		vFlags |= 2
+	}
+	vFlags |= byte(a.Trailer) << 2
+	if aTable.slices.built() {
+		vFlags |= 0x10
+	}
+	vRet := append([]byte(cTableMagic), cTableVersion)
+	vRet = binary.BigEndian.AppendUint16(vRet, a.Poly)
+	vRet = binary.BigEndian.AppendUint16(vRet, a.Init)
+	vRet = append(vRet, vFlags)
+	vRet = binary.BigEndian.AppendUint16(vRet, a.XorOut)
+	vRet = binary.BigEndian.AppendUint16(vRet, a.Check)
+	vRet = append(append(vRet, byte(len(a.Name))), a.Name...)
+	vRet = append(vRet, byte(a.Width))
+	vRet = binary.BigEndian.AppendUint16(vRet, uint16(len(aTable.data)))
+	for _, e := range aTable.data {
+		vRet = binary.BigEndian.AppendUint16(vRet, e)
+	}
+	return binary.BigEndian.AppendUint16(vRet, Checksum(vRet, Table(tableSeal))), nil
+}
+
+//--------------------------------------
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It fails if the data is corrupt,
+// the entries do not match the polynomial or the parameters do not compute the check
+// value of the algorithm, if any.
+func (aTable *TTable) UnmarshalBinary(data []byte) error {
+	const cHeader = len(cTableMagic) + 1 + 9
+	if len(data) < cHeader+1+4 || string(data[:len(cTableMagic)]) != cTableMagic {
+		return errors.New("crc16: not an encoded table")
+	}
+	vBody := data[:len(data)-2]
+	if Checksum(vBody, Table(tableSeal)) != binary.BigEndian.Uint16(data[len(vBody):]) {
+		return errors.New("crc16: corrupt encoded table")
+	}
+	vVersion := data[len(cTableMagic)]
+	if vVersion != 1 && vVersion != cTableVersion {
+		return errors.New("crc16: unsupported encoded table version")
+	}
+
+	p := vBody[len(cTableMagic)+1:]
+	var vAlgo TAlgo
+	vAlgo.Poly = binary.BigEndian.Uint16(p)
+	vAlgo.Init = binary.BigEndian.Uint16(p[2:])
+	vAlgo.RefIn, vAlgo.RefOut = p[4]&1 != 0, p[4]&2 != 0
+	vAlgo.Trailer = TTrailerOrder(p[4] >> 2 & 3)
+	vAlgo.XorOut = binary.BigEndian.Uint16(p[5:])
+	vAlgo.Check = binary.BigEndian.Uint16(p[7:])
+	p = p[9:]
+	vLen := int(p[0])
+	if len(p) < 1+vLen+2+int(vVersion-1) {
+		return errors.New("crc16: corrupt encoded table")
+	}
+	vAlgo.Name, p = string(p[1:1+vLen]), p[1+vLen:]
+	if vVersion > 1 {
+		vAlgo.Width, p = int(p[0]), p[1:]
+		if vAlgo.Width > 16 {
+			return errors.New("crc16: corrupt encoded table")
+		}
+	}
+	vCount := int(binary.BigEndian.Uint16(p))
+	p = p[2:]
+	if len(p) != 2*vCount {
+		return errors.New("crc16: corrupt encoded table")
+	}
+
+	// The entries of a CRC are linear in the index, so each one must be the exclusive or
+	// of those of its lowest set bit and of the other bits, and those of single bits are
+	// checked against the polynomial, to catch tables encoded for another one.
+	var vTable TTable
+	vTable.algo = vAlgo
+	vPoly := vAlgo.Poly << vAlgo.pad()
+	if vCount != len(vTable.data) {
+		vTable.data.build(vPoly)
+	} else {
+		for i := range vTable.data {
+			vTable.data[i] = binary.BigEndian.Uint16(p[2*i:])
+		}
+		for n := range vTable.data {
+			if vTable.data[n] != vTable.data[n&(n-1)]^vTable.data[n&-n] {
+				return errors.New("crc16: encoded table entries do not match the polynomial")
+			}
+		}
+		vShifts := bits.Len(uint(len(vTable.data))) - 1
+		for n := 1; n < len(vTable.data); n <<= 1 {
+			crc := uint16(n) << (16 - vShifts)
+			for range vShifts {
+				crc = crc<<1 ^ vPoly&-(crc>>15)
+			}
+			if vTable.data[n] != crc {
+				return errors.New("crc16: encoded table entries do not match the polynomial")
+			}
+		}
+	}
+	vTable.reflected.derive(&vAlgo, &vTable.data)
+	if vAlgo.Check != 0 && Checksum([]byte("123456789"), &vTable) != vAlgo.Check {
+		return errors.New("crc16: encoded table does not match its check value")
+	}
+
+	aTable.algo = vAlgo
+	aTable.data = vTable.data
+	aTable.reflected = vTable.reflected
+	aTable.seal = aTable.digest()
+	aTable.slices.reset()
+	if vFlags := vBody[len(cTableMagic)+1+4]; vFlags&0x10 != 0 {
+		aTable.slices.build(aTable)
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,206 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || (crc16_ccitt && crc16_ibm))
+
+package crc16
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"encoding/gob"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestTableBinary(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_KERMIT)
+		vData, err := vTable.MarshalBinary()
+		So(err, ShouldBeNil)
+
+		var vDecoded TTable
+		So(vDecoded.UnmarshalBinary(vData), ShouldBeNil)
+		So(vDecoded.Validate(), ShouldBeNil)
+		So(vDecoded.Algo(), ShouldResemble, CRC16_KERMIT)
+		So(vDecoded.Entries(), ShouldEqual, vTable.Entries())
+		So(Checksum([]byte("123456789"), &vDecoded), ShouldEqual, CRC16_KERMIT.Check)
+
+		// A build with the other table size rebuilds the entries.
+		vOther := append([]byte(nil), vData[:len(vData)-2-2*len(vTable.data)-2]...)
+		vOther = append(vOther, 0, 0)
+		vOther = append(vOther, byte(Checksum(vOther, MakeTable(tableSeal))>>8), byte(Checksum(vOther, MakeTable(tableSeal))))
+		So(vDecoded.UnmarshalBinary(vOther), ShouldBeNil)
+		So(vDecoded.Entries(), ShouldEqual, vTable.Entries())
+
+		var vB bytes.Buffer
+		So(gob.NewEncoder(&vB).Encode(vTable), ShouldBeNil)
+		var vGob *TTable
+		So(gob.NewDecoder(&vB).Decode(&vGob), ShouldBeNil)
+		So(vGob.Algo(), ShouldResemble, CRC16_KERMIT)
+		So(Checksum([]byte("123456789"), vGob), ShouldEqual, CRC16_KERMIT.Check)
+
+		// Narrower algorithms keep their width.
+		vNarrow := TAlgo{Poly: 0x80F, RefOut: true, Check: 0xDAF, Name: "CRC-12/UMTS", Width: 12}
+		vEncoded, err := MakeTable(vNarrow).MarshalBinary()
+		So(err, ShouldBeNil)
+		So(vDecoded.UnmarshalBinary(vEncoded), ShouldBeNil)
+		So(vDecoded.Algo(), ShouldResemble, vNarrow)
+		So(Checksum([]byte("123456789"), &vDecoded), ShouldEqual, vNarrow.Check)
+
+		// Version 1 lacks the width.
+		vAt := len(cTableMagic) + 1 + 9 + 1 + len(CRC16_KERMIT.Name)
+		vOld := append(append([]byte(cTableMagic), 1), vData[len(cTableMagic)+1:vAt]...)
+		vOld = append(vOld, vData[vAt+1:len(vData)-2]...)
+		vOld = binary.BigEndian.AppendUint16(vOld, Checksum(vOld, MakeTable(tableSeal)))
+		So(vDecoded.UnmarshalBinary(vOld), ShouldBeNil)
+		vKermit := CRC16_KERMIT
+		vKermit.Width = 0
+		So(vDecoded.Algo(), ShouldResemble, vKermit)
+		So(Checksum([]byte("123456789"), &vDecoded), ShouldEqual, CRC16_KERMIT.Check)
+
+		for i := range vData {
+			vCorrupt := append([]byte(nil), vData...)
+			vCorrupt[i] ^= 0x04
+			So(new(TTable).UnmarshalBinary(vCorrupt), ShouldNotBeNil)
+		}
+		So(new(TTable).UnmarshalBinary(vData[:10]), ShouldNotBeNil)
+		So(new(TTable).UnmarshalBinary(nil), ShouldNotBeNil)
+	})
+}
+
+//--------------------------------------
+
+func TestHashBinary(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_X_25)
+		vData := []byte("123456789")
+		h := New(vTable)
+		h.Write(vData[:4])
+		vState, err := h.(encoding.BinaryMarshaler).MarshalBinary()
+		So(err, ShouldBeNil)
+		So(len(vState), ShouldEqual, 15)
+
+		// The state resumes in a fresh digest, as after a restart.
+		vResumed := New(vTable)
+		So(vResumed.(encoding.BinaryUnmarshaler).UnmarshalBinary(vState), ShouldBeNil)
+		vResumed.Write(vData[4:])
+		So(vResumed.Sum16(), ShouldEqual, CRC16_X_25.Check)
+
+		// Digests of other algorithms reject it.
+		vOther := New(MakeTable(CRC16_KERMIT)).(encoding.BinaryUnmarshaler)
+		So(vOther.UnmarshalBinary(vState), ShouldNotBeNil)
+		So(vResumed.(encoding.BinaryUnmarshaler).UnmarshalBinary(vState[:10]), ShouldNotBeNil)
+		So(vResumed.(encoding.BinaryUnmarshaler).UnmarshalBinary([]byte("c16t\x01")), ShouldNotBeNil)
+		vState[4] = 1
+		So(vResumed.(encoding.BinaryUnmarshaler).UnmarshalBinary(vState), ShouldNotBeNil)
+
+		// As do digests of other sizes, while the wide ones resume as such.
+		vWide32 := NewHash32(vTable)
+		vWide32.Write(vData[:4])
+		vState, err = vWide32.(encoding.BinaryMarshaler).MarshalBinary()
+		So(err, ShouldBeNil)
+		So(New(vTable).(encoding.BinaryUnmarshaler).UnmarshalBinary(vState), ShouldNotBeNil)
+		So(NewHash64(vTable).(encoding.BinaryUnmarshaler).UnmarshalBinary(vState), ShouldNotBeNil)
+		vResumed32 := NewHash32(vTable)
+		So(vResumed32.(encoding.BinaryUnmarshaler).UnmarshalBinary(vState), ShouldBeNil)
+		vResumed32.Write(vData[4:])
+		So(vResumed32.Sum32(), ShouldEqual, uint32(CRC16_X_25.Check))
+		vWide64 := NewHash64(vTable)
+		vWide64.Write(vData)
+		vState, err = vWide64.(encoding.BinaryMarshaler).MarshalBinary()
+		So(err, ShouldBeNil)
+		vResumed64 := NewHash64(vTable)
+		So(vResumed64.(encoding.BinaryUnmarshaler).UnmarshalBinary(vState), ShouldBeNil)
+		So(vResumed64.Sum64(), ShouldEqual, uint64(CRC16_X_25.Check))
+
+		// As do digests of algorithms differing only in width.
+		vNarrow := TAlgo{Poly: 0x80F, RefOut: true, Width: 12}
+		vWide := vNarrow
+		vWide.Width = 0
+		vState, err = New(MakeTable(vNarrow)).(encoding.BinaryMarshaler).MarshalBinary()
+		So(err, ShouldBeNil)
+		So(New(MakeTable(vWide)).(encoding.BinaryUnmarshaler).UnmarshalBinary(vState), ShouldNotBeNil)
+		So(New(MakeTable(vNarrow)).(encoding.BinaryUnmarshaler).UnmarshalBinary(vState), ShouldBeNil)
+	})
+}
+
+//--------------------------------------
+
+func TestTextBinary(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_XMODEM)
+		vWant := NewText(vTable)
+		vWant.Write([]byte("a\rxb"))
+
+		// A carriage return held back at the checkpoint survives it.
+		h := NewText(vTable)
+		h.Write([]byte("a\r"))
+		vState, err := h.(encoding.BinaryMarshaler).MarshalBinary()
+		So(err, ShouldBeNil)
+		vResumed := NewText(vTable)
+		So(vResumed.(encoding.BinaryUnmarshaler).UnmarshalBinary(vState), ShouldBeNil)
+		vResumed.Write([]byte("xb"))
+		So(vResumed.Sum16(), ShouldEqual, vWant.Sum16())
+
+		// Plain and text-mode states are not interchangeable.
+		vPlain, err := New(vTable).(encoding.BinaryMarshaler).MarshalBinary()
+		So(err, ShouldBeNil)
+		So(vResumed.(encoding.BinaryUnmarshaler).UnmarshalBinary(vPlain), ShouldNotBeNil)
+		So(New(vTable).(encoding.BinaryUnmarshaler).UnmarshalBinary(vState), ShouldNotBeNil)
+		vState[len(vState)-1] = 2
+		So(vResumed.(encoding.BinaryUnmarshaler).UnmarshalBinary(vState), ShouldNotBeNil)
+	})
+}
+
+//--------------------------------------
+
+func TestTableBinaryLoad(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTableSliced(CRC16_MODBUS)
+		vData, err := vTable.MarshalBinary()
+		So(err, ShouldBeNil)
+		var vDecoded TTable
+		So(vDecoded.UnmarshalBinary(vData), ShouldBeNil)
+		So(vDecoded.slices.built(), ShouldEqual, cSlicingEngine)
+		So(vDecoded.reflected.entries(), ShouldResemble, vTable.reflected.entries())
+		So(Checksum([]byte("123456789"), &vDecoded), ShouldEqual, CRC16_MODBUS.Check)
+
+		vData, err = MakeTable(CRC16_MODBUS).MarshalBinary()
+		So(err, ShouldBeNil)
+		So(vDecoded.UnmarshalBinary(vData), ShouldBeNil)
+		So(vDecoded.slices.built(), ShouldBeFalse)
+
+		// Entries not matching the algorithm are rejected even when sealed.
+		vBad := append([]byte(nil), vData[:len(vData)-2]...)
+		vBad[len(vBad)-2*len(vDecoded.data)+2*(len(vDecoded.data)/2)+1] ^= 0x01
+		vBad = binary.BigEndian.AppendUint16(vBad, Checksum(vBad, MakeTable(tableSeal)))
+		So(new(TTable).UnmarshalBinary(vBad), ShouldNotBeNil)
+		vBad = append([]byte(nil), vData[:len(vData)-2]...)
+		vBad[len(vBad)-2*len(vDecoded.data)+2*3] ^= 0x04
+		vBad = binary.BigEndian.AppendUint16(vBad, Checksum(vBad, MakeTable(tableSeal)))
+		So(new(TTable).UnmarshalBinary(vBad), ShouldNotBeNil)
+
+		// Tables modified since their construction are not encoded.
+		vCorrupt := MakeTable(CRC16_XMODEM)
+		vCorrupt.data[3] ^= 0x0400
+		_, err = vCorrupt.MarshalBinary()
+		So(err, ShouldEqual, ErrTableCorrupt)
+
+		// As are algorithms whose parameters do not compute their check value.
+		vAlgo := CRC16_MODBUS
+		vAlgo.Check ^= 1
+		vData, err = MakeTable(vAlgo).MarshalBinary()
+		So(err, ShouldBeNil)
+		So(new(TTable).UnmarshalBinary(vData), ShouldNotBeNil)
+		vAlgo.Check = 0
+		vData, err = MakeTable(vAlgo).MarshalBinary()
+		So(err, ShouldBeNil)
+		So(new(TTable).UnmarshalBinary(vData), ShouldBeNil)
+	})
+}
+
+//-----------------------------------------------------------------------------
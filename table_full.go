@@ -0,0 +1,230 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nibble
+
+package crc16
+
+import (
+	"math/bits"
+	"sync/atomic"
+
+	"github.com/mbsulliv/crc16/crc"
+)
+
+//-----------------------------------------------------------------------------
+
+// tTableData is the lookup table processing a byte of input per step.
+type tTableData [256]uint16
+
+// tReflectedData is the lookup table of the algorithms with reflected input, shifting
+// the reflected register least significant bit first so that input bytes need no reversal.
+// It is nil for the other algorithms.
+type tReflectedData struct {
+	data *[256]uint16
+}
+
+// tSlices holds the tables of the slicing engine processing eight bytes of input per step,
+// built on first use.
+type tSlices struct {
+	tables atomic.Pointer[tSliceTables]
+}
+
+// tSliceTables are the tables of the slicing engine, the entry n of the table k being
+// the register after shifting in the byte n followed by k zero bytes, and the constants
+// of the folding engine: x^128, x^192, x^512 and x^576 modulo the polynomial. For the
+// algorithms with reflected input, the tables shift the reflected register least
+// significant bit first, as the reflected lookup table does.
+type tSliceTables struct {
+	slices [8][256]uint16
+	fold   [4]uint64
+	seal   uint16 // digest of the tables when built
+}
+
+// The slicing engine is built in.
+const cSlicingEngine = true
+
+//-----------------------------------------------------------------------------
+
+// Fills the table for the polynomial.
+func (aD *tTableData) build(aPoly uint16) {
+	*aD = crc.MakeEntries(aPoly)
+}
+
+//--------------------------------------
+
+// Returns the register after shifting in the byte d, most significant bit first.
+func (aD *tTableData) shift(c uint16, d byte) uint16 {
+	return crc.Shift(c, d, (*[256]uint16)(aD))
+}
+
+//--------------------------------------
+
+// Builds the table for the algorithm.
+func (aR *tReflectedData) build(aAlgo *TAlgo) {
+	aR.data = nil
+	if aAlgo.RefIn {
+		vEntries := crc.MakeEntriesReflected(bits.Reverse16(aAlgo.Poly << aAlgo.pad()))
+		aR.data = &vEntries
+	}
+}
+
+//--------------------------------------
+
+// Builds the table for the algorithm from the entries of aData, which mirror its own.
+func (aR *tReflectedData) derive(aAlgo *TAlgo, aData *tTableData) {
+	aR.data = nil
+	if aAlgo.RefIn {
+		vEntries := new([256]uint16)
+		for n := range vEntries {
+			vEntries[n] = bits.Reverse16(aData[bits.Reverse8(byte(n))])
+		}
+		aR.data = vEntries
+	}
+}
+
+//--------------------------------------
+
+// Uses the precomputed entries for the algorithm, building them if nil.
+func (aR *tReflectedData) set(aAlgo *TAlgo, aEntries *[256]uint16) {
+	if !aAlgo.RefIn || aEntries == nil {
+		aR.build(aAlgo)
+		return
+	}
+	aR.data = aEntries
+}
+
+//--------------------------------------
+
+// Returns the entries of the table, if any.
+func (aR *tReflectedData) entries() []uint16 {
+	if aR.data == nil {
+		return nil
+	}
+	return aR.data[:]
+}
+
+//--------------------------------------
+
+// Returns the register after shifting in the bytes of data, each least significant bit first.
+// The register is reflected for the loop only.
+func (aR *tReflectedData) update(c uint16, data []byte, aTable *TTable) uint16 {
+	c = bits.Reverse16(c)
+	for _, d := range data {
+		c = crc.ShiftReflected(c, d, aR.data)
+	}
+	return bits.Reverse16(c)
+}
+
+//--------------------------------------
+
+// Returns the register after shifting in the leading multiple of eight bytes of data,
+// and the bytes left, long inputs being folded first if aFold is set.
+func (aS *tSlices) update(crc uint16, data []byte, aTable *TTable, aFold bool) (uint16, []byte) {
+	vTables := aS.tables.Load()
+	if vTables == nil {
+		vTables = aS.build(aTable)
+	}
+	if aFold && len(data) >= cFoldMin {
+		crc, data = fold(crc, data, vTables, aTable)
+	}
+	t := &vTables.slices
+	if aTable.algo.RefIn {
+		// The register is reflected for the loop only.
+		crc = bits.Reverse16(crc)
+		for ; len(data) >= 8; data = data[8:] {
+			d := [8]byte(data)
+			crc = t[7][byte(crc)^d[0]] ^ t[6][byte(crc>>8)^d[1]] ^ t[5][d[2]] ^ t[4][d[3]] ^
+				t[3][d[4]] ^ t[2][d[5]] ^ t[1][d[6]] ^ t[0][d[7]]
+		}
+		return bits.Reverse16(crc), data
+	}
+	for ; len(data) >= 8; data = data[8:] {
+		d := [8]byte(data)
+		crc = t[7][byte(crc>>8)^d[0]] ^ t[6][byte(crc)^d[1]] ^ t[5][d[2]] ^ t[4][d[3]] ^
+			t[3][d[4]] ^ t[2][d[5]] ^ t[1][d[6]] ^ t[0][d[7]]
+	}
+	return crc, data
+}
+
+//--------------------------------------
+
+// Builds the tables from the lookup table and returns them.
+func (aS *tSlices) build(aTable *TTable) *tSliceTables {
+	vRet := new(tSliceTables)
+	t := &vRet.slices
+	if aTable.algo.RefIn {
+		t[0] = *aTable.reflected.data
+		for k := 1; k < 8; k++ {
+			for n, e := range t[k-1] {
+				t[k][n] = e>>8 ^ t[0][byte(e)]
+			}
+		}
+	} else {
+		t[0] = aTable.data
+		for k := 1; k < 8; k++ {
+			for n, e := range t[k-1] {
+				t[k][n] = e<<8 ^ t[0][e>>8]
+			}
+		}
+	}
+	vPoly := aTable.algo.Poly << aTable.algo.pad()
+	vPower := uint16(1)
+	for n := 1; n <= 576; n++ {
+		vPower = vPower<<1 ^ vPoly&-(vPower>>15)
+		switch n {
+		case 128:
+			vRet.fold[0] = uint64(vPower)
+		case 192:
+			vRet.fold[1] = uint64(vPower)
+		case 512:
+			vRet.fold[2] = uint64(vPower)
+		case 576:
+			vRet.fold[3] = uint64(vPower)
+		}
+	}
+	vRet.seal = vRet.digest()
+	aS.tables.Store(vRet)
+	return vRet
+}
+
+//--------------------------------------
+
+// Reports whether the tables, if built, are unchanged since.
+func (aS *tSlices) valid() bool {
+	vTables := aS.tables.Load()
+	return vTables == nil || vTables.digest() == vTables.seal
+}
+
+//--------------------------------------
+
+// Returns the CRC-16/IBM-3740 of the tables and the constants, as TTable.digest does.
+func (aT *tSliceTables) digest() uint16 {
+	vReg := uint16(0xFFFF)
+	for k := range aT.slices {
+		for _, e := range aT.slices[k] {
+			vReg = shiftBitwise(vReg, e, 0x1021)
+		}
+	}
+	for _, v := range aT.fold {
+		for i := 48; i >= 0; i -= 16 {
+			vReg = shiftBitwise(vReg, uint16(v>>i), 0x1021)
+		}
+	}
+	return vReg
+}
+
+//--------------------------------------
+
+// Drops the tables, which are rebuilt on next use.
+func (aS *tSlices) reset() {
+	aS.tables.Store(nil)
+}
+
+//--------------------------------------
+
+// Reports whether the tables are built.
+func (aS *tSlices) built() bool {
+	return aS.tables.Load() != nil
+}
+
+//-----------------------------------------------------------------------------
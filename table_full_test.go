@@ -0,0 +1,65 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nibble
+
+package crc16
+
+import (
+	"testing"
+
+	"github.com/mbsulliv/crc16/crc"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestSliceTables(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		for _, a := range []TAlgo{
+			{Poly: 0x1021, Init: 0xFFFF},
+			{Poly: 0x8005, RefIn: true, RefOut: true},
+		} {
+			vTable := MakeTable(a)
+			vTables := vTable.slices.build(vTable)
+			for k := range vTables.slices {
+				for _, n := range []int{0x01, 0x80, 0xA5, 0xFF} {
+					var c uint16
+					if a.RefIn {
+						c = crc.ShiftReflected(c, byte(n), vTable.reflected.data)
+						for i := 0; i < k; i++ {
+							c = crc.ShiftReflected(c, 0, vTable.reflected.data)
+						}
+					} else {
+						c = vTable.data.shift(c, byte(n))
+						for i := 0; i < k; i++ {
+							c = vTable.data.shift(c, 0)
+						}
+					}
+					So(vTables.slices[k][n], ShouldEqual, c)
+				}
+			}
+		}
+	})
+}
+
+//--------------------------------------
+
+func TestSliceTablesValidate(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTableSliced(TAlgo{Poly: 0x8005, RefIn: true, RefOut: true})
+		So(vTable.Validate(), ShouldBeNil)
+		vTables := vTable.slices.tables.Load()
+		vTables.slices[5][0x3C] ^= 0x0100
+		So(vTable.Validate(), ShouldEqual, ErrTableCorrupt)
+		vTables.slices[5][0x3C] ^= 0x0100
+		So(vTable.Validate(), ShouldBeNil)
+		vTables.fold[2] ^= 1 << 40
+		So(vTable.Validate(), ShouldEqual, ErrTableCorrupt)
+
+		vTable.slices.reset()
+		So(vTable.Validate(), ShouldBeNil)
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,121 @@
+//-----------------------------------------------------------------------------
+
+//go:build crc16_nibble
+
+package crc16
+
+import "math/bits"
+
+//-----------------------------------------------------------------------------
+
+// tTableData is the lookup table processing four bits of input per step.
+// At 32 bytes per table it suits targets short of memory, at about half the speed.
+type tTableData [16]uint16
+
+// tReflectedData stands for the lookup table of the algorithms with reflected input,
+// which is left out: the 16 entries of tTableData serve all algorithms.
+type tReflectedData struct{}
+
+// tSlices stands for the slicing engine, which is left out.
+type tSlices struct{}
+
+// The slicing and folding engines are left out.
+const (
+	cSlicingEngine = false
+	hasFold        = false
+)
+
+//-----------------------------------------------------------------------------
+
+// Fills the table for the polynomial.
+func (aD *tTableData) build(aPoly uint16) {
+	for n := range aD {
+		crc := uint16(n) << 12
+		for i := 0; i < 4; i++ {
+			bit := (crc & 0x8000) != 0
+			crc <<= 1
+			if bit {
+				crc ^= aPoly
+			}
+		}
+		aD[n] = crc
+	}
+}
+
+//--------------------------------------
+
+// Returns the register after shifting in the byte d, most significant bit first.
+func (aD *tTableData) shift(crc uint16, d byte) uint16 {
+	crc = crc<<4 ^ aD[byte(crc>>12)^d>>4]
+	return crc<<4 ^ aD[byte(crc>>12)^d&0x0f]
+}
+
+//--------------------------------------
+
+// Does nothing.
+func (aR *tReflectedData) build(aAlgo *TAlgo) {
+}
+
+//--------------------------------------
+
+// Does nothing.
+func (aR *tReflectedData) derive(aAlgo *TAlgo, aData *tTableData) {
+}
+
+//--------------------------------------
+
+// Does nothing.
+func (aR *tReflectedData) set(aAlgo *TAlgo, aEntries *[256]uint16) {
+}
+
+//--------------------------------------
+
+// Returns no entries.
+func (aR *tReflectedData) entries() []uint16 {
+	return nil
+}
+
+//--------------------------------------
+
+// Returns the register after shifting in the bytes of data, each reversed.
+func (aR *tReflectedData) update(crc uint16, data []byte, aTable *TTable) uint16 {
+	for _, d := range data {
+		crc = aTable.data.shift(crc, bits.Reverse8(d))
+	}
+	return crc
+}
+
+//--------------------------------------
+
+// Returns the register and data unchanged.
+func (aS *tSlices) update(crc uint16, data []byte, aTable *TTable, aFold bool) (uint16, []byte) {
+	return crc, data
+}
+
+//--------------------------------------
+
+// Does nothing.
+func (aS *tSlices) build(aTable *TTable) {
+}
+
+//--------------------------------------
+
+// Does nothing.
+func (aS *tSlices) reset() {
+}
+
+//--------------------------------------
+
+// Reports false.
+func (aS *tSlices) built() bool {
+	return false
+}
+
+//--------------------------------------
+
+// Reports true.
+func (aS *tSlices) valid() bool {
+	return true
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,146 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import "io"
+
+//-----------------------------------------------------------------------------
+
+// This file contains the text-mode digest, which normalizes line endings so that text
+// produces the same checksum in Windows and Unix checkouts.
+
+type textDigest struct {
+	digest
+	cr bool // Whether the last byte written is a carriage return not yet hashed.
+}
+
+//-----------------------------------------------------------------------------
+
+// NewText creates a new CRC16 digest for the given table hashing text with CRLF line endings
+// converted to LF. Lone carriage returns are kept.
+func NewText(t *TTable) Hash16 {
+	aH := textDigest{digest: digest{t: t}}
+	aH.Reset()
+	return &aH
+}
+
+//--------------------------------------
+
+// Write adds more text to the running digest, holding back a final carriage return
+// until the next byte shows whether it ends a line.
+// It never returns an error.
+func (aH *textDigest) Write(data []byte) (int, error) {
+	vLen := len(data)
+	if vLen == 0 {
+		return 0, nil
+	}
+	if aH.cr && data[0] != '\n' {
+		aH.sum = Update(aH.sum, []byte{'\r'}, aH.t)
+	}
+	aH.cr = false
+	for len(data) > 0 {
+		i := 0
+		for i < len(data) && data[i] != '\r' {
+			i++
+		}
+		aH.sum = Update(aH.sum, data[:i], aH.t)
+		if i == len(data) {
+			break
+		}
+		if i+1 == len(data) {
+			aH.cr = true
+			break
+		}
+		if data[i+1] != '\n' {
+			aH.sum = Update(aH.sum, data[i:i+1], aH.t)
+		}
+		data = data[i+1:]
+	}
+	return vLen, nil
+}
+
+//--------------------------------------
+
+// WriteByte adds b to the running digest, e.g. when parsing headers byte by byte.
+// It never returns an error.
+func (aH *textDigest) WriteByte(b byte) error {
+	aH.Write([]byte{b})
+	return nil
+}
+
+//--------------------------------------
+
+// WriteString adds the bytes of s to the running digest like Write, without converting s
+// to a byte slice.
+// It never returns an error.
+func (aH *textDigest) WriteString(s string) (int, error) {
+	return aH.Write(stringBytes(s))
+}
+
+//--------------------------------------
+
+// ReadFrom adds the text read from r until EOF to the running digest like Write,
+// overriding the conversion-less ReadFrom of the embedded digest.
+func (aH *textDigest) ReadFrom(r io.Reader) (int64, error) {
+	vBuf := make([]byte, cReadChunk)
+	var vLen int64
+	for {
+		n, err := r.Read(vBuf)
+		aH.Write(vBuf[:n])
+		vLen += int64(n)
+		if err == io.EOF {
+			return vLen, nil
+		}
+		if err != nil {
+			return vLen, err
+		}
+	}
+}
+
+//--------------------------------------
+
+// Sum appends the current digest (leftmost byte first, big-endian)
+// to b and returns the resulting slice.
+// It does not change the underlying digest state.
+func (aH *textDigest) Sum(b []byte) []byte {
+	s := aH.Sum16()
+	return append(b, byte(s>>8), byte(s))
+}
+
+//--------------------------------------
+
+// SumLE appends the current digest little-endian, rightmost byte first,
+// to b and returns the resulting slice.
+// It does not change the underlying digest state.
+func (aH *textDigest) SumLE(b []byte) []byte {
+	s := aH.Sum16()
+	return append(b, byte(s), byte(s>>8))
+}
+
+//--------------------------------------
+
+// Clone returns an independent copy of the digest, including a held back carriage return.
+func (aH *textDigest) Clone() Hash16 {
+	vRet := *aH
+	return &vRet
+}
+
+//--------------------------------------
+
+// Reset resets the Hash to its initial state.
+func (aH *textDigest) Reset() {
+	aH.digest.Reset()
+	aH.cr = false
+}
+
+//--------------------------------------
+
+// Sum16 returns the CRC16 checksum, counting a final carriage return as a lone one.
+func (aH *textDigest) Sum16() uint16 {
+	if aH.cr {
+		return Complete(Update(aH.sum, []byte{'\r'}, aH.t), aH.t)
+	}
+	return aH.digest.Sum16()
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,53 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestNewText(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(TAlgo{Poly: 0x1021, Init: 0xFFFF})
+		vUnix := "line one\nline two\rstill two\n\nend\r"
+		vWindows := "line one\r\nline two\rstill two\r\n\r\nend\r"
+		vWant := Checksum([]byte(vUnix), vTable)
+
+		vH := NewText(vTable)
+		vH.Write([]byte(vWindows))
+		So(vH.Sum16(), ShouldEqual, vWant)
+
+		// Every split of the text, including between CR and LF.
+		for i := 0; i <= len(vWindows); i++ {
+			vH.Reset()
+			vH.Write([]byte(vWindows[:i]))
+			vH.Write([]byte(vWindows[i:]))
+			So(vH.Sum16(), ShouldEqual, vWant)
+		}
+		vH.Reset()
+		for i := range vWindows {
+			vH.Write([]byte{vWindows[i]})
+		}
+		So(vH.Sum(nil), ShouldResemble, []byte{byte(vWant >> 8), byte(vWant)})
+		So(vH.(LittleEndianSummer).SumLE(nil), ShouldResemble, []byte{byte(vWant), byte(vWant >> 8)})
+
+		vH.Reset()
+		io.Copy(vH, iotest.OneByteReader(strings.NewReader(vWindows)))
+		So(vH.Sum16(), ShouldEqual, vWant)
+
+		vH.Reset()
+		vH.Write([]byte(vUnix))
+		So(vH.Sum16(), ShouldEqual, vWant)
+		vH.Reset()
+		So(vH.Sum16(), ShouldEqual, Checksum(nil, vTable))
+	})
+}
+
+//-----------------------------------------------------------------------------
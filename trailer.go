@@ -0,0 +1,120 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package crc16
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+//-----------------------------------------------------------------------------
+
+// This file contains helpers for frames carrying their checksum
+// in a 2-byte trailer after the data. A nil byte order means the conventional
+// order of the algorithm, as reported by TAlgo.LittleEndian.
+
+// Returns aOrder, or the conventional trailer byte order of the algorithm if it is nil.
+func trailerOrder(aOrder binary.ByteOrder, aTable *TTable) binary.ByteOrder {
+	switch {
+	case aOrder != nil:
+		return aOrder
+	case aTable.algo.LittleEndian():
+		return binary.LittleEndian
+	}
+	return binary.BigEndian
+}
+
+//-----------------------------------------------------------------------------
+
+// AppendChecksum appends the checksum of data to data in the specified byte order
+// and returns the extended slice.
+func AppendChecksum(data []byte, aTable *TTable, aOrder binary.ByteOrder) []byte {
+	return AppendChecksumOf(data, data, aTable, aOrder)
+}
+
+//--------------------------------------
+
+// AppendChecksumOf appends the checksum of data to dst in the specified byte order
+// and returns the extended slice, e.g. to build a frame whose checksum covers only part of it.
+func AppendChecksumOf(dst, data []byte, aTable *TTable, aOrder binary.ByteOrder) []byte {
+	vSum, vOrder := Checksum(data, aTable), trailerOrder(aOrder, aTable)
+	if vAppender, ok := vOrder.(binary.AppendByteOrder); ok {
+		return vAppender.AppendUint16(dst, vSum)
+	}
+	var vTrailer [2]byte
+	vOrder.PutUint16(vTrailer[:], vSum)
+	return append(dst, vTrailer[:]...)
+}
+
+//--------------------------------------
+
+// VerifyTrailer returns true if the last two bytes of frame, read in the specified byte order,
+// are the checksum of the rest of it. Frames shorter than the trailer never verify.
+func VerifyTrailer(frame []byte, aTable *TTable, aOrder binary.ByteOrder) bool {
+	if len(frame) < 2 {
+		return false
+	}
+	return Verify(frame[:len(frame)-2], frame[len(frame)-2:], aTable, aOrder)
+}
+
+//--------------------------------------
+
+// Verify returns true if received, the two bytes of a checksum as received in the specified
+// byte order, the conventional order of the algorithm if nil, is the checksum of data.
+// A received checksum of another length never verifies.
+func Verify(data, received []byte, aTable *TTable, aOrder binary.ByteOrder) bool {
+	if len(received) != 2 {
+		return false
+	}
+	return VerifyUint16(data, trailerOrder(aOrder, aTable).Uint16(received), aTable)
+}
+
+//--------------------------------------
+
+// VerifyUint16 returns true if aSum is the checksum of data.
+func VerifyUint16(data []byte, aSum uint16, aTable *TTable) bool {
+	vOk := Checksum(data, aTable) == aSum
+	countVerified(len(data), vOk)
+	return vOk
+}
+
+//--------------------------------------
+
+// VerifyMessage returns true if the message ends in its checksum in the conventional byte
+// order of the algorithm, running the CRC over the whole message, checksum included, and
+// comparing the register with the residue of the algorithm. Where the residue does not apply,
+// for algorithms with mixed reflection or a trailer order other than that of their register,
+// it compares the trailer like VerifyTrailer.
+func VerifyMessage(message []byte, aTable *TTable) bool {
+	a := &aTable.algo
+	if a.RefIn != a.RefOut || a.LittleEndian() != a.RefOut {
+		return VerifyTrailer(message, aTable, nil)
+	}
+	if len(message) < 2 {
+		return false
+	}
+	vOk := Checksum(message, aTable)^a.XorOut == a.Residue()
+	countVerified(len(message)-2, vOk)
+	return vOk
+}
+
+//--------------------------------------
+
+// CheckTrailer is VerifyTrailer returning a *ChecksumError, with the offset of the trailer,
+// if the frame does not verify.
+func CheckTrailer(frame []byte, aTable *TTable, aOrder binary.ByteOrder) error {
+	if len(frame) < 2 {
+		return errors.New("crc16: frame shorter than its checksum trailer")
+	}
+	vData := frame[:len(frame)-2]
+	vStored, vSum := trailerOrder(aOrder, aTable).Uint16(frame[len(vData):]), Checksum(vData, aTable)
+	countVerified(len(vData), vStored == vSum)
+	if vStored != vSum {
+		return &ChecksumError{Algo: aTable.algo.Name, Expected: vStored, Actual: vSum, Offset: int64(len(vData))}
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
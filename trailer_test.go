@@ -0,0 +1,134 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || (crc16_ccitt && crc16_ibm && crc16_misc))
+
+package crc16
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestTrailer(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_MODBUS)
+		vFrame := AppendChecksum([]byte("123456789"), vTable, binary.LittleEndian)
+		So(vFrame[9:], ShouldResemble, []byte{0x37, 0x4b})
+		So(VerifyTrailer(vFrame, vTable, binary.LittleEndian), ShouldBeTrue)
+		So(VerifyTrailer(vFrame, vTable, binary.BigEndian), ShouldBeFalse)
+
+		vFrame[0] ^= 1
+		So(VerifyTrailer(vFrame, vTable, binary.LittleEndian), ShouldBeFalse)
+
+		So(VerifyTrailer([]byte{0xff}, vTable, binary.LittleEndian), ShouldBeFalse)
+		So(VerifyTrailer(AppendChecksum(nil, vTable, binary.BigEndian), vTable, binary.BigEndian), ShouldBeTrue)
+	})
+}
+
+//--------------------------------------
+
+func TestCheckTrailer(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_MODBUS)
+		vFrame := AppendChecksum([]byte("123456789"), vTable, binary.LittleEndian)
+		So(CheckTrailer(vFrame, vTable, binary.LittleEndian), ShouldBeNil)
+
+		err := CheckTrailer(vFrame, vTable, binary.BigEndian)
+		So(errors.Is(err, ErrChecksumMismatch), ShouldBeTrue)
+		var vMismatch *ChecksumError
+		So(errors.As(err, &vMismatch), ShouldBeTrue)
+		So(*vMismatch, ShouldResemble, ChecksumError{Algo: "CRC-16/MODBUS", Expected: 0x374B, Actual: 0x4B37, Offset: 9})
+		So(err.Error(), ShouldEqual, "crc16: CRC-16/MODBUS checksum mismatch: expected 0x374b, computed 0x4b37 at offset 9")
+		So((&ChecksumError{Expected: 0x12, Offset: -1}).Error(), ShouldEqual, "crc16: checksum mismatch: expected 0x0012, computed 0x0000")
+
+		err = CheckTrailer(vFrame[:1], vTable, binary.LittleEndian)
+		So(err, ShouldNotBeNil)
+		So(errors.Is(err, ErrChecksumMismatch), ShouldBeFalse)
+	})
+}
+
+//--------------------------------------
+
+func TestTrailerOrder(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		So(CRC16_MODBUS.LittleEndian(), ShouldBeTrue)
+		So(CRC16_XMODEM.LittleEndian(), ShouldBeFalse)
+		So((&TAlgo{RefOut: true}).LittleEndian(), ShouldBeTrue)
+		So((&TAlgo{RefOut: true, Trailer: TrailerBigEndian}).LittleEndian(), ShouldBeFalse)
+		So((&TAlgo{Trailer: TrailerLittleEndian}).LittleEndian(), ShouldBeTrue)
+
+		vModbus := MakeTable(CRC16_MODBUS)
+		vFrame := AppendChecksum([]byte("123456789"), vModbus, nil)
+		So(vFrame[9:], ShouldResemble, []byte{0x37, 0x4b})
+		So(VerifyTrailer(vFrame, vModbus, nil), ShouldBeTrue)
+		So(CheckTrailer(vFrame, vModbus, nil), ShouldBeNil)
+		vXmodem := MakeTable(CRC16_XMODEM)
+		So(AppendChecksum([]byte("123456789"), vXmodem, nil)[9:], ShouldResemble, []byte{0x31, 0xc3})
+
+		vAlgo, err := ParseAlgo(`poly=0x8005 init=0xffff refin=true refout=true name="CRC-16/MODBUS"`)
+		So(err, ShouldBeNil)
+		So(vAlgo.Trailer, ShouldEqual, TrailerLittleEndian)
+	})
+}
+
+//--------------------------------------
+
+func TestVerifyMessage(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		for a := range predefined() {
+			vTable := MakeTable(*a)
+			vFrame := AppendChecksum([]byte("residue check"), vTable, nil)
+			So(VerifyMessage(vFrame, vTable), ShouldBeTrue)
+			vFrame[3] ^= 0x01
+			So(VerifyMessage(vFrame, vTable), ShouldBeFalse)
+			So(VerifyMessage(vFrame[:1], vTable), ShouldBeFalse)
+		}
+
+		// Mixed reflection falls back to comparing the trailer.
+		vTable := MakeTable(TAlgo{Poly: 0x8BB7, Init: 0xFFFF, RefIn: true, XorOut: 0x00FF})
+		vFrame := AppendChecksum([]byte("mixed"), vTable, nil)
+		So(VerifyMessage(vFrame, vTable), ShouldBeTrue)
+		vFrame[0] ^= 0x80
+		So(VerifyMessage(vFrame, vTable), ShouldBeFalse)
+	})
+}
+
+//--------------------------------------
+
+func TestAppendChecksumOf(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_MODBUS)
+		vData := []byte("123456789")
+		vHeader := []byte{0x7e, 0x09}
+		vFrame := AppendChecksumOf(append(vHeader, vData...), vData, vTable, binary.BigEndian)
+		So(vFrame, ShouldResemble, []byte{0x7e, 0x09, '1', '2', '3', '4', '5', '6', '7', '8', '9', 0x4b, 0x37})
+		So(AppendChecksumOf(nil, vData, vTable, nil), ShouldResemble, []byte{0x37, 0x4b})
+
+		vDst := make([]byte, 0, 16)
+		So(testing.AllocsPerRun(100, func() { AppendChecksumOf(vDst, vData, vTable, binary.LittleEndian) }), ShouldEqual, 0)
+	})
+}
+
+//--------------------------------------
+
+func TestVerify(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_MODBUS)
+		vData := []byte("123456789")
+		So(Verify(vData, []byte{0x37, 0x4b}, vTable, nil), ShouldBeTrue)
+		So(Verify(vData, []byte{0x37, 0x4b}, vTable, binary.LittleEndian), ShouldBeTrue)
+		So(Verify(vData, []byte{0x37, 0x4b}, vTable, binary.BigEndian), ShouldBeFalse)
+		So(Verify(vData, []byte{0x4b, 0x37}, vTable, binary.BigEndian), ShouldBeTrue)
+		So(Verify(vData, []byte{0x37}, vTable, nil), ShouldBeFalse)
+		So(Verify(vData, []byte{0x37, 0x4b, 0}, vTable, nil), ShouldBeFalse)
+		So(VerifyUint16(vData, CRC16_MODBUS.Check, vTable), ShouldBeTrue)
+		So(VerifyUint16(vData[1:], CRC16_MODBUS.Check, vTable), ShouldBeFalse)
+	})
+}
+
+//-----------------------------------------------------------------------------
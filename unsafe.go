@@ -0,0 +1,45 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+import "unsafe"
+
+//-----------------------------------------------------------------------------
+
+// ChecksumUnsafe returns the checksum of the n bytes of memory at ptr, e.g. a buffer allocated
+// by C code, without copying them.
+//
+// The caller must guarantee that the n bytes are readable and not modified or freed until
+// it returns; a nil ptr is only allowed with a zero n. Memory allocated by Go must be referred
+// to by an unsafe.Pointer kept alive by the caller, never by a uintptr.
+func ChecksumUnsafe(ptr unsafe.Pointer, n int, aTable *TTable) uint16 {
+	if n == 0 {
+		return Checksum(nil, aTable)
+	}
+	return Checksum(unsafe.Slice((*byte)(ptr), n), aTable)
+}
+
+//-----------------------------------------------------------------------------
+
+// UpdateString returns the result of adding the bytes of s to crc, like Update
+// without the allocation and copy of converting s to a byte slice.
+func UpdateString(crc uint16, s string, aTable *TTable) uint16 {
+	return Update(crc, stringBytes(s), aTable)
+}
+
+//--------------------------------------
+
+// ChecksumString returns the checksum of the bytes of s, like Checksum without the allocation
+// and copy of converting s to a byte slice, e.g. for hashing many short string keys.
+func ChecksumString(s string, aTable *TTable) uint16 {
+	return Checksum(stringBytes(s), aTable)
+}
+
+//--------------------------------------
+
+// Returns the bytes of s without copying them. They must not be modified.
+func stringBytes(s string) []byte {
+	return unsafe.Slice(unsafe.StringData(s), len(s))
+}
+
+//-----------------------------------------------------------------------------
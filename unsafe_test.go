@@ -0,0 +1,53 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nocatalogue || crc16_ibm
+
+package crc16
+
+import (
+	"io"
+	"testing"
+	"unsafe"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestChecksumUnsafe(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(TAlgo{Poly: 0x1021, Init: 0xFFFF})
+		vData := []byte("123456789")
+		So(ChecksumUnsafe(unsafe.Pointer(&vData[0]), len(vData), vTable), ShouldEqual, 0x29B1)
+		So(ChecksumUnsafe(unsafe.Pointer(&vData[2]), 3, vTable), ShouldEqual, Checksum(vData[2:5], vTable))
+		So(ChecksumUnsafe(nil, 0, vTable), ShouldEqual, 0xFFFF)
+	})
+}
+
+//--------------------------------------
+
+func TestChecksumString(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_ARC)
+		So(ChecksumString("123456789", vTable), ShouldEqual, CRC16_ARC.Check)
+		So(ChecksumString("", vTable), ShouldEqual, Checksum(nil, vTable))
+		vCrc := UpdateString(Init(vTable), "1234", vTable)
+		vCrc = UpdateString(vCrc, "56789", vTable)
+		So(Complete(vCrc, vTable), ShouldEqual, CRC16_ARC.Check)
+
+		vKey := "sensor/42/temperature"
+		So(testing.AllocsPerRun(100, func() { ChecksumString(vKey, vTable) }), ShouldEqual, 0)
+
+		vH := New(vTable)
+		n, err := io.WriteString(vH, "123456789")
+		So(n, ShouldEqual, 9)
+		So(err, ShouldBeNil)
+		So(vH.Sum16(), ShouldEqual, CRC16_ARC.Check)
+		vH = NewText(vTable)
+		io.WriteString(vH, "one\r")
+		io.WriteString(vH, "\ntwo")
+		So(vH.Sum16(), ShouldEqual, ChecksumString("one\ntwo", vTable))
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,87 @@
+//-----------------------------------------------------------------------------
+
+package crc16
+
+//-----------------------------------------------------------------------------
+
+// This file contains the streaming checksum as a value, carrying the table along with
+// the register so that states of different tables cannot be mixed up and the final
+// processing is applied exactly once, by Sum16. Init, Update and Complete remain the
+// primitives it is built on.
+
+// TDigest is the state of a checksum in progress, e.g.
+//
+//	d := vTable.Digest()
+//	d = d.Update(vHeader)
+//	d = d.Update(vPayload)
+//	vSum := d.Sum16()
+//
+// Being a value, it is copied on assignment, so that checksums of data sharing a prefix
+// may continue from a copy. The zero TDigest has no table and must not be used.
+type TDigest struct {
+	table *TTable
+	crc   uint16
+	len   int64
+}
+
+//-----------------------------------------------------------------------------
+
+// Digest returns the TDigest of the table with no data added.
+func (aTable *TTable) Digest() TDigest {
+	return TDigest{table: aTable, crc: Init(aTable)}
+}
+
+//--------------------------------------
+
+// Update returns the digest with the bytes in data added.
+func (aD TDigest) Update(data []byte) TDigest {
+	aD.crc = Update(aD.crc, data, aD.table)
+	aD.len += int64(len(data))
+	return aD
+}
+
+//--------------------------------------
+
+// UpdateString returns the digest with the bytes of s added, without copying them.
+func (aD TDigest) UpdateString(s string) TDigest {
+	return aD.Update(stringBytes(s))
+}
+
+//--------------------------------------
+
+// UpdateByte returns the digest with the byte b added.
+func (aD TDigest) UpdateByte(b byte) TDigest {
+	aD.crc = updateBytes(aD.crc, []byte{b}, aD.table)
+	aD.len++
+	return aD
+}
+
+//--------------------------------------
+
+// Sum16 returns the checksum of the data added, leaving the digest unchanged.
+func (aD TDigest) Sum16() uint16 {
+	return Complete(aD.crc, aD.table)
+}
+
+//--------------------------------------
+
+// Table returns the table of the digest.
+func (aD TDigest) Table() *TTable {
+	return aD.table
+}
+
+//--------------------------------------
+
+// Len returns the number of bytes added.
+func (aD TDigest) Len() int64 {
+	return aD.len
+}
+
+//--------------------------------------
+
+// Reset returns the digest of the same table with no data added.
+func (aD TDigest) Reset() TDigest {
+	return aD.table.Digest()
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,46 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_nocatalogue || crc16_ccitt
+
+package crc16
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestDigest(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		for _, vAlgo := range []TAlgo{CRC16_KERMIT, CRC16_XMODEM} {
+			vTable := MakeTable(vAlgo)
+			d := vTable.Digest()
+			So(d.Table(), ShouldEqual, vTable)
+			So(d.Sum16(), ShouldEqual, Checksum(nil, vTable))
+
+			vPrefix := d.Update([]byte("1234"))
+			d = vPrefix.UpdateString("56").UpdateByte('7').Update([]byte("89"))
+			So(d.Sum16(), ShouldEqual, vAlgo.Check)
+			So(d.Sum16(), ShouldEqual, vAlgo.Check)
+			So(d.Len(), ShouldEqual, 9)
+
+			// The prefix is unaffected and may be continued differently.
+			So(vPrefix.Len(), ShouldEqual, 4)
+			So(vPrefix.Update([]byte("abc")).Sum16(), ShouldEqual, Checksum([]byte("1234abc"), vTable))
+
+			d = d.Reset()
+			So(d.Len(), ShouldEqual, 0)
+			So(d.Sum16(), ShouldEqual, Checksum(nil, vTable))
+
+			vLong := make([]byte, 1000)
+			for i := range vLong {
+				vLong[i] = byte(i * 7)
+			}
+			So(d.Update(vLong).Sum16(), ShouldEqual, Checksum(vLong, vTable))
+		}
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,139 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package crc16
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+//-----------------------------------------------------------------------------
+
+// This file contains the export of golden test vectors for validating other implementations
+// of the algorithms against the package.
+
+// TVector is a golden test vector: a message and its checksum. Implementations feeding
+// the message in two updates split at any of Splits must produce the same checksum.
+type TVector struct {
+	Name   string
+	Data   []byte
+	Crc    uint16
+	Splits []int
+}
+
+// tJSONAlgoVectors are the test vectors of an algorithm as written by WriteVectors.
+type tJSONAlgoVectors struct {
+	Name    string        `json:"name"`
+	Width   int           `json:"width"`
+	Poly    uint16        `json:"poly"`
+	Init    uint16        `json:"init"`
+	RefIn   bool          `json:"refin"`
+	RefOut  bool          `json:"refout"`
+	XorOut  uint16        `json:"xorout"`
+	Check   uint16        `json:"check"`
+	Vectors []tJSONVector `json:"vectors"`
+}
+
+// tJSONVector is a test vector as written by WriteVectors, with the data in hexadecimal.
+type tJSONVector struct {
+	Name   string `json:"name"`
+	Len    int    `json:"len"`
+	Data   string `json:"data"`
+	Crc    uint16 `json:"crc"`
+	Splits []int  `json:"splits"`
+}
+
+//-----------------------------------------------------------------------------
+
+// Returns n pseudo-random bytes, the same on every platform and release.
+func vectorBytes(n int, aSeed uint32) []byte {
+	vRet := make([]byte, n)
+	for i := range vRet {
+		aSeed = aSeed*1664525 + 1013904223
+		vRet[i] = byte(aSeed >> 24)
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Returns n copies of the byte b.
+func vectorFill(n int, b byte) []byte {
+	vRet := make([]byte, n)
+	for i := range vRet {
+		vRet[i] = b
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// Returns the offsets the incremental update of n bytes is split at: the boundaries,
+// the first and last byte, the middle and the word boundaries of slicing implementations.
+func vectorSplits(n int) []int {
+	vRet := []int{}
+	for _, s := range []int{0, 1, 4, 8, n / 2, n - 1, n} {
+		if s >= 0 && s <= n && (len(vRet) == 0 || s > vRet[len(vRet)-1]) {
+			vRet = append(vRet, s)
+		}
+	}
+	return vRet
+}
+
+//-----------------------------------------------------------------------------
+
+// Vectors returns the golden test vectors of the algorithm: the empty message, the check
+// message, boundary byte values, runs of zeros and ones and pseudo-random messages of
+// lengths around the word and block sizes of common implementations.
+func Vectors(aAlgo TAlgo) []TVector {
+	type tCase struct {
+		name string
+		data []byte
+	}
+	vCases := []tCase{
+		{"empty", []byte{}},
+		{"check", []byte("123456789")},
+		{"byte-00", []byte{0x00}},
+		{"byte-01", []byte{0x01}},
+		{"byte-80", []byte{0x80}},
+		{"byte-ff", []byte{0xff}},
+		{"zeros-2", vectorFill(2, 0x00)},
+		{"zeros-16", vectorFill(16, 0x00)},
+		{"ones-2", vectorFill(2, 0xff)},
+		{"ones-16", vectorFill(16, 0xff)},
+	}
+	for i, n := range []int{3, 7, 8, 15, 16, 17, 31, 32, 33, 63, 64, 65, 255, 256, 257, 1024} {
+		vCases = append(vCases, tCase{"random-" + strconv.Itoa(n), vectorBytes(n, uint32(i)+1)})
+	}
+
+	vTable := MakeTable(aAlgo)
+	vRet := make([]TVector, 0, len(vCases))
+	for _, c := range vCases {
+		vRet = append(vRet, TVector{c.name, c.data, Checksum(c.data, vTable), vectorSplits(len(c.data))})
+	}
+	return vRet
+}
+
+//--------------------------------------
+
+// WriteVectors writes the golden test vectors of the algorithms as a JSON array with an object
+// per algorithm, holding its parameters and its vectors with the data in hexadecimal.
+func WriteVectors(w io.Writer, aAlgos []TAlgo) error {
+	vSets := make([]tJSONAlgoVectors, 0, len(aAlgos))
+	for _, a := range aAlgos {
+		vSet := tJSONAlgoVectors{a.Name, 16, a.Poly, a.Init, a.RefIn, a.RefOut, a.XorOut, a.Check, nil}
+		for _, v := range Vectors(a) {
+			vSet.Vectors = append(vSet.Vectors, tJSONVector{v.Name, len(v.Data), hex.EncodeToString(v.Data), v.Crc, v.Splits})
+		}
+		vSets = append(vSets, vSet)
+	}
+	vEnc := json.NewEncoder(w)
+	vEnc.SetIndent("", "  ")
+	return vEnc.Encode(vSets)
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,58 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || (crc16_ccitt && crc16_ibm))
+
+package crc16
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestVectors(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vVectors := Vectors(CRC16_MODBUS)
+		So(vVectors[0].Name, ShouldEqual, "empty")
+		So(vVectors[0].Crc, ShouldEqual, 0xffff)
+		So(vVectors[0].Splits, ShouldResemble, []int{0})
+		So(vVectors[1].Crc, ShouldEqual, CRC16_MODBUS.Check)
+		So(vVectors[1].Splits, ShouldResemble, []int{0, 1, 4, 8, 9})
+
+		vTable := MakeTable(CRC16_MODBUS)
+		for _, v := range vVectors {
+			for _, s := range v.Splits {
+				So(Complete(Update(Update(Init(vTable), v.Data[:s], vTable), v.Data[s:], vTable), vTable), ShouldEqual, v.Crc)
+			}
+		}
+		So(Vectors(CRC16_MODBUS), ShouldResemble, vVectors)
+
+		var vB bytes.Buffer
+		So(WriteVectors(&vB, []TAlgo{CRC16_MODBUS, CRC16_XMODEM}), ShouldBeNil)
+		var vSets []struct {
+			Name    string
+			Width   int
+			Poly    uint16
+			Vectors []struct {
+				Name string
+				Len  int
+				Data string
+				Crc  uint16
+			}
+		}
+		So(json.Unmarshal(vB.Bytes(), &vSets), ShouldBeNil)
+		So(len(vSets), ShouldEqual, 2)
+		So(vSets[1].Name, ShouldEqual, CRC16_XMODEM.Name)
+		So(vSets[1].Width, ShouldEqual, 16)
+		So(vSets[1].Poly, ShouldEqual, 0x1021)
+		So(vSets[1].Vectors[1].Data, ShouldEqual, "313233343536373839")
+		So(vSets[1].Vectors[1].Crc, ShouldEqual, CRC16_XMODEM.Check)
+		So(vSets[1].Vectors[len(vSets[1].Vectors)-1].Len, ShouldEqual, 1024)
+	})
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,57 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package crc16
+
+import "encoding/binary"
+
+//-----------------------------------------------------------------------------
+
+// This file contains the checksumming of words, e.g. register dumps and sample buffers,
+// as serialized in a given byte order but without serializing them into a buffer first.
+
+// cWordChunk is the number of bytes serialized at a time.
+const cWordChunk = 256
+
+//-----------------------------------------------------------------------------
+
+// Update16 returns the result of adding the words in data, serialized in the specified byte
+// order, big-endian if nil, to the crc.
+func Update16(crc uint16, data []uint16, aTable *TTable, aOrder binary.ByteOrder) uint16 {
+	if aOrder == nil {
+		aOrder = binary.BigEndian
+	}
+	var vBuf [cWordChunk]byte
+	for len(data) > 0 {
+		n := min(len(data), len(vBuf)/2)
+		for i, w := range data[:n] {
+			aOrder.PutUint16(vBuf[2*i:], w)
+		}
+		crc = Update(crc, vBuf[:2*n], aTable)
+		data = data[n:]
+	}
+	return crc
+}
+
+//--------------------------------------
+
+// Update32 returns the result of adding the words in data, serialized in the specified byte
+// order, big-endian if nil, to the crc.
+func Update32(crc uint16, data []uint32, aTable *TTable, aOrder binary.ByteOrder) uint16 {
+	if aOrder == nil {
+		aOrder = binary.BigEndian
+	}
+	var vBuf [cWordChunk]byte
+	for len(data) > 0 {
+		n := min(len(data), len(vBuf)/4)
+		for i, w := range data[:n] {
+			aOrder.PutUint32(vBuf[4*i:], w)
+		}
+		crc = Update(crc, vBuf[:4*n], aTable)
+		data = data[n:]
+	}
+	return crc
+}
+
+//-----------------------------------------------------------------------------
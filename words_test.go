@@ -0,0 +1,49 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || crc16_ibm)
+
+package crc16
+
+import (
+	"encoding/binary"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestUpdateWords(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_MODBUS)
+		v16 := make([]uint16, 300)
+		v32 := make([]uint32, 300)
+		for i := range v16 {
+			v16[i] = uint16(uint32(i) * 2654435761)
+			v32[i] = uint32(i) * 2654435761
+		}
+		for _, vOrder := range []interface {
+			binary.ByteOrder
+			binary.AppendByteOrder
+		}{binary.BigEndian, binary.LittleEndian} {
+			var vBytes []byte
+			for _, w := range v16 {
+				vBytes = vOrder.AppendUint16(vBytes, w)
+			}
+			So(Complete(Update16(Init(vTable), v16, vTable, vOrder), vTable), ShouldEqual, Checksum(vBytes, vTable))
+
+			vBytes = vBytes[:0]
+			for _, w := range v32 {
+				vBytes = vOrder.AppendUint32(vBytes, w)
+			}
+			So(Complete(Update32(Init(vTable), v32, vTable, vOrder), vTable), ShouldEqual, Checksum(vBytes, vTable))
+		}
+
+		// Modbus holding registers are transmitted big-endian.
+		vCrc := Update16(Init(vTable), []uint16{0x0103, 0x0000, 0x000a}, vTable, nil)
+		So(Complete(vCrc, vTable), ShouldEqual, 0xcdc5)
+		So(Update32(1234, nil, vTable, nil), ShouldEqual, 1234)
+	})
+}
+
+//-----------------------------------------------------------------------------
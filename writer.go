@@ -0,0 +1,75 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny
+
+package crc16
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+//-----------------------------------------------------------------------------
+
+// TWriter forwards writes to an underlying writer while computing the checksum of the bytes
+// written, so that frames can be streamed out and their checksum appended without buffering them.
+type TWriter struct {
+	writer io.Writer
+	table  *TTable
+	crc    uint16
+	length int64
+}
+
+//-----------------------------------------------------------------------------
+
+// NewWriter returns a TWriter writing to w and computing the checksum with the specified table.
+func NewWriter(w io.Writer, aTable *TTable) *TWriter {
+	return &TWriter{writer: w, table: aTable, crc: Init(aTable)}
+}
+
+//--------------------------------------
+
+// Write writes p to the underlying writer and adds the bytes it accepted to the checksum.
+func (aW *TWriter) Write(p []byte) (int, error) {
+	n, err := aW.writer.Write(p)
+	aW.crc = Update(aW.crc, p[:n], aW.table)
+	aW.length += int64(n)
+	return n, err
+}
+
+//--------------------------------------
+
+// Sum16 returns the checksum of the bytes written since the writer was created or reset.
+func (aW *TWriter) Sum16() uint16 {
+	return Complete(aW.crc, aW.table)
+}
+
+//--------------------------------------
+
+// Len returns the number of bytes written since the writer was created or reset.
+func (aW *TWriter) Len() int64 {
+	return aW.length
+}
+
+//--------------------------------------
+
+// Reset starts a new checksum without writing anything.
+func (aW *TWriter) Reset() {
+	aW.crc, aW.length = Init(aW.table), 0
+}
+
+//--------------------------------------
+
+// WriteSum writes the checksum of the bytes written since the writer was created or reset
+// to the underlying writer as a 2-byte trailer in the specified byte order, the conventional
+// order of the algorithm if nil, and resets the writer for the next frame. The trailer
+// is not added to the checksum.
+func (aW *TWriter) WriteSum(aOrder binary.ByteOrder) error {
+	var vTrailer [2]byte
+	trailerOrder(aOrder, aW.table).PutUint16(vTrailer[:], aW.Sum16())
+	aW.Reset()
+	_, err := aW.writer.Write(vTrailer[:])
+	return err
+}
+
+//-----------------------------------------------------------------------------
@@ -0,0 +1,63 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || crc16_ibm)
+
+package crc16
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+// tShortWriter accepts up to its number of bytes per write.
+type tShortWriter int
+
+//-----------------------------------------------------------------------------
+
+func (aW tShortWriter) Write(p []byte) (int, error) {
+	if len(p) > int(aW) {
+		return int(aW), io.ErrShortWrite
+	}
+	return len(p), nil
+}
+
+//-----------------------------------------------------------------------------
+
+func TestWriter(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vTable := MakeTable(CRC16_MODBUS)
+		var vOut bytes.Buffer
+		vW := NewWriter(&vOut, vTable)
+		So(vW.Sum16(), ShouldEqual, Checksum(nil, vTable))
+
+		vW.Write([]byte{0x01, 0x03})
+		vW.Write([]byte{0x00, 0x00, 0x00, 0x0a})
+		So(vW.Len(), ShouldEqual, 6)
+		So(vW.Sum16(), ShouldEqual, 0xcdc5)
+		So(vW.WriteSum(nil), ShouldBeNil)
+		So(vW.Len(), ShouldEqual, 0)
+
+		vW.Write([]byte("123456789"))
+		So(vW.WriteSum(binary.BigEndian), ShouldBeNil)
+		So(vOut.Bytes(), ShouldResemble, []byte{0x01, 0x03, 0x00, 0x00, 0x00, 0x0a, 0xc5, 0xcd, '1', '2', '3', '4', '5', '6', '7', '8', '9', 0x4b, 0x37})
+
+		vW.Write([]byte("discarded"))
+		vW.Reset()
+		So(vW.Sum16(), ShouldEqual, Checksum(nil, vTable))
+
+		// Only the bytes accepted by the underlying writer are checksummed.
+		vW = NewWriter(tShortWriter(4), vTable)
+		n, err := vW.Write([]byte("123456789"))
+		So(err, ShouldEqual, io.ErrShortWrite)
+		So(n, ShouldEqual, 4)
+		So(vW.Sum16(), ShouldEqual, Checksum([]byte("1234"), vTable))
+	})
+}
+
+//-----------------------------------------------------------------------------
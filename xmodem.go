@@ -0,0 +1,102 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || crc16_ccitt)
+
+package crc16
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"strconv"
+)
+
+//-----------------------------------------------------------------------------
+
+// This file contains the framing of XMODEM-CRC and YMODEM packets: a start byte, SOH for
+// 128 bytes of payload or STX for 1024, the block number and its complement, the payload
+// and its CRC-16/XMODEM, most significant byte first.
+
+// XMODEM constants.
+const (
+	cXModemSOH   = 0x01
+	cXModemSTX   = 0x02
+	cXModemSUB   = 0x1a
+	cXModemShort = 128
+	cXModemLong  = 1024
+)
+
+// XModemBlockError reports a packet received intact with another block number than expected.
+// A packet repeating the previous block, as sent when an acknowledgement was lost,
+// is to be acknowledged and discarded; any other one means the transfer is out of sync.
+type XModemBlockError struct {
+	Block    byte // Block number of the packet.
+	Expected byte // Block number expected.
+}
+
+//-----------------------------------------------------------------------------
+
+// Error returns the description of the block number mismatch.
+func (aE *XModemBlockError) Error() string {
+	return "crc16: XMODEM block " + strconv.Itoa(int(aE.Block)) + " received, expected " + strconv.Itoa(int(aE.Expected))
+}
+
+//--------------------------------------
+
+// Duplicate reports whether the packet repeats the block preceding the expected one.
+func (aE *XModemBlockError) Duplicate() bool {
+	return aE.Block == aE.Expected-1
+}
+
+//-----------------------------------------------------------------------------
+
+// AppendXModemPacket appends the XMODEM-CRC packet carrying the payload as the block numbered
+// aBlock to dst and returns the extended slice. Payloads of up to 128 bytes are sent in
+// a packet starting with SOH and longer ones, of up to 1024 bytes, with STX, padded with
+// SUB bytes to that length. It panics if the payload is longer.
+func AppendXModemPacket(dst []byte, aBlock byte, aPayload []byte) []byte {
+	vStart, vSize := byte(cXModemSOH), cXModemShort
+	switch {
+	case len(aPayload) > cXModemLong:
+		panic("crc16: XMODEM payload longer than 1024 bytes")
+	case len(aPayload) > cXModemShort:
+		vStart, vSize = cXModemSTX, cXModemLong
+	}
+	dst = append(dst, vStart, aBlock, ^aBlock)
+	vData := len(dst)
+	dst = append(dst, aPayload...)
+	dst = append(dst, bytes.Repeat([]byte{cXModemSUB}, vSize-len(aPayload))...)
+	return binary.BigEndian.AppendUint16(dst, Checksum(dst[vData:], Table(CRC16_XMODEM)))
+}
+
+//--------------------------------------
+
+// ParseXModemPacket returns the payload of the XMODEM-CRC packet, padding included, sharing
+// its memory, if the packet is well-formed, its CRC matches and it is the block numbered aBlock.
+// A CRC mismatch is reported as a *ChecksumError and an unexpected block number as
+// a *XModemBlockError; other errors mean the packet is malformed.
+func ParseXModemPacket(packet []byte, aBlock byte) ([]byte, error) {
+	if len(packet) < 3 || (packet[0] != cXModemSOH && packet[0] != cXModemSTX) {
+		return nil, errors.New("crc16: not an XMODEM-CRC packet")
+	}
+	vSize := cXModemShort
+	if packet[0] == cXModemSTX {
+		vSize = cXModemLong
+	}
+	if len(packet) != 3+vSize+2 {
+		return nil, errors.New("crc16: XMODEM packet of wrong length")
+	}
+	if packet[2] != ^packet[1] {
+		return nil, errors.New("crc16: XMODEM block number and its complement disagree")
+	}
+	if err := CheckTrailer(packet[3:], Table(CRC16_XMODEM), binary.BigEndian); err != nil {
+		err.(*ChecksumError).Offset += 3
+		return nil, err
+	}
+	if packet[1] != aBlock {
+		return nil, &XModemBlockError{Block: packet[1], Expected: aBlock}
+	}
+	return packet[3 : 3+vSize], nil
+}
+
+//-----------------------------------------------------------------------------
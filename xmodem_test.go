@@ -0,0 +1,68 @@
+//-----------------------------------------------------------------------------
+
+//go:build !crc16_tiny && (!crc16_nocatalogue || crc16_ccitt)
+
+package crc16
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+//-----------------------------------------------------------------------------
+
+func TestXModemPacket(aT *testing.T) {
+	Convey(funcName(), aT, func() {
+		vPayload := []byte("firmware image, first block")
+		vPacket := AppendXModemPacket(nil, 1, vPayload)
+		So(len(vPacket), ShouldEqual, 3+128+2)
+		So(vPacket[:3], ShouldResemble, []byte{0x01, 0x01, 0xfe})
+		So(vPacket[3+len(vPayload):3+128], ShouldResemble, bytes.Repeat([]byte{0x1a}, 128-len(vPayload)))
+		So(Checksum(vPacket[3:], MakeTable(CRC16_XMODEM)), ShouldEqual, 0)
+
+		vData, err := ParseXModemPacket(vPacket, 1)
+		So(err, ShouldBeNil)
+		So(vData, ShouldResemble, vPacket[3:131])
+
+		vLong := AppendXModemPacket([]byte{0xff}, 0, bytes.Repeat([]byte{0x55}, 1024))[1:]
+		So(len(vLong), ShouldEqual, 3+1024+2)
+		So(vLong[0], ShouldEqual, 0x02)
+		vData, err = ParseXModemPacket(vLong, 0)
+		So(err, ShouldBeNil)
+		So(len(vData), ShouldEqual, 1024)
+		So(len(AppendXModemPacket(nil, 2, make([]byte, 129))), ShouldEqual, 3+1024+2)
+		So(func() { AppendXModemPacket(nil, 2, make([]byte, 1025)) }, ShouldPanic)
+
+		_, err = ParseXModemPacket(vPacket, 2)
+		var vBlockErr *XModemBlockError
+		So(errors.As(err, &vBlockErr), ShouldBeTrue)
+		So(vBlockErr.Block, ShouldEqual, 1)
+		So(vBlockErr.Duplicate(), ShouldBeTrue)
+		_, err = ParseXModemPacket(vPacket, 3)
+		So(errors.As(err, &vBlockErr), ShouldBeTrue)
+		So(vBlockErr.Duplicate(), ShouldBeFalse)
+
+		vPacket[40] ^= 0x08
+		_, err = ParseXModemPacket(vPacket, 1)
+		So(errors.Is(err, ErrChecksumMismatch), ShouldBeTrue)
+		var vSumErr *ChecksumError
+		So(errors.As(err, &vSumErr), ShouldBeTrue)
+		So(vSumErr.Offset, ShouldEqual, 3+128)
+		vPacket[40] ^= 0x08
+
+		vPacket[2] ^= 1
+		_, err = ParseXModemPacket(vPacket, 1)
+		So(err, ShouldNotBeNil)
+		So(errors.Is(err, ErrChecksumMismatch), ShouldBeFalse)
+		vPacket[2] ^= 1
+		for _, vBad := range [][]byte{nil, {0x04}, vPacket[:100], append([]byte{0x03}, vPacket[1:]...)} {
+			_, err = ParseXModemPacket(vBad, 1)
+			So(err, ShouldNotBeNil)
+		}
+	})
+}
+
+//-----------------------------------------------------------------------------